@@ -25,6 +25,9 @@ const (
 // errMissingGoMod indicates an empty gomod field
 var errMissingGoMod = errors.New("missing gomod specification for module")
 
+// errMissingNameForSchema indicates an empty name while the schema must be generated
+var errMissingNameForSchema = errors.New("missing name of the distribution, required to generate the configuration schema")
+
 // Config holds the builder's configuration
 type Config struct {
 	Logger *zap.Logger
@@ -73,6 +76,8 @@ type Distribution struct {
 	Version          string `mapstructure:"version"`
 	BuildTags        string `mapstructure:"build_tags"`
 	DebugCompilation bool   `mapstructure:"debug_compilation"`
+	// SchemaPath is the path to write the JSON Schema of the distribution's configuration to, if set.
+	SchemaPath string `mapstructure:"schema_path"`
 }
 
 // Module represents a receiver, exporter, processor or extension for the distribution
@@ -143,9 +148,18 @@ func (c *Config) Validate() error {
 		validateModules("connector", c.Connectors),
 		validateModules("provider", c.ConfmapProviders),
 		validateModules("converter", c.ConfmapConverters),
+		c.validateSchemaPath(),
 	)
 }
 
+// validateSchemaPath checks that the name of the binary, which generates the schema, is known.
+func (c *Config) validateSchemaPath() error {
+	if c.Distribution.SchemaPath != "" && c.Distribution.Name == "" {
+		return errMissingNameForSchema
+	}
+	return nil
+}
+
 // SetGoPath sets go path
 func (c *Config) SetGoPath() error {
 	if !c.SkipCompilation || !c.SkipGetModules {
@@ -282,6 +282,19 @@ func TestMissingModule(t *testing.T) {
 	}
 }
 
+func TestSchemaPathWithoutName(t *testing.T) {
+	cfg := Config{
+		Logger: zap.NewNop(),
+		Distribution: Distribution{
+			SchemaPath: "schema.json",
+		},
+	}
+	require.ErrorIs(t, cfg.Validate(), errMissingNameForSchema)
+
+	cfg.Distribution.Name = "otelcol-custom"
+	require.NoError(t, cfg.Validate())
+}
+
 func TestNewDefaultConfig(t *testing.T) {
 	cfg, err := NewDefaultConfig()
 	require.NoError(t, err)
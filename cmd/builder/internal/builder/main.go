@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"text/template"
 	"time"
@@ -26,6 +27,7 @@ var (
 	ErrVersionMismatch = errors.New("mismatch in go.mod and builder configuration versions")
 	errDownloadFailed  = errors.New("failed to download go modules")
 	errCompileFailed   = errors.New("failed to compile the OpenTelemetry Collector distribution")
+	errSchemaFailed    = errors.New("failed to generate the configuration schema of the OpenTelemetry Collector distribution")
 	skipStrictMsg      = "Use --skip-strict-versioning to temporarily disable this check. This flag will be removed in a future minor version"
 )
 
@@ -65,7 +67,11 @@ func GenerateAndCompile(cfg *Config) error {
 		return err
 	}
 
-	return Compile(cfg)
+	if err := Compile(cfg); err != nil {
+		return err
+	}
+
+	return GenerateSchema(cfg)
 }
 
 // Generate assembles a new distribution based on the given configuration
@@ -142,6 +148,40 @@ func Compile(cfg *Config) error {
 	return nil
 }
 
+// GenerateSchema writes the JSON Schema of the configuration of the compiled distribution, with the configuration
+// of all its components, to the schema path of the configuration, if set.
+func GenerateSchema(cfg *Config) error {
+	if cfg.Distribution.SchemaPath == "" {
+		return nil
+	}
+	if cfg.SkipCompilation {
+		cfg.Logger.Info("The distribution is not compiled, the configuration schema will not be generated.")
+		return nil
+	}
+	if goos, goarch := os.Getenv("GOOS"), os.Getenv("GOARCH"); (goos != "" && goos != runtime.GOOS) || (goarch != "" && goarch != runtime.GOARCH) {
+		cfg.Logger.Info("The distribution is compiled for another platform, the configuration schema will not be generated.")
+		return nil
+	}
+
+	cfg.Logger.Info("Generating the configuration schema")
+
+	// The schema is generated by the distribution itself, from the config structs of its components.
+	//nolint:gosec // #nosec G204 -- the binary is the distribution that was just compiled
+	cmd := exec.Command(filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name), "config", "schema")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %w, error message: %s", errSchemaFailed, err, stderr.String())
+	}
+	if err := os.WriteFile(cfg.Distribution.SchemaPath, stdout.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("%w: %w", errSchemaFailed, err)
+	}
+	cfg.Logger.Info("Configuration schema generated", zap.String("path", cfg.Distribution.SchemaPath))
+
+	return nil
+}
+
 // GetModules retrieves the go modules, updating go.mod and go.sum in the process
 func GetModules(cfg *Config) error {
 	if cfg.SkipGetModules {
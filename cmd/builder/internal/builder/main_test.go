@@ -94,6 +94,7 @@ var replaceModules = []string{
 	"/pdata",
 	"/pdata/testdata",
 	"/pdata/pprofile",
+	"/pdata/xpdata",
 	"/pipeline",
 	"/pipeline/xpipeline",
 	"/processor",
@@ -312,6 +313,17 @@ func TestGenerateAndCompile(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			name: "Schema generation",
+			cfgBuilder: func(t *testing.T) *Config {
+				cfg := newTestConfig(t)
+				cfg.Distribution.OutputPath = t.TempDir()
+				cfg.Distribution.Name = "otelcol-schema"
+				cfg.Distribution.SchemaPath = filepath.Join(t.TempDir(), "schema.json")
+				cfg.Replaces = append(cfg.Replaces, replaces...)
+				return cfg
+			},
+		},
 		{
 			name: "ConfResolverDefaultURIScheme set",
 			cfgBuilder: func(t *testing.T) *Config {
@@ -333,6 +345,11 @@ func TestGenerateAndCompile(t *testing.T) {
 			assert.NoError(t, cfg.SetGoPath())
 			assert.NoError(t, cfg.ParseModules())
 			require.NoError(t, GenerateAndCompile(cfg))
+			if cfg.Distribution.SchemaPath != "" {
+				schema, err := os.ReadFile(cfg.Distribution.SchemaPath)
+				require.NoError(t, err)
+				assert.Contains(t, string(schema), `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+			}
 		})
 	}
 }
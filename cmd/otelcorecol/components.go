@@ -12,12 +12,18 @@ import (
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
+	basicauthextension "go.opentelemetry.io/collector/extension/basicauthextension"
+	bearertokenauthextension "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+	filestorageextension "go.opentelemetry.io/collector/extension/filestorageextension"
 	memorylimiterextension "go.opentelemetry.io/collector/extension/memorylimiterextension"
+	ratelimiterextension "go.opentelemetry.io/collector/extension/ratelimiterextension"
+	resilienceextension "go.opentelemetry.io/collector/extension/resilienceextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	samplingprocessor "go.opentelemetry.io/collector/processor/samplingprocessor"
 	"go.opentelemetry.io/collector/receiver"
 	nopreceiver "go.opentelemetry.io/collector/receiver/nopreceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
@@ -28,14 +34,24 @@ func components() (otelcol.Factories, error) {
 	factories := otelcol.Factories{}
 
 	factories.Extensions, err = otelcol.MakeFactoryMap[extension.Factory](
+		basicauthextension.NewFactory(),
+		bearertokenauthextension.NewFactory(),
+		filestorageextension.NewFactory(),
 		memorylimiterextension.NewFactory(),
+		ratelimiterextension.NewFactory(),
+		resilienceextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ExtensionModules = make(map[component.Type]string, len(factories.Extensions))
+	factories.ExtensionModules[basicauthextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/basicauthextension v0.125.0"
+	factories.ExtensionModules[bearertokenauthextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/bearertokenauthextension v0.125.0"
+	factories.ExtensionModules[filestorageextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/filestorageextension v0.125.0"
 	factories.ExtensionModules[memorylimiterextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/memorylimiterextension v0.125.0"
+	factories.ExtensionModules[ratelimiterextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/ratelimiterextension v0.125.0"
+	factories.ExtensionModules[resilienceextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/resilienceextension v0.125.0"
 	factories.ExtensionModules[zpagesextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/zpagesextension v0.125.0"
 
 	factories.Receivers, err = otelcol.MakeFactoryMap[receiver.Factory](
@@ -67,6 +83,7 @@ func components() (otelcol.Factories, error) {
 	factories.Processors, err = otelcol.MakeFactoryMap[processor.Factory](
 		batchprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
+		samplingprocessor.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
@@ -74,6 +91,7 @@ func components() (otelcol.Factories, error) {
 	factories.ProcessorModules = make(map[component.Type]string, len(factories.Processors))
 	factories.ProcessorModules[batchprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/batchprocessor v0.125.0"
 	factories.ProcessorModules[memorylimiterprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.125.0"
+	factories.ProcessorModules[samplingprocessor.NewFactory().Type()] = "go.opentelemetry.io/collector/processor/samplingprocessor v0.125.0"
 
 	factories.Connectors, err = otelcol.MakeFactoryMap[connector.Factory](
 		forwardconnector.NewFactory(),
@@ -22,12 +22,18 @@ require (
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.125.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.125.0
 	go.opentelemetry.io/collector/extension v1.31.0
+	go.opentelemetry.io/collector/extension/basicauthextension v0.125.0
+	go.opentelemetry.io/collector/extension/bearertokenauthextension v0.125.0
+	go.opentelemetry.io/collector/extension/filestorageextension v0.125.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.125.0
+	go.opentelemetry.io/collector/extension/ratelimiterextension v0.125.0
+	go.opentelemetry.io/collector/extension/resilienceextension v0.125.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.125.0
 	go.opentelemetry.io/collector/otelcol v0.125.0
 	go.opentelemetry.io/collector/processor v1.31.0
 	go.opentelemetry.io/collector/processor/batchprocessor v0.125.0
 	go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.125.0
+	go.opentelemetry.io/collector/processor/samplingprocessor v0.125.0
 	go.opentelemetry.io/collector/receiver v1.31.0
 	go.opentelemetry.io/collector/receiver/nopreceiver v0.125.0
 	go.opentelemetry.io/collector/receiver/otlpreceiver v0.125.0
@@ -113,12 +119,14 @@ require (
 	go.opentelemetry.io/collector/extension/xextension v0.125.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.31.0 // indirect
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.125.0 // indirect
+	go.opentelemetry.io/collector/internal/filewatcher v0.125.0 // indirect
 	go.opentelemetry.io/collector/internal/memorylimiter v0.125.0 // indirect
 	go.opentelemetry.io/collector/internal/sharedcomponent v0.125.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.125.0 // indirect
 	go.opentelemetry.io/collector/pdata v1.31.0 // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.125.0 // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.125.0 // indirect
+	go.opentelemetry.io/collector/pdata/xpdata v0.125.0 // indirect
 	go.opentelemetry.io/collector/pipeline v0.125.0 // indirect
 	go.opentelemetry.io/collector/pipeline/xpipeline v0.125.0 // indirect
 	go.opentelemetry.io/collector/processor/processorhelper v0.125.0 // indirect
@@ -158,6 +166,7 @@ require (
 	go.opentelemetry.io/proto/otlp v1.5.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
 	golang.org/x/net v0.39.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
@@ -250,6 +259,10 @@ replace go.opentelemetry.io/collector/exporter/otlphttpexporter => ../../exporte
 
 replace go.opentelemetry.io/collector/extension => ../../extension
 
+replace go.opentelemetry.io/collector/extension/basicauthextension => ../../extension/basicauthextension
+
+replace go.opentelemetry.io/collector/extension/bearertokenauthextension => ../../extension/bearertokenauthextension
+
 replace go.opentelemetry.io/collector/extension/extensionauth => ../../extension/extensionauth
 
 replace go.opentelemetry.io/collector/extension/extensionauth/extensionauthtest => ../../extension/extensionauth/extensionauthtest
@@ -262,8 +275,14 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmid
 
 replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
 
+replace go.opentelemetry.io/collector/extension/filestorageextension => ../../extension/filestorageextension
+
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
+replace go.opentelemetry.io/collector/extension/ratelimiterextension => ../../extension/ratelimiterextension
+
+replace go.opentelemetry.io/collector/extension/resilienceextension => ../../extension/resilienceextension
+
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
 
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
@@ -278,6 +297,8 @@ replace go.opentelemetry.io/collector/internal/telemetry => ../../internal/telem
 
 replace go.opentelemetry.io/collector/internal/sharedcomponent => ../../internal/sharedcomponent
 
+replace go.opentelemetry.io/collector/internal/filewatcher => ../../internal/filewatcher
+
 replace go.opentelemetry.io/collector/otelcol => ../../otelcol
 
 replace go.opentelemetry.io/collector/pdata => ../../pdata
@@ -298,6 +319,8 @@ replace go.opentelemetry.io/collector/processor/batchprocessor => ../../processo
 
 replace go.opentelemetry.io/collector/processor/memorylimiterprocessor => ../../processor/memorylimiterprocessor
 
+replace go.opentelemetry.io/collector/processor/samplingprocessor => ../../processor/samplingprocessor
+
 replace go.opentelemetry.io/collector/processor/xprocessor => ../../processor/xprocessor
 
 replace go.opentelemetry.io/collector/processor/processorhelper/xprocessorhelper => ../../processor/processorhelper/xprocessorhelper
@@ -321,3 +344,5 @@ replace go.opentelemetry.io/collector/semconv => ../../semconv
 replace go.opentelemetry.io/collector/service => ../../service
 
 replace go.opentelemetry.io/collector/service/hostcapabilities => ../../service/hostcapabilities
+
+replace go.opentelemetry.io/collector/pdata/xpdata => ../../pdata/xpdata
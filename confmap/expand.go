@@ -216,6 +216,7 @@ func (mr *Resolver) expandURI(ctx context.Context, input string) (*Retrieved, er
 		return nil, err
 	}
 	mr.closers = append(mr.closers, ret.Close)
+	mr.expandedURIs = append(mr.expandedURIs, lURI.asString())
 	return ret, nil
 }
 
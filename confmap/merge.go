@@ -4,9 +4,84 @@
 package confmap // import "go.opentelemetry.io/collector/confmap"
 
 import (
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
 )
 
+const (
+	// replaceDirective is the suffix of a key whose value replaces the value of the previous configurations
+	// instead of being merged with it, e.g. `processors!replace: [batch]`.
+	replaceDirective = "!replace"
+	// deleteDirective is the suffix of a key that is removed from the previous configurations, e.g. `debug!delete:`.
+	deleteDirective = "!delete"
+)
+
+// applyMergeDirectives applies the merge directives of the input configuration to the existing config,
+// and returns the input configuration without the directives, ready to be merged.
+//
+// The keys with the deleteDirective suffix are removed from the existing config, their value is ignored.
+// The keys with the replaceDirective suffix are removed from the existing config, so that their value
+// replaces the existing one when merged.
+func (l *Conf) applyMergeDirectives(in *Conf) (*Conf, error) {
+	src := in.ToStringMap()
+	found, err := applyMergeDirectives(src, l, "")
+	if err != nil || !found {
+		return in, err
+	}
+	return NewFromStringMap(src), nil
+}
+
+// applyMergeDirectives removes the directives from the src map, and applies them to dest. It reports whether
+// any directive was found.
+func applyMergeDirectives(src map[string]any, dest *Conf, prefix string) (bool, error) {
+	found := false
+	// Sort the keys to apply the directives and report the conflicts deterministically.
+	for _, key := range slices.Sorted(maps.Keys(src)) {
+		val := src[key]
+		name, directive := splitMergeDirective(key)
+		path := name
+		if prefix != "" {
+			path = prefix + KeyDelimiter + name
+		}
+
+		if directive != "" {
+			found = true
+			for _, other := range []string{name, name + replaceDirective, name + deleteDirective} {
+				if _, ok := src[other]; ok && other != key {
+					return true, fmt.Errorf("conflicting merge directive %q: %q is also set", path+directive, other)
+				}
+			}
+			dest.k.Delete(path)
+			delete(src, key)
+			if directive == deleteDirective {
+				continue
+			}
+			src[name] = val
+		}
+
+		if m, ok := val.(map[string]any); ok {
+			nestedFound, err := applyMergeDirectives(m, dest, path)
+			if err != nil {
+				return true, err
+			}
+			found = found || nestedFound
+		}
+	}
+	return found, nil
+}
+
+func splitMergeDirective(key string) (string, string) {
+	for _, directive := range []string{replaceDirective, deleteDirective} {
+		if name, ok := strings.CutSuffix(key, directive); ok {
+			return name, directive
+		}
+	}
+	return key, ""
+}
+
 func mergeAppend(src, dest map[string]any) error {
 	// mergeAppend recursively merges the src map into the dest map (left to right),
 	// modifying and expanding the dest map in the process.
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"maps"
+	"strings"
+
+	yaml "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+// Origin describes where the value of a configuration key comes from.
+type Origin struct {
+	// URI is the location of the configuration source that set the value, e.g. "file:config.yaml".
+	URI string
+	// Line is the line of the key in the configuration source, or 0 if unknown.
+	Line int
+	// Expanded lists the URIs of the references, e.g. "env:TOKEN", that were expanded in the value.
+	Expanded []string
+	// Overrides lists the origins of the values that were overridden by this value, the most recent first.
+	Overrides []Origin
+}
+
+// Origins returns the origin of every key of the configuration returned by the last successful call to Resolve.
+// Nested keys are separated with KeyDelimiter, like in Conf.AllKeys.
+//
+// The keys added by a Converter have no origin.
+func (mr *Resolver) Origins() map[string]Origin {
+	return maps.Clone(mr.origins)
+}
+
+// recordOrigins records the origin of the keys set by the configuration retrieved from uri.
+func recordOrigins(origins map[string]Origin, uri string, ret *Retrieved, conf *Conf) {
+	var lines map[string]int
+	if ret.isSetString {
+		lines = keyLines(ret.stringRepresentation)
+	}
+	for _, key := range conf.AllKeys() {
+		origin := Origin{URI: uri, Line: lines[key]}
+		if prev, ok := origins[key]; ok {
+			origin.Overrides = append([]Origin{{URI: prev.URI, Line: prev.Line}}, prev.Overrides...)
+		}
+		origins[key] = origin
+	}
+}
+
+// resolveOrigins returns the origins of the keys of the resolved configuration. The keys set by the expansion of
+// a reference to a map inherit the origin of the reference.
+func resolveOrigins(origins map[string]Origin, conf *Conf) map[string]Origin {
+	resolved := make(map[string]Origin, len(origins))
+	for _, key := range conf.AllKeys() {
+		for parent := key; parent != ""; {
+			if origin, ok := origins[parent]; ok {
+				resolved[key] = origin
+				break
+			}
+			i := strings.LastIndex(parent, KeyDelimiter)
+			if i < 0 {
+				break
+			}
+			parent = parent[:i]
+		}
+	}
+	return resolved
+}
+
+// keyLines returns the line of every key of the given YAML document.
+func keyLines(yamlStr string) map[string]int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlStr), &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	lines := make(map[string]int)
+	collectKeyLines(doc.Content[0], "", lines)
+	return lines
+}
+
+func collectKeyLines(node *yaml.Node, prefix string, lines map[string]int) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		// Merge directives are removed before merging, the origin is the key they apply to.
+		name, _ := splitMergeDirective(node.Content[i].Value)
+		key := name
+		if prefix != "" {
+			key = prefix + KeyDelimiter + name
+		}
+		lines[key] = node.Content[i].Line
+		collectKeyLines(node.Content[i+1], key, lines)
+	}
+}
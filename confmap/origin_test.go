@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverOrigins(t *testing.T) {
+	sources := map[string]string{
+		"mock:base.yaml": `receivers:
+  otlp:
+    endpoint: localhost:4317
+exporters:
+  debug:
+    verbosity: detailed
+  otlp:
+    endpoint: collector:4317
+    headers: ${mock:headers.yaml}
+`,
+		"mock:overlay.yaml": `exporters:
+  debug!delete:
+  otlp:
+    endpoint: ${env:ENDPOINT}
+`,
+		"mock:headers.yaml": `authorization: secret`,
+	}
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"mock:base.yaml", "mock:overlay.yaml"},
+		ProviderFactories: []ProviderFactory{
+			newFakeProvider("mock", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				return NewRetrievedFromYAML([]byte(sources[uri]))
+			}),
+			newFakeProvider("env", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved("remote:4317")
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "remote:4317", conf.Get("exporters::otlp::endpoint"))
+
+	assert.Equal(t, map[string]Origin{
+		"receivers::otlp::endpoint": {URI: "mock:base.yaml", Line: 3},
+		"exporters::otlp::endpoint": {
+			URI:       "mock:overlay.yaml",
+			Line:      4,
+			Expanded:  []string{"env:ENDPOINT"},
+			Overrides: []Origin{{URI: "mock:base.yaml", Line: 8}},
+		},
+		"exporters::otlp::headers": {
+			URI:      "mock:base.yaml",
+			Line:     9,
+			Expanded: []string{"mock:headers.yaml"},
+		},
+	}, resolver.Origins())
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestResolverOriginsWithoutYAML(t *testing.T) {
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"mock:first", "mock:second"},
+		ProviderFactories: []ProviderFactory{
+			newFakeProvider("mock", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{"processors": map[string]any{"batch": nil}})
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Origin{
+		"processors::batch": {URI: "mock:second", Overrides: []Origin{{URI: "mock:first"}}},
+	}, resolver.Origins())
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
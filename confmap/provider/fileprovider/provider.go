@@ -12,12 +12,16 @@ import (
 	"path/filepath"
 	"strings"
 
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/confmap"
 )
 
 const schemeName = "file"
 
-type provider struct{}
+type provider struct {
+	logger *zap.Logger
+}
 
 // NewFactory returns a factory for a confmap.Provider that reads the configuration from a file.
 //
@@ -34,26 +38,39 @@ type provider struct{}
 // `file:/path/to/file` - absolute path (unix, windows)
 // `file:c:/path/to/file` - absolute path including drive-letter (windows)
 // `file:c:\path\to\file` - absolute path including drive-letter (windows)
+//
+// If a watcher is given to Retrieve, the file is watched and the watcher is called once its content changed.
+// The symlinks to the file are followed, so that updates of a Kubernetes ConfigMap mount are detected.
 func NewFactory() confmap.ProviderFactory {
 	return confmap.NewProviderFactory(newProvider)
 }
 
-func newProvider(confmap.ProviderSettings) confmap.Provider {
-	return &provider{}
+func newProvider(set confmap.ProviderSettings) confmap.Provider {
+	return &provider{logger: set.Logger}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
 	if !strings.HasPrefix(uri, schemeName+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
 
 	// Clean the path before using it.
-	content, err := os.ReadFile(filepath.Clean(uri[len(schemeName)+1:]))
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
 	}
 
-	return confmap.NewRetrievedFromYAML(content)
+	if watcher == nil {
+		return confmap.NewRetrievedFromYAML(content)
+	}
+
+	fw, err := newFileWatcher(path, content, watcher)
+	if err != nil {
+		fmp.logger.Warn("Failed to watch the file, changes will not be detected", zap.String("uri", uri), zap.Error(err))
+		return confmap.NewRetrievedFromYAML(content)
+	}
+	return confmap.NewRetrievedFromYAML(content, confmap.WithRetrievedClose(fw.close))
 }
 
 func (*provider) Scheme() string {
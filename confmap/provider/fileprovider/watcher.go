@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileprovider // import "go.opentelemetry.io/collector/confmap/provider/fileprovider"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// debounceDelay is the time to wait after the last change before reading the file again.
+// Editors and Kubernetes ConfigMap mounts update files in several steps.
+var debounceDelay = 250 * time.Millisecond
+
+// fileWatcher notifies the watcher once the content of the file changed.
+//
+// The parent directories of the file and of its symlink target are watched instead of the file itself,
+// so that files replaced by a rename (as most editors do) or through a symlink swap (as Kubernetes does
+// when a ConfigMap is updated) are detected.
+type fileWatcher struct {
+	path     string
+	realPath string
+	content  []byte
+	onChange confmap.WatcherFunc
+
+	watcher   *fsnotify.Watcher
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newFileWatcher(path string, content []byte, onChange confmap.WatcherFunc) (*fileWatcher, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	fw := &fileWatcher{
+		path:     path,
+		realPath: realPath,
+		content:  content,
+		onChange: onChange,
+		watcher:  watcher,
+		done:     make(chan struct{}),
+	}
+	if err = fw.watcher.Add(filepath.Dir(path)); err != nil {
+		return nil, errors.Join(err, watcher.Close())
+	}
+	if err = fw.watcher.Add(filepath.Dir(realPath)); err != nil {
+		return nil, errors.Join(err, watcher.Close())
+	}
+
+	go fw.run()
+	return fw, nil
+}
+
+func (fw *fileWatcher) run() {
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+
+	reset := func() {
+		if debounce == nil {
+			debounce = time.NewTimer(debounceDelay)
+			debounceC = debounce.C
+			return
+		}
+		debounce.Reset(debounceDelay)
+	}
+
+	for {
+		select {
+		case <-fw.done:
+			return
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+			if fw.isRelevant(event) {
+				reset()
+			}
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Some events were lost, check the file again.
+				reset()
+				continue
+			}
+			fw.notify(&confmap.ChangeEvent{Error: fmt.Errorf("failed to watch the file %v: %w", fw.path, err)})
+			return
+		case <-debounceC:
+			fw.updateSymlinkTarget()
+			content, err := os.ReadFile(fw.path)
+			// The file may be missing while it is being replaced, the next event will check it again.
+			if err != nil || bytes.Equal(content, fw.content) {
+				continue
+			}
+			fw.notify(&confmap.ChangeEvent{})
+			return
+		}
+	}
+}
+
+// isRelevant returns whether the event may have changed the content of the file.
+func (fw *fileWatcher) isRelevant(event fsnotify.Event) bool {
+	if event.Name == fw.path || event.Name == fw.realPath {
+		return true
+	}
+	// Any change of the symlinks pointing to the file, e.g. the "..data" symlink of a ConfigMap mount.
+	realPath, err := filepath.EvalSymlinks(fw.path)
+	return err != nil || realPath != fw.realPath
+}
+
+// updateSymlinkTarget watches the new target of the symlink if it changed.
+func (fw *fileWatcher) updateSymlinkTarget() {
+	realPath, err := filepath.EvalSymlinks(fw.path)
+	if err != nil || realPath == fw.realPath {
+		return
+	}
+	oldDir, newDir := filepath.Dir(fw.realPath), filepath.Dir(realPath)
+	fw.realPath = realPath
+	if oldDir == newDir {
+		return
+	}
+	if oldDir != filepath.Dir(fw.path) {
+		// The directory may already be removed, and not watched anymore.
+		_ = fw.watcher.Remove(oldDir)
+	}
+	_ = fw.watcher.Add(newDir)
+}
+
+func (fw *fileWatcher) notify(event *confmap.ChangeEvent) {
+	select {
+	case <-fw.done:
+		// The configuration was already retrieved again, or the provider is shut down.
+	default:
+		fw.onChange(event)
+	}
+}
+
+func (fw *fileWatcher) close(context.Context) error {
+	var err error
+	fw.closeOnce.Do(func() {
+		close(fw.done)
+		err = fw.watcher.Close()
+	})
+	return err
+}
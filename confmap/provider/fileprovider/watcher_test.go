@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func setDebounceDelay(t *testing.T, d time.Duration) {
+	old := debounceDelay
+	debounceDelay = d
+	t.Cleanup(func() { debounceDelay = old })
+}
+
+func retrieveWatched(t *testing.T, path string) (*confmap.Retrieved, <-chan *confmap.ChangeEvent) {
+	events := make(chan *confmap.ChangeEvent, 10)
+	fp := createProvider()
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, ret.Close(context.Background()))
+		assert.NoError(t, fp.Shutdown(context.Background()))
+	})
+	return ret, events
+}
+
+func TestWatchWrite(t *testing.T) {
+	setDebounceDelay(t, 10*time.Millisecond)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: value"), 0o600))
+
+	_, events := retrieveWatched(t, path)
+
+	require.NoError(t, os.WriteFile(path, []byte("key: other"), 0o600))
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+
+	// The watcher is notified only once.
+	require.NoError(t, os.WriteFile(path, []byte("key: again"), 0o600))
+	select {
+	case <-events:
+		t.Fatal("unexpected change event")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatchUnchangedContent(t *testing.T) {
+	setDebounceDelay(t, 10*time.Millisecond)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: value"), 0o600))
+
+	_, events := retrieveWatched(t, path)
+
+	require.NoError(t, os.WriteFile(path, []byte("key: value"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "other.yaml"), []byte("key: other"), 0o600))
+	select {
+	case <-events:
+		t.Fatal("unexpected change event")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatchDebounce(t *testing.T) {
+	setDebounceDelay(t, 200*time.Millisecond)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: value"), 0o600))
+
+	_, events := retrieveWatched(t, path)
+
+	// The file is truncated before being written, only the final content is read.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0o600)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	_, err = f.WriteString("key: other")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+	assert.Empty(t, events)
+}
+
+func TestWatchRename(t *testing.T) {
+	setDebounceDelay(t, 10*time.Millisecond)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: value"), 0o600))
+
+	_, events := retrieveWatched(t, path)
+
+	tmp := filepath.Join(dir, "config.yaml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("key: other"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+}
+
+func TestWatchSymlinkSwap(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires privileges on windows")
+	}
+	setDebounceDelay(t, 10*time.Millisecond)
+
+	// Same layout as a Kubernetes ConfigMap mount:
+	// config.yaml -> ..data/config.yaml, ..data -> ..v1
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v1", "config.yaml"), []byte("key: value"), 0o600))
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), filepath.Join(dir, "config.yaml")))
+
+	_, events := retrieveWatched(t, filepath.Join(dir, "config.yaml"))
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v2", "config.yaml"), []byte("key: other"), 0o600))
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+}
+
+func TestWatchClose(t *testing.T) {
+	setDebounceDelay(t, 10*time.Millisecond)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("key: value"), 0o600))
+
+	ret, events := retrieveWatched(t, path)
+	require.NoError(t, ret.Close(context.Background()))
+
+	require.NoError(t, os.WriteFile(path, []byte("key: other"), 0o600))
+	select {
+	case <-events:
+		t.Fatal("unexpected change event")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -6,6 +6,7 @@ package httpprovider
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,3 +19,9 @@ func TestSupportedScheme(t *testing.T) {
 	assert.Equal(t, "http", fp.Scheme())
 	require.NoError(t, fp.Shutdown(context.Background()))
 }
+
+func TestPollInterval(t *testing.T) {
+	fp := NewFactoryWithOptions(WithPollInterval(time.Minute), WithMaxBackoff(time.Hour), WithTimeout(time.Second)).Create(confmaptest.NewNopProviderSettings())
+	assert.Equal(t, "http", fp.Scheme())
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
@@ -6,6 +6,8 @@
 package httpsprovider // import "go.opentelemetry.io/collector/confmap/provider/httpsprovider"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
 )
@@ -16,10 +18,61 @@ import (
 //
 // To add extra CA certificates you need to install certificates in the system pool. This procedure is operating system
 // dependent. E.g.: on Linux please refer to the `update-ca-trust` command.
+//
+// The configuration is polled for changes when the OTELCOL_HTTP_CONFIG_POLL_INTERVAL environment variable is set
+// to a duration, e.g. "1m".
 func NewFactory() confmap.ProviderFactory {
-	return confmap.NewProviderFactory(newProvider)
+	return NewFactoryWithOptions()
+}
+
+// NewFactoryWithOptions returns a factory for a confmap.Provider like NewFactory, configured with the given options.
+// The options take precedence over the environment variables.
+func NewFactoryWithOptions(opts ...Option) confmap.ProviderFactory {
+	var o options
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return confmap.NewProviderFactory(func(set confmap.ProviderSettings) confmap.Provider {
+		return configurablehttpprovider.New(configurablehttpprovider.HTTPSScheme, set, o.providerOptions...)
+	})
+}
+
+// Option configures the provider created by NewFactoryWithOptions.
+type Option interface {
+	apply(*options)
+}
+
+type options struct {
+	providerOptions []configurablehttpprovider.Option
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(o *options) {
+	f(o)
+}
+
+func withProviderOption(opt configurablehttpprovider.Option) Option {
+	return optionFunc(func(o *options) {
+		o.providerOptions = append(o.providerOptions, opt)
+	})
+}
+
+// WithPollInterval enables polling the configuration at the given interval, and reloading the Collector
+// once the content of the configuration changed. Conditional requests (ETag and If-Modified-Since) are used,
+// so that the server does not need to send the configuration if it is unchanged.
+func WithPollInterval(interval time.Duration) Option {
+	return withProviderOption(configurablehttpprovider.WithPollInterval(interval))
+}
+
+// WithMaxBackoff sets the maximum time to wait between two polls after consecutive failures.
+// The time to wait is doubled after each failure, starting at the poll interval. Defaults to 5 minutes.
+func WithMaxBackoff(maxBackoff time.Duration) Option {
+	return withProviderOption(configurablehttpprovider.WithMaxBackoff(maxBackoff))
 }
 
-func newProvider(set confmap.ProviderSettings) confmap.Provider {
-	return configurablehttpprovider.New(configurablehttpprovider.HTTPSScheme, set)
+// WithTimeout sets the time limit of each HTTP request, including reading the response body.
+// Defaults to 30 seconds, or to the duration set in the OTELCOL_HTTP_CONFIG_TIMEOUT environment variable.
+func WithTimeout(timeout time.Duration) Option {
+	return withProviderOption(configurablehttpprovider.WithTimeout(timeout))
 }
@@ -5,6 +5,7 @@ package httpsprovider
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -15,3 +16,8 @@ func TestSupportedScheme(t *testing.T) {
 	fp := NewFactory().Create(confmaptest.NewNopProviderSettings())
 	assert.Equal(t, "https", fp.Scheme())
 }
+
+func TestPollInterval(t *testing.T) {
+	fp := NewFactoryWithOptions(WithPollInterval(time.Minute), WithMaxBackoff(time.Hour), WithTimeout(time.Second)).Create(confmaptest.NewNopProviderSettings())
+	assert.Equal(t, "https", fp.Scheme())
+}
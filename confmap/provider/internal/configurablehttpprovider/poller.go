@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configurablehttpprovider // import "go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// poller polls the configuration and notifies the watcher once its content changed.
+type poller struct {
+	client     *http.Client
+	uri        string
+	last       fetchResult
+	interval   time.Duration
+	maxBackoff time.Duration
+	onChange   confmap.WatcherFunc
+	logger     *zap.Logger
+
+	cancel context.CancelFunc
+}
+
+func newPoller(client *http.Client, uri string, last fetchResult, interval, maxBackoff time.Duration, onChange confmap.WatcherFunc, logger *zap.Logger) *poller {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &poller{
+		client:     client,
+		uri:        uri,
+		last:       last,
+		interval:   interval,
+		maxBackoff: max(maxBackoff, interval),
+		onChange:   onChange,
+		logger:     logger,
+		cancel:     cancel,
+	}
+	go p.run(ctx)
+	return p
+}
+
+func (p *poller) run(ctx context.Context) {
+	wait := p.interval
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		res, err := fetch(ctx, p.client, p.uri, p.last)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			// Back off exponentially, the server may be overloaded or temporarily unavailable.
+			wait = min(2*wait, p.maxBackoff)
+			p.logger.Warn("Failed to poll the configuration", zap.String("uri", p.uri), zap.Duration("retry_in", wait), zap.Error(err))
+		case res.notModified || bytes.Equal(res.body, p.last.body):
+			wait = p.interval
+			p.last = res
+		default:
+			if ctx.Err() == nil {
+				p.onChange(&confmap.ChangeEvent{})
+			}
+			return
+		}
+		timer.Reset(wait)
+	}
+}
+
+// close stops polling. It does not wait for the watcher to return, since the watcher may be
+// blocked until the configuration is retrieved again, which closes the previous poller.
+func (p *poller) close(context.Context) error {
+	p.cancel()
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configurablehttpprovider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+// configServer serves a configuration, answering conditional requests with its version as ETag.
+type configServer struct {
+	mu       sync.Mutex
+	content  string
+	version  int
+	status   int
+	useETag  bool
+	requests []*http.Request
+}
+
+func (s *configServer) set(content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content = content
+	s.version++
+}
+
+func (s *configServer) setStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *configServer) numRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func (s *configServer) lastRequest() *http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+	if s.useETag {
+		etag := `"v` + strconv.Itoa(s.version) + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(s.content))
+}
+
+func retrievePolled(t *testing.T, url string, opts ...Option) <-chan *confmap.ChangeEvent {
+	events := make(chan *confmap.ChangeEvent, 10)
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), opts...)
+	ret, err := fp.Retrieve(context.Background(), url, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, ret.Close(context.Background()))
+		assert.NoError(t, fp.Shutdown(context.Background()))
+	})
+	return events
+}
+
+func TestPollChangedContent(t *testing.T) {
+	srv := &configServer{content: "key: value", useETag: true}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	events := retrievePolled(t, ts.URL, WithPollInterval(10*time.Millisecond))
+
+	// The configuration is not sent again while it is unchanged.
+	require.Eventually(t, func() bool { return srv.numRequests() > 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, `"v0"`, srv.lastRequest().Header.Get("If-None-Match"))
+	assert.Empty(t, events)
+
+	srv.set("key: other")
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+
+	// The watcher is notified only once.
+	numRequests := srv.numRequests()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, numRequests, srv.numRequests())
+}
+
+func TestPollWithoutValidators(t *testing.T) {
+	srv := &configServer{content: "key: value"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	events := retrievePolled(t, ts.URL, WithPollInterval(10*time.Millisecond))
+
+	// The same content is sent again, the watcher is not notified.
+	require.Eventually(t, func() bool { return srv.numRequests() > 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, srv.lastRequest().Header.Get("If-None-Match"))
+	assert.Empty(t, events)
+
+	srv.set("key: other")
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+}
+
+func TestPollLastModified(t *testing.T) {
+	lastModified := time.Now().UTC().Format(http.TimeFormat)
+	var mu sync.Mutex
+	var ifModifiedSince []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if v := r.Header.Get("If-Modified-Since"); v != "" {
+			ifModifiedSince = append(ifModifiedSince, v)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte("key: value"))
+	}))
+	defer ts.Close()
+
+	events := retrievePolled(t, ts.URL, WithPollInterval(10*time.Millisecond))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ifModifiedSince) > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, lastModified, ifModifiedSince[0])
+	mu.Unlock()
+	assert.Empty(t, events)
+}
+
+func TestPollBackoff(t *testing.T) {
+	srv := &configServer{content: "key: value", useETag: true}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	events := retrievePolled(t, ts.URL, WithPollInterval(10*time.Millisecond), WithMaxBackoff(40*time.Millisecond))
+
+	// Failures are not reported to the watcher, the configuration is polled again later.
+	srv.setStatus(http.StatusServiceUnavailable)
+	numRequests := srv.numRequests()
+	require.Eventually(t, func() bool { return srv.numRequests() > numRequests+3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, events)
+
+	srv.setStatus(0)
+	srv.set("key: other")
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+}
+
+func TestPollDisabled(t *testing.T) {
+	srv := &configServer{content: "key: value"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	events := retrievePolled(t, ts.URL)
+
+	srv.set("key: other")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, srv.numRequests())
+	assert.Empty(t, events)
+}
+
+func TestPollClose(t *testing.T) {
+	srv := &configServer{content: "key: value"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), WithPollInterval(10*time.Millisecond))
+	events := make(chan *confmap.ChangeEvent, 10)
+	ret, err := fp.Retrieve(context.Background(), ts.URL, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	require.NoError(t, ret.Close(context.Background()))
+
+	srv.set("key: other")
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestPollIntervalFromEnv(t *testing.T) {
+	srv := &configServer{content: "key: value", useETag: true}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	t.Setenv(PollIntervalEnvVar, "10ms")
+	events := retrievePolled(t, ts.URL)
+
+	srv.set("key: other")
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+}
+
+func TestInvalidEnv(t *testing.T) {
+	for _, envVar := range []string{PollIntervalEnvVar, TimeoutEnvVar} {
+		t.Run(envVar, func(t *testing.T) {
+			t.Setenv(envVar, "1 minute")
+			fp := New(HTTPScheme, confmaptest.NewNopProviderSettings())
+			_, err := fp.Retrieve(context.Background(), "http://localhost/config", nil)
+			require.ErrorContains(t, err, envVar)
+			assert.NoError(t, fp.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	block := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-block
+	}))
+	defer ts.Close()
+	defer close(block)
+
+	fp := New(HTTPScheme, confmaptest.NewNopProviderSettings(), WithTimeout(10*time.Millisecond))
+	_, err := fp.Retrieve(context.Background(), ts.URL, nil)
+	require.ErrorContains(t, err, "Client.Timeout exceeded")
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
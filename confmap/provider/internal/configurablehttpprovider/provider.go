@@ -14,6 +14,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/confmap"
 )
@@ -25,10 +28,54 @@ const (
 	HTTPSScheme SchemeType = "https"
 )
 
+const (
+	// PollIntervalEnvVar is the environment variable enabling polling the configuration at the given interval,
+	// when the WithPollInterval option is not given. The value is parsed as a time.Duration, e.g. "30s".
+	PollIntervalEnvVar = "OTELCOL_HTTP_CONFIG_POLL_INTERVAL"
+	// TimeoutEnvVar is the environment variable setting the timeout of the HTTP requests,
+	// when the WithTimeout option is not given. The value is parsed as a time.Duration, e.g. "30s".
+	TimeoutEnvVar = "OTELCOL_HTTP_CONFIG_TIMEOUT"
+)
+
 type provider struct {
 	scheme             SchemeType
 	caCertPath         string // Used for tests
 	insecureSkipVerify bool   // Used for tests
+	logger             *zap.Logger
+
+	pollInterval time.Duration
+	maxBackoff   time.Duration
+	timeout      time.Duration
+	// envErr is the error parsing the environment variables, reported by Retrieve.
+	envErr error
+}
+
+// Option configures the provider.
+type Option func(*provider)
+
+// WithPollInterval enables polling the configuration at the given interval when a watcher is given to Retrieve.
+// The watcher is called once the content of the configuration changed.
+// Conditional requests are used, so that the server does not need to send the configuration if it is unchanged.
+func WithPollInterval(interval time.Duration) Option {
+	return func(p *provider) {
+		p.pollInterval = interval
+	}
+}
+
+// WithMaxBackoff sets the maximum time to wait between two polls after consecutive failures.
+// The time to wait is doubled after each failure, starting at the poll interval. Defaults to 5 minutes.
+func WithMaxBackoff(maxBackoff time.Duration) Option {
+	return func(p *provider) {
+		p.maxBackoff = maxBackoff
+	}
+}
+
+// WithTimeout sets the time limit of each HTTP request, including reading the response body.
+// Defaults to 30 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *provider) {
+		p.timeout = timeout
+	}
 }
 
 // New returns a new provider that reads the configuration from http server using the configured transport mechanism
@@ -38,15 +85,44 @@ type provider struct {
 // One example for http-uri: http://localhost:3333/getConfig
 // One example for https-uri: https://localhost:3333/getConfig
 // This is used by the http and https external implementations.
-func New(scheme SchemeType, _ confmap.ProviderSettings) confmap.Provider {
-	return &provider{scheme: scheme}
+func New(scheme SchemeType, set confmap.ProviderSettings, opts ...Option) confmap.Provider {
+	p := &provider{
+		scheme:     scheme,
+		logger:     set.Logger,
+		maxBackoff: 5 * time.Minute,
+		timeout:    30 * time.Second,
+	}
+	p.envErr = p.loadEnv()
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// loadEnv sets the poll interval and the timeout from the environment variables, if they are set.
+func (fmp *provider) loadEnv() error {
+	for envVar, dst := range map[string]*time.Duration{
+		PollIntervalEnvVar: &fmp.pollInterval,
+		TimeoutEnvVar:      &fmp.timeout,
+	} {
+		val, ok := os.LookupEnv(envVar)
+		if !ok || val == "" {
+			continue
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for the %s environment variable: %w", val, envVar, err)
+		}
+		*dst = d
+	}
+	return nil
 }
 
 // Create the client based on the type of scheme that was selected.
 func (fmp *provider) createClient() (*http.Client, error) {
 	switch fmp.scheme {
 	case HTTPScheme:
-		return &http.Client{}, nil
+		return &http.Client{Timeout: fmp.timeout}, nil
 	case HTTPSScheme:
 		pool, err := x509.SystemCertPool()
 		if err != nil {
@@ -65,6 +141,7 @@ func (fmp *provider) createClient() (*http.Client, error) {
 		}
 
 		return &http.Client{
+			Timeout: fmp.timeout,
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{
 					InsecureSkipVerify: fmp.insecureSkipVerify,
@@ -77,11 +154,15 @@ func (fmp *provider) createClient() (*http.Client, error) {
 	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
 	if !strings.HasPrefix(uri, string(fmp.scheme)+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, string(fmp.scheme))
 	}
 
+	if fmp.envErr != nil {
+		return nil, fmp.envErr
+	}
+
 	if _, err := url.ParseRequestURI(uri); err != nil {
 		return nil, fmt.Errorf("invalid uri %q: %w", uri, err)
 	}
@@ -91,25 +172,69 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("unable to configure http transport layer: %w", err)
 	}
 
+	res, err := fetch(ctx, client, uri, fetchResult{})
+	if err != nil {
+		return nil, err
+	}
+
+	if watcher == nil || fmp.pollInterval <= 0 {
+		return confmap.NewRetrievedFromYAML(res.body)
+	}
+
+	p := newPoller(client, uri, res, fmp.pollInterval, fmp.maxBackoff, watcher, fmp.logger)
+	return confmap.NewRetrievedFromYAML(res.body, confmap.WithRetrievedClose(p.close))
+}
+
+// fetchResult holds the content of the configuration, and the validators to send conditional requests.
+type fetchResult struct {
+	body         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
+
+// fetch sends a HTTP GET request for the given uri. If the previous result is given, a conditional request is sent
+// and the result is marked as not modified if the server answers with 304.
+func fetch(ctx context.Context, client *http.Client, uri string, prev fetchResult) (fetchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return fetchResult{}, fmt.Errorf("unable to create the HTTP GET request for uri %q: %w", uri, err)
+	}
+	if prev.etag != "" {
+		req.Header.Set("If-None-Match", prev.etag)
+	}
+	if prev.lastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.lastModified)
+	}
+
 	// send a HTTP GET request
-	resp, err := client.Get(uri)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
+		return fetchResult{}, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && (prev.etag != "" || prev.lastModified != "") {
+		prev.notModified = true
+		return prev, nil
+	}
+
 	// check the HTTP status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
+		return fetchResult{}, fmt.Errorf("failed to load resource from uri %q. status code: %d", uri, resp.StatusCode)
 	}
 
 	// read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
+		return fetchResult{}, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
 	}
 
-	return confmap.NewRetrievedFromYAML(body)
+	return fetchResult{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
 }
 
 func (fmp *provider) Scheme() string {
@@ -37,6 +37,10 @@ type Resolver struct {
 
 	closers []CloseFunc
 	watcher chan error
+
+	origins map[string]Origin
+	// expandedURIs collects the URIs expanded in the value being resolved.
+	expandedURIs []string
 }
 
 // ResolverSettings are the settings to configure the behavior of the Resolver.
@@ -173,6 +177,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
+	origins := make(map[string]Origin)
 	for _, uri := range mr.uris {
 		ret, err := mr.retrieveValue(ctx, uri)
 		if err != nil {
@@ -183,6 +188,9 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err != nil {
 			return nil, err
 		}
+		if retCfgMap, err = retMap.applyMergeDirectives(retCfgMap); err != nil {
+			return nil, fmt.Errorf("cannot merge the configuration from %q: %w", uri.asString(), err)
+		}
 		if enableMergeAppendOption.IsEnabled() {
 			// only use MergeAppend when enableMergeAppendOption featuregate is enabled.
 			err = retMap.mergeAppend(retCfgMap)
@@ -192,16 +200,29 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err != nil {
 			return nil, err
 		}
+		recordOrigins(origins, uri.asString(), ret, retCfgMap)
+		// Forget the keys removed by the merge directives.
+		for k := range origins {
+			if !retMap.IsSet(k) {
+				delete(origins, k)
+			}
+		}
 	}
 
 	cfgMap := make(map[string]any)
 	for _, k := range retMap.AllKeys() {
+		mr.expandedURIs = nil
 		val, err := mr.expandValueRecursively(ctx, retMap.unsanitizedGet(k))
 		if err != nil {
 			return nil, err
 		}
 		cfgMap[k] = escapeDollarSigns(val)
+		if origin, ok := origins[k]; ok && len(mr.expandedURIs) > 0 {
+			origin.Expanded = mr.expandedURIs
+			origins[k] = origin
+		}
 	}
+	mr.expandedURIs = nil
 	retMap = NewFromStringMap(cfgMap)
 
 	// Apply the converters in the given order.
@@ -211,6 +232,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		}
 	}
 
+	mr.origins = resolveOrigins(origins, retMap)
 	return retMap, nil
 }
 
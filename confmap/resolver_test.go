@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -477,6 +478,57 @@ func TestMergeFunctionality(t *testing.T) {
 	}
 }
 
+func TestMergeDirectives(t *testing.T) {
+	for _, flagEnabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("feature-flag-enabled=%v", flagEnabled), func(t *testing.T) {
+			if flagEnabled {
+				require.NoError(t, featuregate.GlobalRegistry().Set(enableMergeAppendOption.ID(), true))
+				defer func() {
+					// Restore previous value.
+					require.NoError(t, featuregate.GlobalRegistry().Set(enableMergeAppendOption.ID(), false))
+				}()
+			}
+			runScenario(t, "testdata/merge-directives-scenarios.yaml")
+		})
+	}
+}
+
+func TestMergeDirectivesConflict(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        map[string]any
+		expectedErr string
+	}{
+		{
+			name: "key_and_directive",
+			conf: map[string]any{
+				"exporters": map[string]any{"debug": nil, "debug!delete": nil},
+			},
+			expectedErr: `conflicting merge directive "exporters::debug!delete": "debug" is also set`,
+		},
+		{
+			name: "two_directives",
+			conf: map[string]any{
+				"exporters": map[string]any{"debug!replace": nil, "debug!delete": nil},
+			},
+			expectedErr: `conflicting merge directive "exporters::debug!delete": "debug!replace" is also set`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, err := NewResolver(ResolverSettings{
+				URIs: []string{"mock:"},
+				ProviderFactories: []ProviderFactory{newFakeProvider("mock", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+					return NewRetrieved(tt.conf)
+				})},
+			})
+			require.NoError(t, err)
+			_, err = resolver.Resolve(context.Background())
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func runScenario(t *testing.T, path string) {
 	yamlData, err := os.ReadFile(filepath.Clean(path))
 	require.NoError(t, err)
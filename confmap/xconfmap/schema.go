@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfmap // import "go.opentelemetry.io/collector/confmap/xconfmap"
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// SchemaDraft is the JSON Schema dialect of the generated schemas.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the durations accepted by time.ParseDuration, durations can also be set in nanoseconds.
+const durationPattern = `^[-+]?(\d+(\.\d*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+var (
+	durationType         = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType  = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType    = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	unmarshalerType      = reflect.TypeOf((*confmap.Unmarshaler)(nil)).Elem()
+	schemaCustomizerType = reflect.TypeOf((*SchemaCustomizer)(nil)).Elem()
+)
+
+// SchemaCustomizer defines an optional interface for configurations to complete the JSON Schema generated
+// for them, e.g. with the values accepted by their Validate method.
+type SchemaCustomizer interface {
+	// CustomizeSchema updates the schema generated for the configuration.
+	CustomizeSchema(schema *Schema)
+}
+
+// Schema is a JSON Schema describing a configuration.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// forbidden is true for the "false" schema, which matches no value.
+	forbidden bool
+}
+
+// FalseSchema returns the schema matching no value, e.g. to forbid additional properties.
+func FalseSchema() *Schema {
+	return &Schema{forbidden: true}
+}
+
+// MarshalJSON marshals the schema, the schema matching no value is marshaled as false.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.forbidden {
+		return []byte("false"), nil
+	}
+	type schema Schema
+	return json.Marshal((*schema)(s))
+}
+
+// SchemaType is the list of JSON types allowed by a schema.
+type SchemaType []string
+
+// MarshalJSON marshals a single type as a string, and multiple types as an array.
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// NewSchema generates the JSON Schema of a configuration from its Go type, using the mapstructure tags
+// of its fields like confmap.Conf.Unmarshal. The values of cfg, e.g. the default configuration of a component,
+// are used as default values.
+//
+// The configurations implementing confmap.Unmarshaler may accept additional properties.
+// The configurations implementing SchemaCustomizer can complete their schema.
+func NewSchema(cfg any) *Schema {
+	g := &schemaGenerator{inProgress: map[reflect.Type]bool{}}
+	return g.schema(reflect.ValueOf(cfg))
+}
+
+type schemaGenerator struct {
+	// inProgress holds the types being generated, to stop on recursive types.
+	inProgress map[reflect.Type]bool
+}
+
+// schema generates the schema of the given value, the value may be invalid if only its type is known.
+func (g *schemaGenerator) schema(v reflect.Value) *Schema {
+	if !v.IsValid() {
+		return &Schema{}
+	}
+	t := v.Type()
+	if t.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return &Schema{}
+		}
+		return g.schema(v.Elem())
+	}
+	if t.Kind() == reflect.Pointer {
+		elem := reflect.New(t.Elem()).Elem()
+		if !v.IsNil() {
+			elem = v.Elem()
+		}
+		s := g.schema(elem)
+		// A nil pointer can be set with a null value.
+		if len(s.Type) > 0 && !slices.Contains(s.Type, "null") {
+			s.Type = append(s.Type, "null")
+		}
+		return s
+	}
+
+	if g.inProgress[t] {
+		return &Schema{}
+	}
+	g.inProgress[t] = true
+	defer delete(g.inProgress, t)
+
+	s := g.typeSchema(v)
+	if reflect.PointerTo(t).Implements(schemaCustomizerType) {
+		if !v.CanAddr() {
+			ptr := reflect.New(t)
+			ptr.Elem().Set(v)
+			v = ptr.Elem()
+		}
+		v.Addr().Interface().(SchemaCustomizer).CustomizeSchema(s)
+	}
+	return s
+}
+
+func (g *schemaGenerator) typeSchema(v reflect.Value) *Schema {
+	t := v.Type()
+	switch {
+	case t == durationType:
+		s := &Schema{Type: SchemaType{"string", "integer"}, Pattern: durationPattern}
+		if !v.IsZero() {
+			s.Default = time.Duration(v.Int()).String()
+		}
+		return s
+	case reflect.PointerTo(t).Implements(textUnmarshalerType) && !reflect.PointerTo(t).Implements(unmarshalerType):
+		// The value is unmarshaled from its text representation, e.g. a component.ID.
+		return &Schema{Type: SchemaType{"string"}, Default: defaultValue(v)}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: SchemaType{"boolean"}, Default: defaultValue(v)}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: SchemaType{"integer"}, Default: defaultValue(v)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: SchemaType{"number"}, Default: defaultValue(v)}
+	case reflect.String:
+		return &Schema{Type: SchemaType{"string"}, Default: defaultValue(v)}
+	case reflect.Slice, reflect.Array:
+		s := &Schema{Type: SchemaType{"array"}, Items: g.schema(reflect.New(t.Elem()).Elem())}
+		if t.Kind() == reflect.Slice {
+			s.Type = append(s.Type, "null")
+		}
+		if v.Len() > 0 {
+			s.Default = defaultValue(v)
+		}
+		return s
+	case reflect.Map:
+		return &Schema{
+			Type:                 SchemaType{"object", "null"},
+			AdditionalProperties: g.schema(reflect.New(t.Elem()).Elem()),
+		}
+	case reflect.Struct:
+		return g.structSchema(v)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema generates the schema of a struct, a null value keeps the default configuration.
+func (g *schemaGenerator) structSchema(v reflect.Value) *Schema {
+	s := &Schema{
+		Type:       SchemaType{"object", "null"},
+		Properties: map[string]*Schema{},
+	}
+	g.addFields(s, v)
+	if reflect.PointerTo(v.Type()).Implements(unmarshalerType) {
+		// The configuration unmarshals itself, its properties cannot be known.
+		s.AdditionalProperties = nil
+	} else if s.AdditionalProperties == nil {
+		s.AdditionalProperties = FalseSchema()
+	}
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+func (g *schemaGenerator) addFields(s *Schema, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(confmap.MapstructureTag)
+		name, opts, _ := strings.Cut(tag, ",")
+		squash := slices.Contains(strings.Split(opts, ","), "squash")
+		// The exported fields of a squashed embedded struct are set even if the struct is not exported.
+		if name == "-" || (!field.IsExported() && !(squash && field.Anonymous)) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fv := v.Field(i)
+		switch {
+		case squash:
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv = reflect.New(field.Type.Elem())
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				g.addFields(s, fv)
+			}
+		case slices.Contains(strings.Split(opts, ","), "remain"):
+			if field.Type.Kind() == reflect.Map {
+				s.AdditionalProperties = g.schema(reflect.New(field.Type.Elem()).Elem())
+			} else {
+				s.AdditionalProperties = &Schema{}
+			}
+		default:
+			s.Properties[name] = g.schema(fv)
+		}
+	}
+}
+
+// defaultValue returns the value in its JSON representation, or nil if it is the zero value.
+func defaultValue(v reflect.Value) any {
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	t := v.Type()
+	if t.Implements(textMarshalerType) && t != durationType {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil || (t.Kind() == reflect.String && string(text) != v.String()) {
+			// The value cannot be represented, or is masked like a configopaque.String.
+			return nil
+		}
+		return string(text)
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == durationType {
+			return time.Duration(v.Int()).String()
+		}
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		values := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem := defaultValue(v.Index(i))
+			if elem == nil {
+				// The elements without a JSON representation are not used as default.
+				return nil
+			}
+			values = append(values, elem)
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfmap
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type schemaLevel string
+
+func (l *schemaLevel) UnmarshalText(text []byte) error {
+	*l = schemaLevel(text)
+	return nil
+}
+
+func (l *schemaLevel) CustomizeSchema(schema *Schema) {
+	schema.Enum = []any{"basic", "detailed"}
+}
+
+type schemaOpaque string
+
+func (schemaOpaque) MarshalText() ([]byte, error) {
+	return []byte("[REDACTED]"), nil
+}
+
+type schemaCustom struct {
+	Value string `mapstructure:"value"`
+}
+
+func (c *schemaCustom) Unmarshal(conf *confmap.Conf) error {
+	return conf.Unmarshal(c)
+}
+
+type schemaTree struct {
+	Children []schemaTree `mapstructure:"children"`
+}
+
+type schemaEmbedded struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+type schemaConfig struct {
+	schemaEmbedded `mapstructure:",squash"`
+
+	Endpoint string            `mapstructure:"endpoint"`
+	Token    schemaOpaque      `mapstructure:"token"`
+	Timeout  time.Duration     `mapstructure:"timeout"`
+	Level    schemaLevel       `mapstructure:"level"`
+	Retries  *int              `mapstructure:"retries"`
+	Ratio    float64           `mapstructure:"ratio"`
+	Tags     []string          `mapstructure:"tags"`
+	Headers  map[string]string `mapstructure:"headers"`
+	Custom   schemaCustom      `mapstructure:"custom"`
+	Tree     schemaTree        `mapstructure:"tree"`
+	Any      any               `mapstructure:"any"`
+	Untagged string
+	Ignored  string `mapstructure:"-"`
+}
+
+func (c *schemaConfig) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	return nil
+}
+
+func (c *schemaConfig) CustomizeSchema(schema *Schema) {
+	schema.Required = []string{"endpoint"}
+	minRatio, maxRatio := 0.0, 1.0
+	schema.Properties["ratio"].ExclusiveMinimum = &minRatio
+	schema.Properties["ratio"].Maximum = &maxRatio
+}
+
+func TestNewSchema(t *testing.T) {
+	cfg := &schemaConfig{
+		schemaEmbedded: schemaEmbedded{Enabled: true},
+		Endpoint:       "localhost:4317",
+		Token:          "secret",
+		Timeout:        5 * time.Second,
+		Level:          "basic",
+		Tags:           []string{"a", "b"},
+	}
+	actual, err := json.MarshalIndent(NewSchema(cfg), "", "  ")
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "schema.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestNewSchemaNil(t *testing.T) {
+	actual, err := json.Marshal(NewSchema(nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(actual))
+}
@@ -20,4 +20,7 @@
 // may be done by the component itself, however typically it is done by the original sender, after
 // the receiver in the pipeline returns a response to the sender indicating that the Collector is
 // currently overloaded and the request must be retried.
+//
+// A non-Permanent error can be wrapped with NewThrottle when the destination asked to wait before
+// sending more data. In that case, no data should be sent to the destination before the delay.
 package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
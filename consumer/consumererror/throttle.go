@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+	"time"
+)
+
+// throttle is an error returned when the destination is throttling the data,
+// and asked to wait for a delay before sending more data.
+type throttle struct {
+	err   error
+	delay time.Duration
+}
+
+// NewThrottle wraps an error to indicate that the destination is throttling the data, e.g. with
+// a Retry-After HTTP header or a gRPC RetryInfo, and that no data should be sent to it before the given delay.
+// The throttle errors are not permanent, the same data can be sent again after the delay.
+func NewThrottle(err error, delay time.Duration) error {
+	return throttle{err: err, delay: delay}
+}
+
+func (t throttle) Error() string {
+	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (t throttle) Unwrap() error {
+	return t.err
+}
+
+// ThrottleDelay returns the delay of the error wrapped with the NewThrottle function, and whether
+// the error was wrapped with it.
+func ThrottleDelay(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var t throttle
+	if !errors.As(err, &t) {
+		return 0, false
+	}
+	return t.delay, true
+}
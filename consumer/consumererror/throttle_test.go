@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	_, ok := ThrottleDelay(nil)
+	assert.False(t, ok)
+
+	err := errors.New("testError")
+	_, ok = ThrottleDelay(err)
+	assert.False(t, ok)
+
+	err = fmt.Errorf("%w", NewThrottle(err, 5*time.Second))
+	delay, ok := ThrottleDelay(err)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+	assert.False(t, IsPermanent(err))
+	assert.Equal(t, "Throttle (5s), error: testError", err.Error())
+}
+
+func TestThrottle_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	throttleErr := NewThrottle(err, time.Second)
+
+	target := testErrorType{}
+	require.ErrorAs(t, throttleErr, &target)
+	assert.Equal(t, err, target)
+}
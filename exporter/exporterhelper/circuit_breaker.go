@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+const (
+	// CircuitBreakerFailFast rejects the requests immediately while the circuit is open.
+	CircuitBreakerFailFast = internal.CircuitBreakerFailFast
+	// CircuitBreakerHold blocks the requests while the circuit is open, so the data is kept in the queue.
+	CircuitBreakerHold = internal.CircuitBreakerHold
+)
+
+// CircuitBreakerConfig defines configuration for pausing the export of the data while the backend keeps failing.
+type CircuitBreakerConfig = internal.CircuitBreakerConfig
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+// By default, the circuit breaker is disabled.
+func NewDefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return internal.NewDefaultCircuitBreakerConfig()
+}
+
+// WithCircuitBreaker enables the circuit breaker for an exporter.
+// The circuit opens once the ratio of failed attempts to send the data in the sliding window is too high,
+// the data is then rejected or held in the queue, depending on CircuitBreakerConfig.OnOpen, until probe requests
+// succeed. The state of the circuit is reported as the status of the exporter.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	return internal.WithCircuitBreaker(cfg)
+}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// DeadLetterConfig defines configuration for keeping the data that failed to be exported.
+type DeadLetterConfig = internal.DeadLetterConfig
+
+// NewDefaultDeadLetterConfig returns the default config for DeadLetterConfig.
+// By default, the dead letter queue is disabled.
+func NewDefaultDeadLetterConfig() DeadLetterConfig {
+	return internal.NewDefaultDeadLetterConfig()
+}
+
+// WithDeadLetter enables the dead letter queue for an exporter.
+// The requests that are dropped after failing to be exported are written to the dead letter queue
+// with the error and the time they were rejected, and can be replayed when the exporter starts.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithDeadLetter(cfg DeadLetterConfig) Option {
+	return internal.WithDeadLetter(cfg)
+}
@@ -39,6 +39,10 @@ type BaseExporter struct {
 	QueueSender sender.Sender[request.Request]
 	RetrySender sender.Sender[request.Request]
 
+	deadLetterSender     *deadLetterSender
+	circuitBreakerSender *circuitBreakerSender
+	concurrencySender    *concurrencySender
+
 	firstSender sender.Sender[request.Request]
 
 	ConsumerOptions []consumer.Option
@@ -49,6 +53,8 @@ type BaseExporter struct {
 	queueBatchSettings QueueBatchSettings[request.Request]
 	queueCfg           queuebatch.Config
 	batcherCfg         BatcherConfig
+	deadLetterCfg      DeadLetterConfig
+	circuitBreakerCfg  CircuitBreakerConfig
 }
 
 func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sender.SendFunc[request.Request], options ...Option) (*BaseExporter, error) {
@@ -72,17 +78,45 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 		be.firstSender = newTimeoutSender(be.timeoutCfg, be.firstSender)
 	}
 
+	var err error
+	// Then setup the concurrency Sender to adapt the number of concurrent attempts to send the data.
+	if be.queueCfg.Enabled && be.queueCfg.AdaptiveConcurrency != nil {
+		be.concurrencySender, err = newConcurrencySender(*be.queueCfg.AdaptiveConcurrency, be.queueCfg.NumConsumers,
+			set, signal, be.firstSender)
+		if err != nil {
+			return nil, err
+		}
+		be.firstSender = be.concurrencySender
+	}
+
+	// Then setup the circuit breaker Sender, before the retry Sender so that every attempt is counted.
+	if be.circuitBreakerCfg.Enabled {
+		if be.circuitBreakerCfg.OnOpen == CircuitBreakerHold && !be.queueCfg.Enabled {
+			return nil, errors.New("`sending_queue` must be enabled to hold the data while the circuit breaker is open")
+		}
+		be.circuitBreakerSender = newCircuitBreakerSender(be.circuitBreakerCfg, set, be.firstSender)
+		be.firstSender = be.circuitBreakerSender
+	}
+
 	if be.retryCfg.Enabled {
 		be.RetrySender = newRetrySender(be.retryCfg, set, be.firstSender)
 		be.firstSender = be.RetrySender
 	}
 
-	var err error
 	be.firstSender, err = newObsReportSender(set, signal, be.firstSender)
 	if err != nil {
 		return nil, err
 	}
 
+	if be.deadLetterCfg.Enabled {
+		if be.queueBatchSettings.Encoding == nil {
+			return nil, errors.New("`QueueBatchSettings.Encoding` must not be nil when dead letter queue is enabled")
+		}
+		be.deadLetterSender = newDeadLetterSender(be.deadLetterCfg, set.TelemetrySettings, set.ID, signal,
+			be.queueBatchSettings.Encoding, be.queueCfg, be.firstSender)
+		be.firstSender = be.deadLetterSender
+	}
+
 	if be.batcherCfg.Enabled || be.queueCfg.Batch != nil {
 		// Batcher mutates the data.
 		be.ConsumerOptions = append(be.ConsumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
@@ -103,6 +137,10 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 		be.firstSender = be.QueueSender
 	}
 
+	if be.deadLetterSender != nil {
+		be.deadLetterSender.replaySender = be.firstSender
+	}
+
 	return be, nil
 }
 
@@ -125,9 +163,33 @@ func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	// Then start the circuit breaker, so it can report the status of the exporter.
+	if be.circuitBreakerSender != nil {
+		if err := be.circuitBreakerSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	// Then start the dead letter queue, so it is ready to keep the data failed to be exported from the queue.
+	if be.deadLetterSender != nil {
+		if err := be.deadLetterSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	// Last start the QueueBatch.
 	if be.QueueSender != nil {
-		return be.QueueSender.Start(ctx, host)
+		if err := be.QueueSender.Start(ctx, host); err != nil {
+			if be.deadLetterSender != nil {
+				err = multierr.Append(err, be.deadLetterSender.Shutdown(ctx))
+			}
+			return err
+		}
+	}
+
+	// Replay the dead letter queue once all the senders are started.
+	if be.deadLetterSender != nil {
+		be.deadLetterSender.startReplay()
 	}
 
 	return nil
@@ -141,11 +203,26 @@ func (be *BaseExporter) Shutdown(ctx context.Context) error {
 		err = multierr.Append(err, be.RetrySender.Shutdown(ctx))
 	}
 
+	// Also release the requests held by the circuit breaker.
+	if be.circuitBreakerSender != nil {
+		err = multierr.Append(err, be.circuitBreakerSender.Shutdown(ctx))
+	}
+
 	// Then shutdown the queue sender.
 	if be.QueueSender != nil {
 		err = multierr.Append(err, be.QueueSender.Shutdown(ctx))
 	}
 
+	// Then shutdown the dead letter queue, after the data failed to be exported from the queue is written.
+	if be.deadLetterSender != nil {
+		err = multierr.Append(err, be.deadLetterSender.Shutdown(ctx))
+	}
+
+	// Then shutdown the concurrency sender, once no more data is exported.
+	if be.concurrencySender != nil {
+		err = multierr.Append(err, be.concurrencySender.Shutdown(ctx))
+	}
+
 	// Last shutdown the wrapped exporter itself.
 	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
@@ -221,6 +298,24 @@ func WithQueueBatch(cfg queuebatch.Config, set QueueBatchSettings[request.Reques
 	}
 }
 
+// WithDeadLetter enables the dead letter queue for an exporter.
+// The QueueBatchSettings.Encoding must be set, see WithQueueBatchSettings.
+func WithDeadLetter(cfg DeadLetterConfig) Option {
+	return func(o *BaseExporter) error {
+		o.deadLetterCfg = cfg
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables the circuit breaker for an exporter.
+// The CircuitBreakerHold mode requires the queue to be enabled.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	return func(o *BaseExporter) error {
+		o.circuitBreakerCfg = cfg
+		return nil
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -0,0 +1,351 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+const (
+	// CircuitBreakerFailFast rejects the requests immediately while the circuit is open.
+	CircuitBreakerFailFast = "fail_fast"
+	// CircuitBreakerHold blocks the requests while the circuit is open, so the data is kept in the queue.
+	CircuitBreakerHold = "hold"
+
+	// circuitBreakerBuckets is the number of buckets of the sliding window.
+	circuitBreakerBuckets = 10
+)
+
+// CircuitBreakerConfig defines configuration for pausing the export of the data while the backend keeps failing.
+type CircuitBreakerConfig struct {
+	// Enabled indicates whether the circuit breaker is enabled.
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureRatio is the ratio of failed requests in the sliding window above which the circuit opens.
+	FailureRatio float64 `mapstructure:"failure_ratio"`
+
+	// MinRequests is the minimum number of requests in the sliding window before the circuit can open.
+	MinRequests int `mapstructure:"min_requests"`
+
+	// Window is the duration of the sliding window the failure ratio is computed on.
+	Window time.Duration `mapstructure:"window"`
+
+	// OpenDuration is the time the circuit stays open before probe requests are sent to the backend.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// HalfOpenRequests is the number of probe requests that must succeed to close the circuit.
+	HalfOpenRequests int `mapstructure:"half_open_requests"`
+
+	// OnOpen is what happens to the requests while the circuit is open, either "fail_fast" or "hold".
+	OnOpen string `mapstructure:"on_open"`
+}
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+// By default, the circuit breaker is disabled.
+func NewDefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Enabled:          false,
+		FailureRatio:     0.5,
+		MinRequests:      10,
+		Window:           time.Minute,
+		OpenDuration:     30 * time.Second,
+		HalfOpenRequests: 1,
+		OnOpen:           CircuitBreakerFailFast,
+	}
+}
+
+// Validate checks if the CircuitBreakerConfig is valid
+func (cfg *CircuitBreakerConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FailureRatio <= 0 || cfg.FailureRatio > 1 {
+		return errors.New("`failure_ratio` must be greater than 0 and at most 1")
+	}
+	if cfg.MinRequests <= 0 {
+		return errors.New("`min_requests` must be greater than 0")
+	}
+	if cfg.Window <= 0 {
+		return errors.New("`window` must be greater than 0")
+	}
+	if cfg.OpenDuration <= 0 {
+		return errors.New("`open_duration` must be greater than 0")
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		return errors.New("`half_open_requests` must be greater than 0")
+	}
+	if cfg.OnOpen != CircuitBreakerFailFast && cfg.OnOpen != CircuitBreakerHold {
+		return fmt.Errorf("`on_open` must be either %q or %q", CircuitBreakerFailFast, CircuitBreakerHold)
+	}
+	return nil
+}
+
+// CustomizeSchema adds the values accepted by Validate to the JSON Schema of the CircuitBreakerConfig.
+func (cfg *CircuitBreakerConfig) CustomizeSchema(schema *xconfmap.Schema) {
+	zero, one := 0.0, 1.0
+	schema.Properties["failure_ratio"].ExclusiveMinimum = &zero
+	schema.Properties["failure_ratio"].Maximum = &one
+	schema.Properties["min_requests"].Minimum = &one
+	schema.Properties["half_open_requests"].Minimum = &one
+	schema.Properties["on_open"].Enum = []any{CircuitBreakerFailFast, CircuitBreakerHold}
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreakerSender stops sending the requests to the backend once the ratio of failed requests is too high.
+// Every attempt to send a request is counted, so it must be placed after the retry sender.
+type circuitBreakerSender struct {
+	cfg    CircuitBreakerConfig
+	logger *zap.Logger
+	next   sender.Sender[request.Request]
+	stopCh chan struct{}
+	now    func() time.Time
+
+	mu       sync.Mutex
+	host     component.Host
+	state    circuitState
+	window   *failureWindow
+	openedAt time.Time
+	lastErr  error
+	// probes is the number of probe requests sent while the circuit is half-open, and successes the number of them
+	// that succeeded. The canceled probe requests are not counted.
+	probes    int
+	successes int
+	// changed is closed when the state changes or a probe request completes, to wake up the held requests.
+	changed chan struct{}
+}
+
+func newCircuitBreakerSender(cfg CircuitBreakerConfig, set exporter.Settings, next sender.Sender[request.Request]) *circuitBreakerSender {
+	cb := &circuitBreakerSender{
+		cfg:     cfg,
+		logger:  set.Logger,
+		next:    next,
+		stopCh:  make(chan struct{}),
+		now:     time.Now,
+		changed: make(chan struct{}),
+	}
+	cb.window = newFailureWindow(cfg.Window, cb.now())
+	return cb
+}
+
+func (cb *circuitBreakerSender) Start(_ context.Context, host component.Host) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.host = host
+	return nil
+}
+
+func (cb *circuitBreakerSender) Shutdown(context.Context) error {
+	close(cb.stopCh)
+	return nil
+}
+
+// Send implements the requestSender interface
+func (cb *circuitBreakerSender) Send(ctx context.Context, req request.Request) error {
+	for {
+		probe, err := cb.acquire()
+		if err == nil {
+			err = cb.next.Send(ctx, req)
+			cb.release(ctx, probe, err)
+			return err
+		}
+		if cb.cfg.OnOpen != CircuitBreakerHold {
+			return err
+		}
+		// Hold the request until a probe request can be sent, but get interrupted when shutting down
+		// or request is cancelled or timed out.
+		if err = cb.wait(ctx, err); err != nil {
+			return err
+		}
+	}
+}
+
+// acquire checks if a request can be sent, and whether it is a probe request of the half-open circuit.
+func (cb *circuitBreakerSender) acquire() (bool, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == circuitOpen && !cb.now().Before(cb.openedAt.Add(cb.cfg.OpenDuration)) {
+		cb.state = circuitHalfOpen
+		cb.probes, cb.successes = 0, 0
+		cb.notifyLocked()
+		cb.logger.Info("Circuit breaker is half-open, sending probe requests.")
+	}
+	switch cb.state {
+	case circuitClosed:
+		return false, nil
+	case circuitHalfOpen:
+		if cb.probes < cb.cfg.HalfOpenRequests {
+			cb.probes++
+			return true, nil
+		}
+	}
+	return false, experr.NewCircuitOpenErr(cb.lastErr)
+}
+
+// release records the result of a request sent after acquire.
+func (cb *circuitBreakerSender) release(ctx context.Context, probe bool, err error) {
+	// The requests canceled by the caller or by the shutdown say nothing about the backend, and the permanent
+	// errors are returned by a backend that is up.
+	ignored := err != nil && (ctx.Err() != nil || experr.IsShutdownErr(err))
+	failed := err != nil && !ignored && !consumererror.IsPermanent(err)
+
+	cb.mu.Lock()
+	var event *componentstatus.Event
+	switch {
+	case !probe && cb.state == circuitClosed:
+		if ignored {
+			break
+		}
+		now := cb.now()
+		cb.window.record(now, failed)
+		total, failures := cb.window.counts(now)
+		if failed && total >= cb.cfg.MinRequests && float64(failures) >= cb.cfg.FailureRatio*float64(total) {
+			event = cb.openLocked(err)
+		}
+	case probe && cb.state == circuitHalfOpen:
+		switch {
+		case ignored:
+			cb.probes--
+		case failed:
+			event = cb.openLocked(err)
+		default:
+			cb.successes++
+			if cb.successes >= cb.cfg.HalfOpenRequests {
+				cb.state = circuitClosed
+				cb.window.reset(cb.now())
+				cb.logger.Info("Circuit breaker is closed, exporting is resumed.")
+				event = componentstatus.NewEvent(componentstatus.StatusOK)
+			}
+		}
+		cb.notifyLocked()
+	}
+	host := cb.host
+	cb.mu.Unlock()
+
+	if event != nil && host != nil {
+		componentstatus.ReportStatus(host, event)
+	}
+}
+
+// openLocked opens the circuit after the given error, and returns the status event to report.
+func (cb *circuitBreakerSender) openLocked(err error) *componentstatus.Event {
+	cb.state = circuitOpen
+	cb.openedAt = cb.now()
+	cb.lastErr = err
+	cb.notifyLocked()
+	cb.logger.Warn("Circuit breaker is open, exporting is paused.",
+		zap.Error(err), zap.Duration("open_duration", cb.cfg.OpenDuration))
+	return componentstatus.NewRecoverableErrorEvent(fmt.Errorf("circuit breaker is open: %w", err))
+}
+
+// notifyLocked wakes up the held requests.
+func (cb *circuitBreakerSender) notifyLocked() {
+	close(cb.changed)
+	cb.changed = make(chan struct{})
+}
+
+// wait blocks until the circuit may accept the request, and returns an error wrapping err if interrupted.
+func (cb *circuitBreakerSender) wait(ctx context.Context, err error) error {
+	cb.mu.Lock()
+	changed := cb.changed
+	var timerCh <-chan time.Time
+	if cb.state == circuitOpen {
+		timer := time.NewTimer(cb.openedAt.Add(cb.cfg.OpenDuration).Sub(cb.now()))
+		defer timer.Stop()
+		timerCh = timer.C
+	}
+	cb.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request is cancelled or timed out: %w", err)
+	case <-cb.stopCh:
+		return experr.NewShutdownErr(err)
+	case <-changed:
+	case <-timerCh:
+	}
+	return nil
+}
+
+// failureWindow counts the requests and the failed requests in a sliding window, split in buckets.
+type failureWindow struct {
+	bucketDuration time.Duration
+	buckets        [circuitBreakerBuckets]windowBucket
+	// current is the index of the bucket started at start.
+	current int
+	start   time.Time
+}
+
+type windowBucket struct {
+	total    int
+	failures int
+}
+
+func newFailureWindow(duration time.Duration, now time.Time) *failureWindow {
+	return &failureWindow{
+		bucketDuration: max(duration/circuitBreakerBuckets, 1),
+		start:          now,
+	}
+}
+
+func (w *failureWindow) record(now time.Time, failed bool) {
+	w.advance(now)
+	w.buckets[w.current].total++
+	if failed {
+		w.buckets[w.current].failures++
+	}
+}
+
+func (w *failureWindow) counts(now time.Time) (total, failures int) {
+	w.advance(now)
+	for _, b := range w.buckets {
+		total += b.total
+		failures += b.failures
+	}
+	return total, failures
+}
+
+func (w *failureWindow) reset(now time.Time) {
+	w.buckets = [circuitBreakerBuckets]windowBucket{}
+	w.current = 0
+	w.start = now
+}
+
+// advance drops the buckets that are out of the window at the given time.
+func (w *failureWindow) advance(now time.Time) {
+	steps := int(now.Sub(w.start) / w.bucketDuration)
+	if steps <= 0 {
+		return
+	}
+	if steps >= circuitBreakerBuckets {
+		w.reset(now)
+		return
+	}
+	for i := 0; i < steps; i++ {
+		w.current = (w.current + 1) % circuitBreakerBuckets
+		w.buckets[w.current] = windowBucket{}
+	}
+	w.start = w.start.Add(time.Duration(steps) * w.bucketDuration)
+}
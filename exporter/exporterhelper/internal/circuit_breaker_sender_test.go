@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// statusHost records the status events reported by the components.
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, ev)
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var statuses []componentstatus.Status
+	for _, ev := range h.events {
+		statuses = append(statuses, ev.Status())
+	}
+	return statuses
+}
+
+// fakeBackend returns the configured error for every request it receives.
+type fakeBackend struct {
+	mu       sync.Mutex
+	err      error
+	requests int
+}
+
+func (b *fakeBackend) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+func (b *fakeBackend) numRequests() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.requests
+}
+
+func (b *fakeBackend) send(context.Context, request.Request) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.requests++
+	return b.err
+}
+
+func newTestCircuitBreaker(t *testing.T, cfg CircuitBreakerConfig, backend *fakeBackend) (*circuitBreakerSender, *statusHost, *time.Time) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	cb := newCircuitBreakerSender(cfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(backend.send))
+	cb.now = func() time.Time { return now }
+	cb.window = newFailureWindow(cfg.Window, now)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, cb.Start(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, cb.Shutdown(context.Background())) })
+	return cb, host, &now
+}
+
+func TestCircuitBreakerConfigValidate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.FailureRatio = 0
+	// The configuration is not validated if the circuit breaker is disabled.
+	require.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*CircuitBreakerConfig)
+		err    string
+	}{
+		{name: "failure_ratio", modify: func(cfg *CircuitBreakerConfig) { cfg.FailureRatio = 1.5 }, err: "`failure_ratio` must be greater than 0 and at most 1"},
+		{name: "min_requests", modify: func(cfg *CircuitBreakerConfig) { cfg.MinRequests = 0 }, err: "`min_requests` must be greater than 0"},
+		{name: "window", modify: func(cfg *CircuitBreakerConfig) { cfg.Window = 0 }, err: "`window` must be greater than 0"},
+		{name: "open_duration", modify: func(cfg *CircuitBreakerConfig) { cfg.OpenDuration = -time.Second }, err: "`open_duration` must be greater than 0"},
+		{name: "half_open_requests", modify: func(cfg *CircuitBreakerConfig) { cfg.HalfOpenRequests = 0 }, err: "`half_open_requests` must be greater than 0"},
+		{name: "on_open", modify: func(cfg *CircuitBreakerConfig) { cfg.OnOpen = "drop" }, err: "`on_open` must be either \"fail_fast\" or \"hold\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultCircuitBreakerConfig()
+			cfg.Enabled = true
+			tt.modify(&cfg)
+			require.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func TestCircuitBreakerConfigSchema(t *testing.T) {
+	schema := xconfmap.NewSchema(NewDefaultCircuitBreakerConfig())
+	assert.Equal(t, []any{CircuitBreakerFailFast, CircuitBreakerHold}, schema.Properties["on_open"].Enum)
+	assert.InDelta(t, 0, *schema.Properties["failure_ratio"].ExclusiveMinimum, 0)
+	assert.InDelta(t, 1, *schema.Properties["failure_ratio"].Maximum, 0)
+	assert.InDelta(t, 1, *schema.Properties["min_requests"].Minimum, 0)
+	assert.InDelta(t, 1, *schema.Properties["half_open_requests"].Minimum, 0)
+}
+
+func TestCircuitBreakerFailFast(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinRequests = 4
+	backend := &fakeBackend{}
+	cb, host, now := newTestCircuitBreaker(t, cfg, backend)
+
+	// The circuit stays closed while the failure ratio is below the threshold.
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	backendErr := errors.New("backend is down")
+	backend.setErr(backendErr)
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), backendErr)
+	assert.Empty(t, host.statuses())
+
+	// The circuit opens once the failure ratio and the minimum number of requests are reached.
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), backendErr)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// The requests are rejected without being sent while the circuit is open.
+	err := cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	require.True(t, experr.IsCircuitOpenErr(err))
+	require.ErrorIs(t, err, backendErr)
+	assert.Equal(t, 4, backend.numRequests())
+
+	// A single probe request is sent once the open duration elapsed, a failure opens the circuit again.
+	*now = now.Add(cfg.OpenDuration)
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), backendErr)
+	assert.Equal(t, 5, backend.numRequests())
+	require.True(t, experr.IsCircuitOpenErr(cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1})))
+
+	// A successful probe request closes the circuit.
+	*now = now.Add(cfg.OpenDuration)
+	backend.setErr(nil)
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 7, backend.numRequests())
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusOK,
+	}, host.statuses())
+}
+
+func TestCircuitBreakerIgnoredErrors(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinRequests = 2
+	backend := &fakeBackend{}
+	cb, host, _ := newTestCircuitBreaker(t, cfg, backend)
+
+	// The permanent errors are returned by a backend that is up.
+	backend.setErr(consumererror.NewPermanent(errors.New("bad data")))
+	for i := 0; i < 5; i++ {
+		require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+
+	// The requests canceled by the caller are not counted.
+	backend.setErr(context.Canceled)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 5; i++ {
+		require.Error(t, cb.Send(ctx, &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, 10, backend.numRequests())
+	assert.Empty(t, host.statuses())
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinRequests = 2
+	backend := &fakeBackend{err: errors.New("backend is down")}
+	cb, host, now := newTestCircuitBreaker(t, cfg, backend)
+
+	// The failures out of the window are forgotten.
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	*now = now.Add(cfg.Window)
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Empty(t, host.statuses())
+
+	*now = now.Add(cfg.Window / 2)
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+}
+
+func TestCircuitBreakerHold(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinRequests = 1
+	cfg.OpenDuration = 50 * time.Millisecond
+	cfg.OnOpen = CircuitBreakerHold
+	backendErr := errors.New("backend is down")
+	backend := &fakeBackend{err: backendErr}
+	cb := newCircuitBreakerSender(cfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(backend.send))
+	require.NoError(t, cb.Start(context.Background(), componenttest.NewNopHost()))
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), backendErr)
+
+	// The request is held until the circuit is half-open, and sent as a probe request.
+	backend.setErr(nil)
+	start := time.Now()
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	assert.Equal(t, 2, backend.numRequests())
+
+	// The held requests are interrupted when the request is canceled or the sender is shut down.
+	backend.setErr(backendErr)
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), backendErr)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := cb.Send(ctx, &requesttest.FakeRequest{Items: 1})
+	require.ErrorContains(t, err, "request is cancelled or timed out")
+	require.ErrorIs(t, err, backendErr)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	}()
+	require.NoError(t, cb.Shutdown(context.Background()))
+	require.True(t, experr.IsShutdownErr(<-errCh))
+	assert.Equal(t, 3, backend.numRequests())
+}
+
+func TestCircuitBreakerHoldHalfOpenRequests(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinRequests = 1
+	cfg.OpenDuration = time.Millisecond
+	cfg.HalfOpenRequests = 2
+	cfg.OnOpen = CircuitBreakerHold
+	backendErr := errors.New("backend is down")
+	var mu sync.Mutex
+	inFlight, requests := 0, 0
+	release := make(chan struct{})
+	cb := newCircuitBreakerSender(cfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(func(context.Context, request.Request) error {
+		mu.Lock()
+		requests++
+		if requests == 1 {
+			mu.Unlock()
+			return backendErr
+		}
+		inFlight++
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	}))
+	require.NoError(t, cb.Start(context.Background(), componenttest.NewNopHost()))
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), backendErr)
+
+	// Only the probe requests are sent while the circuit is half-open, the other requests are held.
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		}()
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return inFlight == 2
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, 3, requests)
+	mu.Unlock()
+
+	// The held requests are sent once the probe requests succeeded.
+	close(release)
+	wg.Wait()
+	assert.Equal(t, 6, requests)
+	require.NoError(t, cb.Shutdown(context.Background()))
+}
+
+func TestCircuitBreakerWithRetry(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.MinRequests = 2
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 0
+	backendErr := errors.New("backend is down")
+	backend := &fakeBackend{err: backendErr}
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, backend.send,
+		WithRetry(rCfg), WithCircuitBreaker(cfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Every attempt is counted, the retries stop once the circuit is open.
+	err = be.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	require.True(t, experr.IsCircuitOpenErr(err))
+	assert.Equal(t, 2, backend.numRequests())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestCircuitBreakerHoldWithoutQueue(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.OnOpen = CircuitBreakerHold
+	_, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithCircuitBreaker(cfg))
+	require.EqualError(t, err, "`sending_queue` must be enabled to hold the data while the circuit breaker is open")
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// concurrencySender limits the number of concurrent attempts to send the data, and adapts the limit with an additive
+// increase/multiplicative decrease (AIMD) algorithm: the limit is increased by one once a number of attempts equal
+// to the limit succeeded, and multiplied by the backoff ratio when an attempt is throttled or too slow.
+type concurrencySender struct {
+	component.StartFunc
+	cfg      queuebatch.AdaptiveConcurrencyConfig
+	maxLimit int
+	next     sender.Sender[request.Request]
+	tb       *metadata.TelemetryBuilder
+	now      func() time.Time
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// released is closed when an attempt completes or the limit changes, to wake up the waiting attempts.
+	released chan struct{}
+}
+
+func newConcurrencySender(cfg queuebatch.AdaptiveConcurrencyConfig, maxLimit int, set exporter.Settings, signal pipeline.Signal,
+	next sender.Sender[request.Request],
+) (*concurrencySender, error) {
+	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	cs := &concurrencySender{
+		cfg:      cfg,
+		maxLimit: maxLimit,
+		next:     next,
+		tb:       tb,
+		now:      time.Now,
+		limit:    float64(cfg.MinConsumers),
+		released: make(chan struct{}),
+	}
+	asyncAttr := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String(ExporterKey, set.ID.String()), attribute.String(DataTypeKey, signal.String())))
+	err = tb.RegisterExporterQueueConcurrencyLimitCallback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(int64(cs.currentLimit()), asyncAttr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+func (cs *concurrencySender) Shutdown(context.Context) error {
+	cs.tb.Shutdown()
+	return nil
+}
+
+// Send implements the requestSender interface
+func (cs *concurrencySender) Send(ctx context.Context, req request.Request) error {
+	if err := cs.acquire(ctx); err != nil {
+		return err
+	}
+	start := cs.now()
+	err := cs.next.Send(ctx, req)
+	cs.release(ctx, cs.now().Sub(start), err)
+	return err
+}
+
+func (cs *concurrencySender) currentLimit() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return int(cs.limit)
+}
+
+// acquire blocks until the number of attempts in flight is below the limit.
+func (cs *concurrencySender) acquire(ctx context.Context) error {
+	for {
+		cs.mu.Lock()
+		if cs.inFlight < int(cs.limit) {
+			cs.inFlight++
+			cs.mu.Unlock()
+			return nil
+		}
+		released := cs.released
+		cs.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release adapts the limit to the result and the latency of an attempt.
+func (cs *concurrencySender) release(ctx context.Context, latency time.Duration, err error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	inFlight := cs.inFlight
+	cs.inFlight--
+
+	switch {
+	case ctx.Err() != nil || experr.IsShutdownErr(err):
+		// The attempts canceled by the caller say nothing about the backend.
+	case isThrottleErr(ctx, err) || (cs.cfg.LatencyThreshold > 0 && latency > cs.cfg.LatencyThreshold):
+		cs.limit = max(float64(cs.cfg.MinConsumers), cs.limit*cs.cfg.BackoffRatio)
+	case err == nil && 2*inFlight >= int(cs.limit):
+		// Only increase the limit if it is actually used, otherwise it would grow without limit while idle.
+		cs.limit = min(float64(cs.maxLimit), cs.limit+1/cs.limit)
+	}
+
+	close(cs.released)
+	cs.released = make(chan struct{})
+}
+
+// isThrottleErr returns true if the error means that the backend is overloaded: the backend asked to retry later,
+// responded with RESOURCE_EXHAUSTED or UNAVAILABLE (HTTP 429 and 503), or the attempt timed out.
+func isThrottleErr(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := consumererror.ThrottleDelay(err); ok {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return true
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code() == codes.ResourceExhausted || st.Code() == codes.Unavailable
+	}
+	return false
+}
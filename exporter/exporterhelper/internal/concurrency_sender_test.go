@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func newTestConcurrencySender(t *testing.T, cfg queuebatch.AdaptiveConcurrencyConfig, maxLimit int, next sender.SendFunc[request.Request]) *concurrencySender {
+	cs, err := newConcurrencySender(cfg, maxLimit, exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalTraces,
+		sender.NewSender(next))
+	require.NoError(t, err)
+	require.NoError(t, cs.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, cs.Shutdown(context.Background())) })
+	return cs
+}
+
+func TestConcurrencySenderLimitsInFlight(t *testing.T) {
+	cfg := queuebatch.NewDefaultAdaptiveConcurrencyConfig()
+	cfg.MinConsumers = 2
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	release := make(chan struct{})
+	cs := newTestConcurrencySender(t, cfg, 10, func(context.Context, request.Request) error {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		}()
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return inFlight == 2
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, 2, maxInFlight)
+	mu.Unlock()
+
+	// The waiting attempts are canceled with their context.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cs.Send(ctx, &requesttest.FakeRequest{Items: 1}), context.DeadlineExceeded)
+
+	close(release)
+	wg.Wait()
+}
+
+func TestConcurrencySenderIncrease(t *testing.T) {
+	cs := newTestConcurrencySender(t, queuebatch.NewDefaultAdaptiveConcurrencyConfig(), 4, noopExport)
+
+	// The limit is only increased while at least half of it is used, sending the data sequentially does not.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, 3, cs.currentLimit())
+
+	// The limit is increased up to the maximum while it is used.
+	for round := 0; round < 10; round++ {
+		limit := cs.currentLimit()
+		for i := 0; i < limit; i++ {
+			require.NoError(t, cs.acquire(context.Background()))
+		}
+		for i := 0; i < limit; i++ {
+			cs.release(context.Background(), time.Millisecond, nil)
+		}
+	}
+	assert.Equal(t, 4, cs.currentLimit())
+}
+
+func TestConcurrencySenderDecrease(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		latency  time.Duration
+		err      error
+		expected int
+	}{
+		{name: "success", ctx: context.Background(), latency: time.Millisecond, expected: 8},
+		{name: "throttle_retry", ctx: context.Background(), err: consumererror.NewThrottle(errors.New("throttled"), time.Second), expected: 7},
+		{name: "resource_exhausted", ctx: context.Background(), err: fmt.Errorf("failed: %w", status.Error(codes.ResourceExhausted, "too many requests")), expected: 7},
+		{name: "unavailable", ctx: context.Background(), err: status.Error(codes.Unavailable, "service unavailable"), expected: 7},
+		{name: "timeout", ctx: context.Background(), err: context.DeadlineExceeded, expected: 7},
+		{name: "latency", ctx: context.Background(), latency: 2 * time.Second, expected: 7},
+		{name: "other_error", ctx: context.Background(), err: status.Error(codes.InvalidArgument, "bad data"), expected: 8},
+		{name: "canceled", ctx: canceledCtx, err: context.Canceled, expected: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := queuebatch.NewDefaultAdaptiveConcurrencyConfig()
+			cfg.LatencyThreshold = time.Second
+			cs := newTestConcurrencySender(t, cfg, 10, noopExport)
+			cs.limit = 8
+			cs.inFlight = 1
+			cs.release(tt.ctx, tt.latency, tt.err)
+			assert.Equal(t, tt.expected, cs.currentLimit())
+		})
+	}
+}
+
+func TestConcurrencySenderMinLimit(t *testing.T) {
+	cfg := queuebatch.NewDefaultAdaptiveConcurrencyConfig()
+	cfg.MinConsumers = 3
+	throttleErr := consumererror.NewThrottle(errors.New("throttled"), time.Second)
+	cs := newTestConcurrencySender(t, cfg, 10, func(context.Context, request.Request) error { return throttleErr })
+	for i := 0; i < 10; i++ {
+		require.ErrorIs(t, cs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), throttleErr)
+	}
+	assert.Equal(t, 3, cs.currentLimit())
+}
+
+func TestConcurrencySenderMetric(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := exportertest.NewNopSettings(exportertest.NopType)
+	set.TelemetrySettings = tt.NewTelemetrySettings()
+	qCfg := NewDefaultQueueConfig()
+	qCfg.AdaptiveConcurrency = &queuebatch.AdaptiveConcurrencyConfig{MinConsumers: 5, BackoffRatio: 0.9}
+	be, err := NewBaseExporter(set, pipeline.SignalLogs, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	metadatatest.AssertEqualExporterQueueConcurrencyLimit(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(ExporterKey, set.ID.String()),
+					attribute.String(DataTypeKey, pipeline.SignalLogs.String())),
+				Value: int64(5),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
@@ -0,0 +1,471 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	deadLetterReadIndexKey  = "dl_ri"
+	deadLetterWriteIndexKey = "dl_wi"
+	deadLetterFileExt       = ".json"
+)
+
+// DeadLetterConfig defines configuration for keeping the requests that failed to be exported,
+// so that they can be audited and replayed later instead of being dropped.
+type DeadLetterConfig struct {
+	// Enabled indicates whether the failed requests are written to the dead letter queue.
+	Enabled bool `mapstructure:"enabled"`
+
+	// StorageID if not empty, uses the component specified as a storage extension to keep the failed requests.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// Directory if not empty, is the directory where the failed requests are written as files,
+	// one JSON file per request. Exactly one of StorageID and Directory must be set.
+	Directory string `mapstructure:"directory"`
+
+	// ReplayOnStart indicates whether the requests kept in the dead letter queue are sent again when the exporter
+	// starts. The requests are removed from the dead letter queue once they are accepted by the exporter.
+	ReplayOnStart bool `mapstructure:"replay_on_start"`
+}
+
+// NewDefaultDeadLetterConfig returns the default config for DeadLetterConfig.
+// By default, the dead letter queue is disabled.
+func NewDefaultDeadLetterConfig() DeadLetterConfig {
+	return DeadLetterConfig{}
+}
+
+// Validate checks if the DeadLetterConfig is valid
+func (cfg *DeadLetterConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if (cfg.StorageID == nil) == (cfg.Directory == "") {
+		return errors.New("exactly one of `storage` or `directory` must be set")
+	}
+	return nil
+}
+
+// deadLetterRecord is a request that failed to be exported, as written to the dead letter queue.
+type deadLetterRecord struct {
+	// Timestamp is the time when the request was rejected.
+	Timestamp time.Time `json:"timestamp"`
+	// Exporter is the ID of the exporter that rejected the request.
+	Exporter string `json:"exporter"`
+	// Signal is the type of the data in the request.
+	Signal string `json:"signal"`
+	// Items is the number of items in the request.
+	Items int `json:"items"`
+	// Error is the error returned by the last attempt to export the request.
+	Error string `json:"error"`
+	// Data is the request encoded with the QueueBatchSettings.Encoding.
+	Data []byte `json:"data"`
+}
+
+// errDeadLetterSkip is returned by the function passed to deadLetterStore.replay to skip a record without
+// removing it, so that it can still be inspected.
+var errDeadLetterSkip = errors.New("dead letter record skipped")
+
+// deadLetterStore is where the records of the dead letter queue are kept.
+type deadLetterStore interface {
+	// add appends the record at the end of the store.
+	add(ctx context.Context, record []byte) error
+	// replay calls fn with the records in the order they were added. The records are removed from the store
+	// if fn returns nil, and skipped if it returns errDeadLetterSkip. It stops at the first other error
+	// returned by fn.
+	replay(ctx context.Context, fn func([]byte) error) error
+	close(ctx context.Context) error
+}
+
+// deadLetterSender is a sender that writes the requests that failed to be exported to a dead letter queue.
+type deadLetterSender struct {
+	cfg      DeadLetterConfig
+	id       component.ID
+	signal   pipeline.Signal
+	encoding queuebatch.Encoding[request.Request]
+	logger   *zap.Logger
+	next     sender.Sender[request.Request]
+
+	// queued indicates whether the requests are queued, so the errors are not returned to the caller.
+	queued bool
+	// persisted indicates whether the requests are kept by a persistent queue on shutdown.
+	persisted bool
+
+	// replaySender is the sender used to replay the kept requests, usually the first sender of the exporter.
+	replaySender sender.Sender[request.Request]
+
+	store      deadLetterStore
+	stopReplay context.CancelFunc
+	replayWG   sync.WaitGroup
+}
+
+func newDeadLetterSender(
+	cfg DeadLetterConfig,
+	set component.TelemetrySettings,
+	id component.ID,
+	signal pipeline.Signal,
+	encoding queuebatch.Encoding[request.Request],
+	qCfg queuebatch.Config,
+	next sender.Sender[request.Request],
+) *deadLetterSender {
+	return &deadLetterSender{
+		cfg:       cfg,
+		id:        id,
+		signal:    signal,
+		encoding:  encoding,
+		logger:    set.Logger,
+		next:      next,
+		queued:    qCfg.Enabled,
+		persisted: qCfg.Enabled && qCfg.StorageID != nil,
+	}
+}
+
+// Start opens the dead letter queue.
+func (dls *deadLetterSender) Start(ctx context.Context, host component.Host) error {
+	if dls.cfg.StorageID != nil {
+		client, err := toDeadLetterStorageClient(ctx, *dls.cfg.StorageID, host, dls.id, dls.signal)
+		if err != nil {
+			return err
+		}
+		store, err := newStorageDeadLetterStore(ctx, client)
+		if err != nil {
+			return errors.Join(err, client.Close(ctx))
+		}
+		dls.store = store
+	} else {
+		store, err := newFileDeadLetterStore(dls.cfg.Directory, dls.id, dls.signal)
+		if err != nil {
+			return err
+		}
+		dls.store = store
+	}
+	return nil
+}
+
+// startReplay starts replaying the kept requests in the background if configured.
+// It must be called once all the senders of the exporter are started.
+func (dls *deadLetterSender) startReplay() {
+	if !dls.cfg.ReplayOnStart || dls.replaySender == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	dls.stopReplay = cancel
+	dls.replayWG.Add(1)
+	go func() {
+		defer dls.replayWG.Done()
+		dls.replay(ctx)
+	}()
+}
+
+// replay sends the requests kept in the dead letter queue. It stops at the first request that is not accepted.
+// The requests written to the dead letter queue during the replay are kept for the next time.
+func (dls *deadLetterSender) replay(ctx context.Context) {
+	replayed := 0
+	err := dls.store.replay(ctx, func(buf []byte) error {
+		var record deadLetterRecord
+		if err := json.Unmarshal(buf, &record); err != nil {
+			dls.logger.Error("Failed to decode a dead letter record. Skipping it.", zap.Error(err))
+			return errDeadLetterSkip
+		}
+		if record.Exporter != dls.id.String() || record.Signal != dls.signal.String() {
+			dls.logger.Error("The dead letter record belongs to another exporter or signal. Skipping it.",
+				zap.String("record_exporter", record.Exporter), zap.String("record_signal", record.Signal))
+			return errDeadLetterSkip
+		}
+		req, err := dls.encoding.Unmarshal(record.Data)
+		if err != nil {
+			dls.logger.Error("Failed to decode a dead letter request. Skipping it.", zap.Error(err))
+			return errDeadLetterSkip
+		}
+		if err = dls.replaySender.Send(ctx, req); err != nil {
+			return err
+		}
+		replayed++
+		return nil
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		dls.logger.Warn("Failed to replay the dead letter queue, the remaining requests are kept.",
+			zap.Error(err), zap.Int("replayed_requests", replayed))
+		return
+	}
+	if replayed > 0 {
+		dls.logger.Info("Replayed the dead letter queue.", zap.Int("replayed_requests", replayed))
+	}
+}
+
+// Shutdown stops the replay and closes the dead letter queue.
+func (dls *deadLetterSender) Shutdown(ctx context.Context) error {
+	if dls.stopReplay != nil {
+		dls.stopReplay()
+		dls.replayWG.Wait()
+	}
+	if dls.store == nil {
+		return nil
+	}
+	err := dls.store.close(ctx)
+	dls.store = nil
+	return err
+}
+
+func (dls *deadLetterSender) Send(ctx context.Context, req request.Request) error {
+	err := dls.next.Send(ctx, req)
+	if err == nil || !dls.isDropped(err) {
+		return err
+	}
+
+	// Keep only the part of the request that failed, if known.
+	if errReq, ok := req.(request.ErrorHandler); ok {
+		req = errReq.OnError(err)
+	}
+	// The export may have failed because the context is canceled, the data must still be written.
+	if dlErr := dls.write(context.WithoutCancel(ctx), req, err); dlErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to write to the dead letter queue: %w", dlErr))
+	}
+	dls.logger.Warn("Exporting failed. The data is written to the dead letter queue.",
+		zap.Error(err), zap.Int("items", req.ItemsCount()))
+	return nil
+}
+
+// isDropped returns true if the request is dropped after returning the error, so it must be kept.
+func (dls *deadLetterSender) isDropped(err error) bool {
+	if !dls.queued {
+		// The error is returned to the caller which can retry the request, unless it is permanent.
+		return consumererror.IsPermanent(err)
+	}
+	// The persistent queue keeps the requests that are not exported on shutdown.
+	return !dls.persisted || !experr.IsShutdownErr(err)
+}
+
+func (dls *deadLetterSender) write(ctx context.Context, req request.Request, exportErr error) error {
+	if dls.store == nil {
+		return errors.New("the dead letter queue is not started")
+	}
+	data, err := dls.encoding.Marshal(req)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(deadLetterRecord{
+		Timestamp: time.Now().UTC(),
+		Exporter:  dls.id.String(),
+		Signal:    dls.signal.String(),
+		Items:     req.ItemsCount(),
+		Error:     exportErr.Error(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+	return dls.store.add(ctx, buf)
+}
+
+func toDeadLetterStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, signal pipeline.Signal) (storage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension %q for the dead letter queue not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindExporter, ownerID, "dead_letter_"+signal.String())
+}
+
+// storageDeadLetterStore keeps the records in a storage.Client, indexed like the persistent queue.
+type storageDeadLetterStore struct {
+	client storage.Client
+
+	// mu guards the indexes.
+	mu         sync.Mutex
+	readIndex  uint64
+	writeIndex uint64
+}
+
+func newStorageDeadLetterStore(ctx context.Context, client storage.Client) (*storageDeadLetterStore, error) {
+	ops := []*storage.Operation{
+		storage.GetOperation(deadLetterReadIndexKey),
+		storage.GetOperation(deadLetterWriteIndexKey),
+	}
+	if err := client.Batch(ctx, ops...); err != nil {
+		return nil, err
+	}
+	s := &storageDeadLetterStore{client: client}
+	var err error
+	if s.readIndex, err = bytesToDeadLetterIndex(ops[0].Value); err != nil {
+		return nil, err
+	}
+	if s.writeIndex, err = bytesToDeadLetterIndex(ops[1].Value); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *storageDeadLetterStore) add(ctx context.Context, record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.client.Batch(ctx,
+		storage.SetOperation(deadLetterItemKey(s.writeIndex), record),
+		storage.SetOperation(deadLetterWriteIndexKey, deadLetterIndexToBytes(s.writeIndex+1)))
+	if err != nil {
+		return err
+	}
+	s.writeIndex++
+	return nil
+}
+
+func (s *storageDeadLetterStore) replay(ctx context.Context, fn func([]byte) error) error {
+	// Only replay the records added before, the ones added during the replay are kept for the next time.
+	s.mu.Lock()
+	writeIndex := s.writeIndex
+	s.mu.Unlock()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		index := s.readIndex
+		s.mu.Unlock()
+		if index >= writeIndex {
+			return nil
+		}
+
+		record, err := s.client.Get(ctx, deadLetterItemKey(index))
+		if err != nil {
+			return err
+		}
+		// A missing record is skipped, e.g. if it was removed manually.
+		ops := []*storage.Operation{storage.SetOperation(deadLetterReadIndexKey, deadLetterIndexToBytes(index+1))}
+		if record != nil {
+			switch err = fn(record); {
+			case err == nil:
+				ops = append(ops, storage.DeleteOperation(deadLetterItemKey(index)))
+			case !errors.Is(err, errDeadLetterSkip):
+				return err
+			}
+		}
+
+		s.mu.Lock()
+		err = s.client.Batch(ctx, ops...)
+		if err == nil {
+			s.readIndex = index + 1
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *storageDeadLetterStore) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
+
+func deadLetterItemKey(index uint64) string {
+	return fmt.Sprintf("dl_%d", index)
+}
+
+func deadLetterIndexToBytes(index uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, index)
+}
+
+func bytesToDeadLetterIndex(buf []byte) (uint64, error) {
+	if buf == nil {
+		return 0, nil
+	}
+	if len(buf) != 8 {
+		return 0, errors.New("invalid dead letter queue index")
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// fileDeadLetterStore keeps every record in its own file, in a subdirectory per exporter and signal, named so
+// that the lexical order is the order the records were added: "<exporter>/<signal>/<unix nanoseconds>-<sequence>.json".
+// The exporter ID is escaped, so that the subdirectories of different exporters never overlap.
+type fileDeadLetterStore struct {
+	dir string
+	seq atomic.Uint64
+}
+
+func newFileDeadLetterStore(dir string, id component.ID, signal pipeline.Signal) (*fileDeadLetterStore, error) {
+	dir = filepath.Join(dir, url.PathEscape(id.String()), signal.String())
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the dead letter directory %q: %w", dir, err)
+	}
+	return &fileDeadLetterStore{dir: dir}, nil
+}
+
+func (s *fileDeadLetterStore) add(_ context.Context, record []byte) error {
+	name := fmt.Sprintf("%020d-%06d%s", time.Now().UnixNano(), s.seq.Add(1)%1_000_000, deadLetterFileExt)
+	// Write to a temporary file first, so that a partially written record is never replayed.
+	tmp, err := os.CreateTemp(s.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(record); err != nil {
+		return errors.Join(err, tmp.Close(), os.Remove(tmp.Name()))
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Join(err, os.Remove(tmp.Name()))
+	}
+	if err = os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return errors.Join(err, os.Remove(tmp.Name()))
+	}
+	return nil
+}
+
+func (s *fileDeadLetterStore) replay(ctx context.Context, fn func([]byte) error) error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+deadLetterFileExt))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		record, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		if err = fn(record); errors.Is(err, errDeadLetterSkip) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileDeadLetterStore) close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,458 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestDeadLetterConfig_Validate(t *testing.T) {
+	cfg := NewDefaultDeadLetterConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.EqualError(t, cfg.Validate(), "exactly one of `storage` or `directory` must be set")
+
+	cfg.Directory = t.TempDir()
+	require.NoError(t, cfg.Validate())
+
+	storageID := component.MustNewID("file_storage")
+	cfg.StorageID = &storageID
+	require.EqualError(t, cfg.Validate(), "exactly one of `storage` or `directory` must be set")
+
+	cfg.Directory = ""
+	require.NoError(t, cfg.Validate())
+}
+
+func TestDeadLetterStores(t *testing.T) {
+	id := component.MustNewIDWithName("otlp", "backend")
+	tests := []struct {
+		name     string
+		newStore func(t *testing.T) func() deadLetterStore
+	}{
+		{
+			name: "file",
+			newStore: func(t *testing.T) func() deadLetterStore {
+				dir := t.TempDir()
+				return func() deadLetterStore {
+					s, err := newFileDeadLetterStore(dir, id, pipeline.SignalTraces)
+					require.NoError(t, err)
+					return s
+				}
+			},
+		},
+		{
+			name: "storage",
+			newStore: func(t *testing.T) func() deadLetterStore {
+				ext := storagetest.NewMockStorageExtension(nil)
+				return func() deadLetterStore {
+					client, err := ext.GetClient(context.Background(), component.KindExporter, id, "dead_letter_traces")
+					require.NoError(t, err)
+					s, err := newStorageDeadLetterStore(context.Background(), client)
+					require.NoError(t, err)
+					return s
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newStore := tt.newStore(t)
+			store := newStore()
+			for i := 0; i < 3; i++ {
+				require.NoError(t, store.add(context.Background(), []byte(strconv.Itoa(i))))
+			}
+
+			// The replay stops at the first error and keeps the remaining records.
+			errReplay := errors.New("replay error")
+			var replayed []string
+			err := store.replay(context.Background(), func(record []byte) error {
+				if len(replayed) == 1 {
+					return errReplay
+				}
+				replayed = append(replayed, string(record))
+				return nil
+			})
+			require.ErrorIs(t, err, errReplay)
+			assert.Equal(t, []string{"0"}, replayed)
+			require.NoError(t, store.close(context.Background()))
+
+			// The remaining records are restored when the store is opened again.
+			store = newStore()
+			replayed = nil
+			require.NoError(t, store.replay(context.Background(), func(record []byte) error {
+				replayed = append(replayed, string(record))
+				if string(record) == "1" {
+					// The skipped records are kept, but not replayed again.
+					return errDeadLetterSkip
+				}
+				// The records added during the replay are kept for the next replay.
+				return store.add(context.Background(), []byte("new"))
+			}))
+			assert.Equal(t, []string{"1", "2"}, replayed)
+
+			replayed = nil
+			require.NoError(t, store.replay(context.Background(), func(record []byte) error {
+				replayed = append(replayed, string(record))
+				if string(record) == "1" {
+					return errDeadLetterSkip
+				}
+				return nil
+			}))
+			// The file store replays the skipped records again, the storage store only keeps them.
+			assert.Contains(t, [][]string{{"new"}, {"1", "new"}}, replayed)
+			require.NoError(t, store.close(context.Background()))
+		})
+	}
+}
+
+func TestDeadLetterFileStore_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := newFileDeadLetterStore(dir, component.MustNewID("otlp"), pipeline.SignalLogs)
+	require.NoError(t, err)
+	require.NoError(t, store.add(context.Background(), []byte("logs")))
+
+	other, err := newFileDeadLetterStore(dir, component.MustNewID("otlp"), pipeline.SignalMetrics)
+	require.NoError(t, err)
+	require.NoError(t, other.add(context.Background(), []byte("metrics")))
+	// The store of an exporter whose ID starts like the one of the store must not overlap with it.
+	similar, err := newFileDeadLetterStore(dir, component.MustNewIDWithName("otlp", "logs"), pipeline.SignalLogs)
+	require.NoError(t, err)
+	require.NoError(t, similar.add(context.Background(), []byte("similar")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("unrelated"), 0o600))
+
+	var replayed []string
+	require.NoError(t, store.replay(context.Background(), func(record []byte) error {
+		replayed = append(replayed, string(record))
+		return nil
+	}))
+	assert.Equal(t, []string{"logs"}, replayed)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+func TestDeadLetterSender_ReplayKeepsInvalidRecords(t *testing.T) {
+	id := component.MustNewID("otlp")
+	dlCfg := DeadLetterConfig{Enabled: true, Directory: t.TempDir()}
+	dls := newDeadLetterSender(dlCfg, exportertest.NewNopSettings(exportertest.NopType).TelemetrySettings, id,
+		pipeline.SignalLogs, itemsEncoding{}, NewDefaultQueueConfig(), nil)
+	require.NoError(t, dls.Start(context.Background(), hosttest.NewHost(nil)))
+
+	newRecord := func(exporter, signal string, data []byte) []byte {
+		buf, err := json.Marshal(deadLetterRecord{Exporter: exporter, Signal: signal, Data: data})
+		require.NoError(t, err)
+		return buf
+	}
+	invalid := [][]byte{
+		[]byte("not json"),
+		newRecord("otlp/other", "logs", []byte("1")),
+		newRecord("otlp", "traces", []byte("2")),
+		newRecord("otlp", "logs", []byte("not a number")),
+	}
+	for _, record := range invalid {
+		require.NoError(t, dls.store.add(context.Background(), record))
+	}
+	require.NoError(t, dls.store.add(context.Background(), newRecord("otlp", "logs", []byte("3"))))
+
+	sink := requesttest.NewSink()
+	dls.replaySender = sender.NewSender(sink.Export)
+	dls.replay(context.Background())
+	assert.Equal(t, 3, sink.ItemsCount())
+
+	// The invalid records are left in the dead letter queue.
+	var kept [][]byte
+	require.NoError(t, dls.store.replay(context.Background(), func(record []byte) error {
+		kept = append(kept, record)
+		return errDeadLetterSkip
+	}))
+	assert.Equal(t, invalid, kept)
+	require.NoError(t, dls.Shutdown(context.Background()))
+}
+
+func TestBaseExporter_DeadLetter(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		name  string
+		cfg   func(t *testing.T) DeadLetterConfig
+		queue bool
+		err   error
+	}{
+		{
+			name: "directory_permanent_error",
+			cfg: func(t *testing.T) DeadLetterConfig {
+				return DeadLetterConfig{Enabled: true, Directory: t.TempDir()}
+			},
+			err: consumererror.NewPermanent(errors.New("bad data")),
+		},
+		{
+			name: "storage_permanent_error",
+			cfg: func(*testing.T) DeadLetterConfig {
+				return DeadLetterConfig{Enabled: true, StorageID: &storageID}
+			},
+			err: consumererror.NewPermanent(errors.New("bad data")),
+		},
+		{
+			name: "directory_queue_error",
+			cfg: func(t *testing.T) DeadLetterConfig {
+				return DeadLetterConfig{Enabled: true, Directory: t.TempDir()}
+			},
+			queue: true,
+			err:   errors.New("unavailable"),
+		},
+		{
+			name: "storage_queue_error",
+			cfg: func(*testing.T) DeadLetterConfig {
+				return DeadLetterConfig{Enabled: true, StorageID: &storageID}
+			},
+			queue: true,
+			err:   errors.New("unavailable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := hosttest.NewHost(map[component.ID]component.Component{
+				storageID: storagetest.NewMockStorageExtension(nil),
+			})
+			dlCfg := tt.cfg(t)
+			qCfg := NewDefaultQueueConfig()
+			qCfg.Enabled = tt.queue
+			rCfg := configretry.NewDefaultBackOffConfig()
+			rCfg.Enabled = false
+
+			set := exportertest.NewNopSettings(exportertest.NopType)
+			rejected := make(chan struct{}, 1)
+			be, err := NewBaseExporter(set, pipeline.SignalTraces,
+				func(context.Context, request.Request) error {
+					rejected <- struct{}{}
+					return tt.err
+				},
+				WithQueueBatchSettings(newItemsQueueBatch()),
+				WithQueue(qCfg),
+				WithRetry(rCfg),
+				WithDeadLetter(dlCfg))
+			require.NoError(t, err)
+			require.NoError(t, be.Start(context.Background(), host))
+			require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 7}))
+			<-rejected
+			require.NoError(t, be.Shutdown(context.Background()))
+
+			// Check the written record without replaying it.
+			dls := newDeadLetterSender(dlCfg, set.TelemetrySettings, set.ID, pipeline.SignalTraces,
+				itemsEncoding{}, qCfg, nil)
+			require.NoError(t, dls.Start(context.Background(), host))
+			var records []deadLetterRecord
+			errKeep := errors.New("keep the record")
+			require.ErrorIs(t, dls.store.replay(context.Background(), func(buf []byte) error {
+				var record deadLetterRecord
+				require.NoError(t, json.Unmarshal(buf, &record))
+				records = append(records, record)
+				return errKeep
+			}), errKeep)
+			require.Len(t, records, 1)
+			assert.Equal(t, tt.err.Error(), records[0].Error)
+			assert.Equal(t, set.ID.String(), records[0].Exporter)
+			assert.Equal(t, "traces", records[0].Signal)
+			assert.Equal(t, 7, records[0].Items)
+			assert.Equal(t, []byte("7"), records[0].Data)
+			assert.WithinDuration(t, time.Now(), records[0].Timestamp, time.Minute)
+			require.NoError(t, dls.Shutdown(context.Background()))
+
+			// Replay the record when a new exporter starts.
+			dlCfg.ReplayOnStart = true
+			sink := requesttest.NewSink()
+			// The same exporter ID must be used to replay the records.
+			be, err = NewBaseExporter(set, pipeline.SignalTraces, sink.Export,
+				WithQueueBatchSettings(newItemsQueueBatch()),
+				WithQueue(qCfg),
+				WithRetry(rCfg),
+				WithDeadLetter(dlCfg))
+			require.NoError(t, err)
+			require.NoError(t, be.Start(context.Background(), host))
+			assert.Eventually(t, func() bool {
+				return sink.ItemsCount() == 7
+			}, time.Second, 10*time.Millisecond)
+			require.NoError(t, be.Shutdown(context.Background()))
+
+			// The replayed record is removed from the dead letter queue.
+			require.NoError(t, dls.Start(context.Background(), host))
+			require.NoError(t, dls.store.replay(context.Background(), func([]byte) error {
+				t.Fatal("the dead letter queue must be empty")
+				return nil
+			}))
+			require.NoError(t, dls.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestBaseExporter_DeadLetterReturnsRetryableError(t *testing.T) {
+	dlCfg := DeadLetterConfig{Enabled: true, Directory: t.TempDir()}
+	qCfg := NewDefaultQueueConfig()
+	qCfg.Enabled = false
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.Enabled = false
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, errExport,
+		WithQueueBatchSettings(newItemsQueueBatch()),
+		WithQueue(qCfg),
+		WithRetry(rCfg),
+		WithDeadLetter(dlCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), hosttest.NewHost(nil)))
+	// The error is returned to the caller that can retry, so the data is not written.
+	require.EqualError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}), "my error")
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	files, err := filepath.Glob(filepath.Join(dlCfg.Directory, "*", "*", "*"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestBaseExporter_DeadLetterErrors(t *testing.T) {
+	_, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, noopExport,
+		WithDeadLetter(DeadLetterConfig{Enabled: true, Directory: t.TempDir()}))
+	require.EqualError(t, err, "`QueueBatchSettings.Encoding` must not be nil when dead letter queue is enabled")
+
+	storageID := component.MustNewID("file_storage")
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, noopExport,
+		WithQueueBatchSettings(newItemsQueueBatch()),
+		WithDeadLetter(DeadLetterConfig{Enabled: true, StorageID: &storageID}))
+	require.NoError(t, err)
+	require.EqualError(t, be.Start(context.Background(), hosttest.NewHost(nil)),
+		"storage extension \"file_storage\" for the dead letter queue not found")
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, noopExport,
+		WithQueueBatchSettings(newItemsQueueBatch()),
+		WithDeadLetter(DeadLetterConfig{Enabled: true, StorageID: &storageID}))
+	require.NoError(t, err)
+	require.EqualError(t, be.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(errors.New("client error")),
+	})), "client error")
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporter_DeadLetterClosedOnQueueStartError(t *testing.T) {
+	dlStorageID := component.MustNewID("dl_storage")
+	ext := &recordingStorageExtension{Extension: storagetest.NewMockStorageExtension(nil)}
+	qCfg := NewDefaultQueueConfig()
+	missingID := component.MustNewID("missing")
+	qCfg.StorageID = &missingID
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, noopExport,
+		WithQueueBatchSettings(newItemsQueueBatch()),
+		WithQueue(qCfg),
+		WithDeadLetter(DeadLetterConfig{Enabled: true, StorageID: &dlStorageID}))
+	require.NoError(t, err)
+	require.Error(t, be.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{
+		dlStorageID: ext,
+	})))
+	require.Len(t, ext.clients, 1)
+	assert.True(t, ext.clients[0].(*storagetest.MockStorageClient).IsClosed())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestDeadLetterSender_WritesWithCanceledContext(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: &ctxStorageExtension{Extension: storagetest.NewMockStorageExtension(nil)},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	dls := newDeadLetterSender(DeadLetterConfig{Enabled: true, StorageID: &storageID},
+		exportertest.NewNopSettings(exportertest.NopType).TelemetrySettings, component.MustNewID("otlp"),
+		pipeline.SignalLogs, itemsEncoding{}, NewDefaultQueueConfig(),
+		sender.NewSender(func(ctx context.Context, _ request.Request) error {
+			cancel()
+			return ctx.Err()
+		}))
+	require.NoError(t, dls.Start(context.Background(), host))
+
+	// The request failed because the context is canceled, it is still written to the dead letter queue.
+	require.NoError(t, dls.Send(ctx, &requesttest.FakeRequest{Items: 3}))
+	var records int
+	require.NoError(t, dls.store.replay(context.Background(), func([]byte) error {
+		records++
+		return errDeadLetterSkip
+	}))
+	assert.Equal(t, 1, records)
+	require.NoError(t, dls.Shutdown(context.Background()))
+}
+
+// ctxStorageExtension returns the clients of the wrapped storage extension, failing the operations
+// with a canceled context.
+type ctxStorageExtension struct {
+	storage.Extension
+}
+
+func (e *ctxStorageExtension) GetClient(ctx context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	client, err := e.Extension.GetClient(ctx, kind, id, name)
+	return ctxStorageClient{Client: client}, err
+}
+
+type ctxStorageClient struct {
+	storage.Client
+}
+
+func (c ctxStorageClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Client.Batch(ctx, ops...)
+}
+
+// recordingStorageExtension records the clients returned by the wrapped storage extension.
+type recordingStorageExtension struct {
+	storage.Extension
+	clients []storage.Client
+}
+
+func (e *recordingStorageExtension) GetClient(ctx context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	client, err := e.Extension.GetClient(ctx, kind, id, name)
+	if err == nil {
+		e.clients = append(e.clients, client)
+	}
+	return client, err
+}
+
+func newItemsQueueBatch() QueueBatchSettings[request.Request] {
+	qbs := newFakeQueueBatch()
+	qbs.Encoding = itemsEncoding{}
+	return qbs
+}
+
+// itemsEncoding encodes the number of items of a requesttest.FakeRequest.
+type itemsEncoding struct{}
+
+func (itemsEncoding) Marshal(req request.Request) ([]byte, error) {
+	return []byte(strconv.Itoa(req.ItemsCount())), nil
+}
+
+func (itemsEncoding) Unmarshal(buf []byte) (request.Request, error) {
+	items, err := strconv.Atoi(string(buf))
+	if err != nil {
+		return nil, err
+	}
+	return &requesttest.FakeRequest{Items: items}, nil
+}
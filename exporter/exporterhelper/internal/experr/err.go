@@ -27,3 +27,26 @@ func IsShutdownErr(err error) bool {
 	var sdErr shutdownErr
 	return errors.As(err, &sdErr)
 }
+
+type circuitOpenErr struct {
+	err error
+}
+
+// NewCircuitOpenErr returns an error for a request rejected by an open circuit breaker,
+// wrapping the last error that was returned by the backend.
+func NewCircuitOpenErr(err error) error {
+	return circuitOpenErr{err: err}
+}
+
+func (c circuitOpenErr) Error() string {
+	return "circuit breaker is open: " + c.err.Error()
+}
+
+func (c circuitOpenErr) Unwrap() error {
+	return c.err
+}
+
+func IsCircuitOpenErr(err error) bool {
+	var coErr circuitOpenErr
+	return errors.As(err, &coErr)
+}
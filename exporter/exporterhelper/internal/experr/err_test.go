@@ -22,3 +22,16 @@ func TestIsShutdownErr(t *testing.T) {
 	err = NewShutdownErr(err)
 	require.True(t, IsShutdownErr(err))
 }
+
+func TestNewCircuitOpenErr(t *testing.T) {
+	err := NewCircuitOpenErr(errors.New("some error"))
+	assert.Equal(t, "circuit breaker is open: some error", err.Error())
+}
+
+func TestIsCircuitOpenErr(t *testing.T) {
+	err := errors.New("testError")
+	require.False(t, IsCircuitOpenErr(err))
+	err = NewCircuitOpenErr(err)
+	require.True(t, IsCircuitOpenErr(err))
+	require.False(t, IsShutdownErr(err))
+}
@@ -25,20 +25,23 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                             metric.Meter
-	mu                                sync.Mutex
-	registrations                     []metric.Registration
-	ExporterEnqueueFailedLogRecords   metric.Int64Counter
-	ExporterEnqueueFailedMetricPoints metric.Int64Counter
-	ExporterEnqueueFailedSpans        metric.Int64Counter
-	ExporterQueueCapacity             metric.Int64ObservableGauge
-	ExporterQueueSize                 metric.Int64ObservableGauge
-	ExporterSendFailedLogRecords      metric.Int64Counter
-	ExporterSendFailedMetricPoints    metric.Int64Counter
-	ExporterSendFailedSpans           metric.Int64Counter
-	ExporterSentLogRecords            metric.Int64Counter
-	ExporterSentMetricPoints          metric.Int64Counter
-	ExporterSentSpans                 metric.Int64Counter
+	meter                                    metric.Meter
+	mu                                       sync.Mutex
+	registrations                            []metric.Registration
+	ExporterEnqueueFailedLogRecords          metric.Int64Counter
+	ExporterEnqueueFailedMetricPoints        metric.Int64Counter
+	ExporterEnqueueFailedSpans               metric.Int64Counter
+	ExporterQueueBatchPartitionRejectedItems metric.Int64Counter
+	ExporterQueueBatchPartitions             metric.Int64ObservableGauge
+	ExporterQueueCapacity                    metric.Int64ObservableGauge
+	ExporterQueueConcurrencyLimit            metric.Int64ObservableGauge
+	ExporterQueueSize                        metric.Int64ObservableGauge
+	ExporterSendFailedLogRecords             metric.Int64Counter
+	ExporterSendFailedMetricPoints           metric.Int64Counter
+	ExporterSendFailedSpans                  metric.Int64Counter
+	ExporterSentLogRecords                   metric.Int64Counter
+	ExporterSentMetricPoints                 metric.Int64Counter
+	ExporterSentSpans                        metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -52,6 +55,21 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterExporterQueueBatchPartitionsCallback sets callback for observable ExporterQueueBatchPartitions metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueBatchPartitionsCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueBatchPartitions, obs: o})
+		return nil
+	}, builder.ExporterQueueBatchPartitions)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueCapacityCallback sets callback for observable ExporterQueueCapacity metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -67,6 +85,21 @@ func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric
 	return nil
 }
 
+// RegisterExporterQueueConcurrencyLimitCallback sets callback for observable ExporterQueueConcurrencyLimit metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueConcurrencyLimitCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueConcurrencyLimit, obs: o})
+		return nil
+	}, builder.ExporterQueueConcurrencyLimit)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueSizeCallback sets callback for observable ExporterQueueSize metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueSizeCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -128,12 +161,30 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueBatchPartitionRejectedItems, err = builder.meter.Int64Counter(
+		"otelcol_exporter_queue_batch_partition_rejected_items",
+		metric.WithDescription("Number of items rejected because the maximum number of batch partitions is reached. [development]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterQueueBatchPartitions, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_batch_partitions",
+		metric.WithDescription("Current number of partitions used for batching, see `sending_queue::batch::partition_by`. [development]"),
+		metric.WithUnit("{partitions}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterQueueCapacity, err = builder.meter.Int64ObservableGauge(
 		"otelcol_exporter_queue_capacity",
 		metric.WithDescription("Fixed capacity of the retry queue (in batches) [alpha]"),
 		metric.WithUnit("{batches}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueConcurrencyLimit, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_concurrency_limit",
+		metric.WithDescription("Current limit of concurrent exports, see `sending_queue::adaptive_concurrency`. [development]"),
+		metric.WithUnit("{exports}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterQueueSize, err = builder.meter.Int64ObservableGauge(
 		"otelcol_exporter_queue_size",
 		metric.WithDescription("Current size of the retry queue (in batches) [alpha]"),
@@ -5,11 +5,15 @@ package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhel
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/pipeline"
 )
 
 // Config defines configuration for queueing and batching incoming requests.
@@ -18,7 +22,8 @@ type Config struct {
 	Enabled bool `mapstructure:"enabled"`
 
 	// WaitForResult determines if incoming requests are blocked until the request is processed or not.
-	// Currently, this option is not available when persistent queue is configured using the storage configuration.
+	// If the persistent queue is configured using the storage configuration, incoming requests are always
+	// blocked until they are written to the storage, this option additionally blocks them until processed.
 	WaitForResult bool `mapstructure:"wait_for_result"`
 
 	// Sizer determines the type of size measurement used by this component.
@@ -42,10 +47,18 @@ type Config struct {
 
 	// NumConsumers is the maximum number of concurrent consumers from the queue.
 	// This applies across all different optional configurations from above (e.g. wait_for_result, blockOnOverflow, persistent, etc.).
-	// TODO: This will also control the maximum number of shards, when supported:
-	//  https://github.com/open-telemetry/opentelemetry-collector/issues/12473.
+	// If ShardBy is configured, this is the number of shards.
 	NumConsumers int `mapstructure:"num_consumers"`
 
+	// ShardBy configures how the requests are assigned to the consumers. If configured, every request is assigned
+	// to one of NumConsumers shards based on the hash of the configured key, and every shard is consumed
+	// sequentially. Like this, the order of the data with the same key is preserved.
+	ShardBy ShardConfig `mapstructure:"shard_by"`
+
+	// AdaptiveConcurrency if not nil, adapts the number of concurrent exports between
+	// AdaptiveConcurrencyConfig.MinConsumers and NumConsumers to the observed latency and throttling errors.
+	AdaptiveConcurrency *AdaptiveConcurrencyConfig `mapstructure:"adaptive_concurrency"`
+
 	// BatchConfig it configures how the requests are consumed from the queue and batch together during consumption.
 	// TODO: This will be changed to Optional when available.
 	Batch *BatchConfig `mapstructure:"batch"`
@@ -55,6 +68,12 @@ type Config struct {
 }
 
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	// Start from the default adaptive concurrency configuration if it is configured.
+	if conf.IsSet("adaptive_concurrency") && cfg.AdaptiveConcurrency == nil {
+		defaultCfg := NewDefaultAdaptiveConcurrencyConfig()
+		cfg.AdaptiveConcurrency = &defaultCfg
+	}
+
 	if err := conf.Unmarshal(cfg); err != nil {
 		return err
 	}
@@ -82,24 +101,105 @@ func (cfg *Config) Validate() error {
 		return errors.New("`queue_size` must be positive")
 	}
 
-	// Only support request sizer for persistent queue at this moment.
-	if cfg.StorageID != nil && cfg.WaitForResult {
-		return errors.New("`wait_for_result` is not supported with a persistent queue configured with `storage`")
+	// Only support items sizer for batch at this moment.
+	if cfg.Batch != nil && (cfg.Sizer != request.SizerTypeItems && cfg.Sizer != request.SizerTypeBytes) {
+		return errors.New("`batch` supports only `items` or `bytes` sizer")
 	}
 
-	// Only support request sizer for persistent queue at this moment.
-	if cfg.StorageID != nil && cfg.Sizer != request.SizerTypeRequests {
-		return errors.New("persistent queue configured with `storage` only supports `requests` sizer")
+	if cfg.ShardBy.enabled() && cfg.Batch != nil && cfg.Batch.PartitionBy.enabled() {
+		return errors.New("`batch::partition_by` is not supported with `shard_by`")
 	}
 
-	// Only support items sizer for batch at this moment.
-	if cfg.Batch != nil && (cfg.Sizer != request.SizerTypeItems && cfg.Sizer != request.SizerTypeBytes) {
-		return errors.New("`batch` supports only `items` or `bytes` sizer")
+	if cfg.AdaptiveConcurrency != nil && cfg.AdaptiveConcurrency.MinConsumers > cfg.NumConsumers {
+		return errors.New("`adaptive_concurrency::min_consumers` must be less than or equal to `num_consumers`")
+	}
+
+	return nil
+}
+
+// ShardConfig defines a configuration for assigning requests to shards.
+type ShardConfig struct {
+	// MetadataKeys is a list of client.Metadata keys used as the shard key.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// ResourceAttributes is a list of resource attributes used as the shard key.
+	// The requests containing multiple resources are split if necessary.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// TraceID indicates whether the trace ID of the spans and log records is used as the shard key.
+	// The requests containing multiple traces are split if necessary.
+	TraceID bool `mapstructure:"trace_id"`
+}
+
+// enabled returns true if any sharding is configured.
+func (cfg *ShardConfig) enabled() bool {
+	return len(cfg.MetadataKeys) > 0 || len(cfg.ResourceAttributes) > 0 || cfg.TraceID
+}
+
+func (cfg *ShardConfig) Validate() error {
+	if len(cfg.ResourceAttributes) > 0 && cfg.TraceID {
+		return errors.New("`resource_attributes` and `trace_id` cannot be used together")
+	}
+	return nil
+}
+
+// validateSignal returns an error if the configured key is not supported by the given signal. It is checked when
+// the exporter is created, as the configuration does not know the signals it is used with.
+func (cfg *ShardConfig) validateSignal(signal pipeline.Signal) error {
+	if cfg.TraceID && signal != pipeline.SignalTraces && signal != pipeline.SignalLogs {
+		return fmt.Errorf("`shard_by::trace_id` is not supported for %s", signal)
+	}
+	return nil
+}
+
+// AdaptiveConcurrencyConfig defines a configuration for adapting the number of concurrent exports, using an
+// additive increase/multiplicative decrease (AIMD) algorithm: the limit is increased by one while the exports
+// succeed, and multiplied by BackoffRatio when an export is throttled, times out, or exceeds LatencyThreshold.
+type AdaptiveConcurrencyConfig struct {
+	// MinConsumers is the minimum number of concurrent exports, and the initial one.
+	// The maximum number of concurrent exports is the number of consumers.
+	MinConsumers int `mapstructure:"min_consumers"`
+
+	// LatencyThreshold is the duration above which an export decreases the limit like a throttled export.
+	// Zero means that the latency is not taken into account.
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// BackoffRatio is the ratio the limit is multiplied by when an export is throttled.
+	BackoffRatio float64 `mapstructure:"backoff_ratio"`
+}
+
+// NewDefaultAdaptiveConcurrencyConfig returns the default config for AdaptiveConcurrencyConfig.
+func NewDefaultAdaptiveConcurrencyConfig() AdaptiveConcurrencyConfig {
+	return AdaptiveConcurrencyConfig{
+		MinConsumers: 1,
+		BackoffRatio: 0.9,
+	}
+}
+
+func (cfg *AdaptiveConcurrencyConfig) Validate() error {
+	if cfg.MinConsumers <= 0 {
+		return errors.New("`min_consumers` must be positive")
+	}
+
+	if cfg.LatencyThreshold < 0 {
+		return errors.New("`latency_threshold` must be non-negative")
+	}
+
+	if cfg.BackoffRatio < 0.5 || cfg.BackoffRatio >= 1 {
+		return errors.New("`backoff_ratio` must be greater than or equal to 0.5 and less than 1")
 	}
 
 	return nil
 }
 
+// CustomizeSchema adds the values accepted by Validate to the JSON Schema of the AdaptiveConcurrencyConfig.
+func (cfg *AdaptiveConcurrencyConfig) CustomizeSchema(schema *xconfmap.Schema) {
+	one, minRatio := 1.0, 0.5
+	schema.Properties["min_consumers"].Minimum = &one
+	schema.Properties["backoff_ratio"].Minimum = &minRatio
+	schema.Properties["backoff_ratio"].ExclusiveMaximum = &one
+}
+
 // BatchConfig defines a configuration for batching requests based on a timeout and a minimum number of items.
 type BatchConfig struct {
 	// FlushTimeout sets the time after which a batch will be sent regardless of its size.
@@ -110,6 +210,67 @@ type BatchConfig struct {
 
 	// MaxSize defines the configuration for the maximum size of a batch.
 	MaxSize int64 `mapstructure:"max_size"`
+
+	// PartitionBy defines how the requests are partitioned, so that only the data of the same partition is batched
+	// together. If not configured, all the data is batched together.
+	PartitionBy PartitionConfig `mapstructure:"partition_by"`
+}
+
+// PartitionConfig defines a configuration for partitioning requests before batching.
+// Every distinct combination of the configured values gets its own batch and flush timer.
+type PartitionConfig struct {
+	// MetadataKeys is a list of client.Metadata keys used to partition the data.
+	// Empty value and unset metadata are treated as distinct cases.
+	// Entries are case-insensitive. Duplicated entries will trigger a validation error.
+	MetadataKeys []string `mapstructure:"metadata_keys"`
+
+	// ResourceAttributes is a list of resource attributes used to partition the data.
+	// Empty value and unset attributes are treated as distinct cases.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// Scope indicates whether the data is partitioned by the instrumentation scope name and version.
+	Scope bool `mapstructure:"scope"`
+
+	// CardinalityLimit is the maximum number of partitions. Once the limit is reached, the partitions
+	// without pending data are evicted, and the data that belongs to a new partition is rejected if there
+	// are none. Zero means no limit.
+	CardinalityLimit int `mapstructure:"cardinality_limit"`
+}
+
+// enabled returns true if any partitioning is configured.
+func (cfg *PartitionConfig) enabled() bool {
+	return len(cfg.MetadataKeys) > 0 || len(cfg.ResourceAttributes) > 0 || cfg.Scope
+}
+
+func (cfg *PartitionConfig) Validate() error {
+	uniq := map[string]struct{}{}
+	for _, k := range cfg.MetadataKeys {
+		l := strings.ToLower(k)
+		if _, ok := uniq[l]; ok {
+			return fmt.Errorf("duplicate entry in `metadata_keys`: %q (case-insensitive)", l)
+		}
+		uniq[l] = struct{}{}
+	}
+
+	uniq = map[string]struct{}{}
+	for _, k := range cfg.ResourceAttributes {
+		if _, ok := uniq[k]; ok {
+			return fmt.Errorf("duplicate entry in `resource_attributes`: %q", k)
+		}
+		uniq[k] = struct{}{}
+	}
+
+	if cfg.CardinalityLimit < 0 {
+		return errors.New("`cardinality_limit` must be non-negative")
+	}
+
+	return nil
+}
+
+// CustomizeSchema adds the values accepted by Validate to the JSON Schema of the PartitionConfig.
+func (cfg *PartitionConfig) CustomizeSchema(schema *xconfmap.Schema) {
+	zero := 0.0
+	schema.Properties["cardinality_limit"].Minimum = &zero
 }
 
 func (cfg *BatchConfig) Validate() error {
@@ -12,7 +12,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/pipeline"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -34,17 +36,17 @@ func TestConfig_Validate(t *testing.T) {
 	cfg = newTestConfig()
 	cfg.WaitForResult = true
 	cfg.StorageID = &storageID
-	require.EqualError(t, cfg.Validate(), "`wait_for_result` is not supported with a persistent queue configured with `storage`")
+	require.NoError(t, cfg.Validate())
 
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeBytes
 	cfg.StorageID = &storageID
-	require.EqualError(t, cfg.Validate(), "persistent queue configured with `storage` only supports `requests` sizer")
+	require.NoError(t, cfg.Validate())
 
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeItems
 	cfg.StorageID = &storageID
-	require.EqualError(t, cfg.Validate(), "persistent queue configured with `storage` only supports `requests` sizer")
+	require.NoError(t, cfg.Validate())
 
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeRequests
@@ -54,6 +56,12 @@ func TestConfig_Validate(t *testing.T) {
 	cfg.Sizer = request.SizerTypeBytes
 	require.NoError(t, cfg.Validate())
 
+	cfg = newTestConfig()
+	cfg.ShardBy = ShardConfig{TraceID: true}
+	require.NoError(t, cfg.Validate())
+	cfg.Batch.PartitionBy = PartitionConfig{Scope: true}
+	require.EqualError(t, cfg.Validate(), "`batch::partition_by` is not supported with `shard_by`")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	cfg.Enabled = false
 	assert.NoError(t, cfg.Validate())
@@ -96,6 +104,119 @@ func TestBatchConfig_Validate(t *testing.T) {
 	require.EqualError(t, cfg.Validate(), "`max_size` must be greater or equal to `min_size`")
 }
 
+func TestPartitionConfig_Validate(t *testing.T) {
+	cfg := PartitionConfig{
+		MetadataKeys:       []string{"tenant", "project"},
+		ResourceAttributes: []string{"service.name"},
+		Scope:              true,
+		CardinalityLimit:   100,
+	}
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.enabled())
+	assert.False(t, (&PartitionConfig{CardinalityLimit: 100}).enabled())
+
+	cfg.MetadataKeys = []string{"tenant", "Tenant"}
+	require.EqualError(t, cfg.Validate(), "duplicate entry in `metadata_keys`: \"tenant\" (case-insensitive)")
+
+	cfg.MetadataKeys = nil
+	cfg.ResourceAttributes = []string{"service.name", "service.name"}
+	require.EqualError(t, cfg.Validate(), "duplicate entry in `resource_attributes`: \"service.name\"")
+
+	cfg.ResourceAttributes = nil
+	cfg.CardinalityLimit = -1
+	require.EqualError(t, cfg.Validate(), "`cardinality_limit` must be non-negative")
+}
+
+func TestPartitionConfigUnmarshal(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"enabled":       true,
+		"num_consumers": 2,
+		"queue_size":    100,
+		"sizer":         "items",
+		"batch": map[string]any{
+			"flush_timeout": "1s",
+			"partition_by": map[string]any{
+				"metadata_keys":       []any{"tenant"},
+				"resource_attributes": []any{"service.name"},
+				"scope":               true,
+				"cardinality_limit":   10,
+			},
+		},
+	})
+
+	qCfg := Config{}
+	require.NoError(t, conf.Unmarshal(&qCfg))
+	require.NotNil(t, qCfg.Batch)
+	assert.Equal(t, PartitionConfig{
+		MetadataKeys:       []string{"tenant"},
+		ResourceAttributes: []string{"service.name"},
+		Scope:              true,
+		CardinalityLimit:   10,
+	}, qCfg.Batch.PartitionBy)
+}
+
+func TestShardConfig_Validate(t *testing.T) {
+	cfg := ShardConfig{MetadataKeys: []string{"tenant"}, TraceID: true}
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.enabled())
+	assert.False(t, (&ShardConfig{}).enabled())
+
+	cfg.ResourceAttributes = []string{"service.name"}
+	require.EqualError(t, cfg.Validate(), "`resource_attributes` and `trace_id` cannot be used together")
+}
+
+func TestShardConfig_ValidateSignal(t *testing.T) {
+	cfg := ShardConfig{TraceID: true}
+	require.NoError(t, cfg.validateSignal(pipeline.SignalTraces))
+	require.NoError(t, cfg.validateSignal(pipeline.SignalLogs))
+	require.EqualError(t, cfg.validateSignal(pipeline.SignalMetrics), "`shard_by::trace_id` is not supported for metrics")
+	require.NoError(t, (&ShardConfig{MetadataKeys: []string{"tenant"}}).validateSignal(pipeline.SignalMetrics))
+}
+
+func TestAdaptiveConcurrencyConfig_Validate(t *testing.T) {
+	cfg := NewDefaultAdaptiveConcurrencyConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.MinConsumers = 0
+	require.EqualError(t, cfg.Validate(), "`min_consumers` must be positive")
+
+	cfg = NewDefaultAdaptiveConcurrencyConfig()
+	cfg.LatencyThreshold = -time.Second
+	require.EqualError(t, cfg.Validate(), "`latency_threshold` must be non-negative")
+
+	cfg = NewDefaultAdaptiveConcurrencyConfig()
+	cfg.BackoffRatio = 1
+	require.EqualError(t, cfg.Validate(), "`backoff_ratio` must be greater than or equal to 0.5 and less than 1")
+
+	qCfg := newTestConfig()
+	qCfg.AdaptiveConcurrency = &AdaptiveConcurrencyConfig{MinConsumers: qCfg.NumConsumers + 1, BackoffRatio: 0.9}
+	require.EqualError(t, qCfg.Validate(), "`adaptive_concurrency::min_consumers` must be less than or equal to `num_consumers`")
+}
+
+func TestAdaptiveConcurrencyConfigUnmarshal(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"enabled":       true,
+		"num_consumers": 20,
+		"queue_size":    100,
+		"adaptive_concurrency": map[string]any{
+			"latency_threshold": "2s",
+		},
+	})
+
+	// The default values are used for the fields that are not set.
+	qCfg := Config{}
+	require.NoError(t, conf.Unmarshal(&qCfg))
+	assert.Equal(t, &AdaptiveConcurrencyConfig{
+		MinConsumers:     1,
+		LatencyThreshold: 2 * time.Second,
+		BackoffRatio:     0.9,
+	}, qCfg.AdaptiveConcurrency)
+
+	qCfg = Config{}
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{"num_consumers": 2}).Unmarshal(&qCfg))
+	assert.Nil(t, qCfg.AdaptiveConcurrency)
+}
+
 func newTestBatchConfig() BatchConfig {
 	return BatchConfig{
 		FlushTimeout: 200 * time.Millisecond,
@@ -103,3 +224,13 @@ func newTestBatchConfig() BatchConfig {
 		MaxSize:      0,
 	}
 }
+
+func TestConfigSchema(t *testing.T) {
+	schema := xconfmap.NewSchema(Config{Batch: &BatchConfig{}})
+	adaptive := schema.Properties["adaptive_concurrency"]
+	assert.InDelta(t, 1, *adaptive.Properties["min_consumers"].Minimum, 0)
+	assert.InDelta(t, 0.5, *adaptive.Properties["backoff_ratio"].Minimum, 0)
+	assert.InDelta(t, 1, *adaptive.Properties["backoff_ratio"].ExclusiveMaximum, 0)
+	partition := schema.Properties["batch"].Properties["partition_by"]
+	assert.InDelta(t, 0, *partition.Properties["cardinality_limit"].Minimum, 0)
+}
@@ -26,6 +26,8 @@ type batcherSettings[T any] struct {
 	sizer      request.Sizer[T]
 	next       sender.SendFunc[T]
 	maxWorkers int
+	// sequential indicates that the batches are flushed synchronously one at a time, in the order they are formed.
+	sequential bool
 }
 
 // defaultBatcher continuously batch incoming requests and flushes asynchronously if minimum size limit is met or on timeout.
@@ -40,17 +42,29 @@ type defaultBatcher struct {
 	currentBatch   *batch
 	timer          *time.Timer
 	shutdownCh     chan struct{}
+	sequential     bool
+	// flushMu serializes the processing if sequential is set. It is acquired before currentBatchMu.
+	flushMu sync.Mutex
 }
 
 func newDefaultBatcher(bCfg BatchConfig, bSet batcherSettings[request.Request]) *defaultBatcher {
+	return newDefaultBatcherWithWorkerPool(bCfg, bSet, newWorkerPool(bSet.maxWorkers))
+}
+
+// newWorkerPool returns a pool limiting the number of concurrent flushes, or nil if not limited.
+func newWorkerPool(maxWorkers int) chan struct{} {
 	// TODO: Determine what is the right behavior for this in combination with async queue.
-	var workerPool chan struct{}
-	if bSet.maxWorkers != 0 {
-		workerPool = make(chan struct{}, bSet.maxWorkers)
-		for i := 0; i < bSet.maxWorkers; i++ {
-			workerPool <- struct{}{}
-		}
+	if maxWorkers == 0 {
+		return nil
+	}
+	workerPool := make(chan struct{}, maxWorkers)
+	for i := 0; i < maxWorkers; i++ {
+		workerPool <- struct{}{}
 	}
+	return workerPool
+}
+
+func newDefaultBatcherWithWorkerPool(bCfg BatchConfig, bSet batcherSettings[request.Request], workerPool chan struct{}) *defaultBatcher {
 	return &defaultBatcher{
 		cfg:         bCfg,
 		workerPool:  workerPool,
@@ -59,6 +73,7 @@ func newDefaultBatcher(bCfg BatchConfig, bSet batcherSettings[request.Request])
 		consumeFunc: bSet.next,
 		stopWG:      sync.WaitGroup{},
 		shutdownCh:  make(chan struct{}, 1),
+		sequential:  bSet.sequential,
 	}
 }
 
@@ -69,6 +84,10 @@ func (qb *defaultBatcher) resetTimer() {
 }
 
 func (qb *defaultBatcher) Consume(ctx context.Context, req request.Request, done Done) {
+	if qb.sequential {
+		qb.flushMu.Lock()
+		defer qb.flushMu.Unlock()
+	}
 	qb.currentBatchMu.Lock()
 
 	if qb.currentBatch == nil {
@@ -192,6 +211,10 @@ func (qb *defaultBatcher) Start(_ context.Context, _ component.Host) error {
 
 // flushCurrentBatchIfNecessary sends out the current request batch if it is not nil
 func (qb *defaultBatcher) flushCurrentBatchIfNecessary() {
+	if qb.sequential {
+		qb.flushMu.Lock()
+		defer qb.flushMu.Unlock()
+	}
 	qb.currentBatchMu.Lock()
 	if qb.currentBatch == nil {
 		qb.currentBatchMu.Unlock()
@@ -207,7 +230,12 @@ func (qb *defaultBatcher) flushCurrentBatchIfNecessary() {
 }
 
 // flush starts a goroutine that calls consumeFunc. It blocks until a worker is available if necessary.
+// If the batcher is sequential, it calls consumeFunc synchronously instead.
 func (qb *defaultBatcher) flush(ctx context.Context, req request.Request, done Done) {
+	if qb.sequential {
+		done.OnDone(qb.consumeFunc(ctx, req))
+		return
+	}
 	qb.stopWG.Add(1)
 	if qb.workerPool != nil {
 		<-qb.workerPool
@@ -221,6 +249,13 @@ func (qb *defaultBatcher) flush(ctx context.Context, req request.Request, done D
 	}()
 }
 
+// hasPendingBatch returns whether some data is waiting in the current batch.
+func (qb *defaultBatcher) hasPendingBatch() bool {
+	qb.currentBatchMu.Lock()
+	defer qb.currentBatchMu.Unlock()
+	return qb.currentBatch != nil
+}
+
 // Shutdown ensures that queue and all Batcher are stopped.
 func (qb *defaultBatcher) Shutdown(_ context.Context) error {
 	close(qb.shutdownCh)
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var errTooManyPartitions = errors.New("too many batch partitions, see `partition_by::cardinality_limit`")
+
+// partitionBatcher is a Batcher that keeps a separate batch and flush timer per partition.
+// All the partitions share the same pool of workers used for flushing. Once the cardinality limit is reached,
+// the idle partitions without pending data are evicted to make room for the new ones.
+type partitionBatcher struct {
+	cfg         BatchConfig
+	bSet        batcherSettings[request.Request]
+	workerPool  chan struct{}
+	partitioner Partitioner[request.Request]
+	keyFunc     request.ResourceScopeKeyFunc
+	logger      *zap.Logger
+	tb          *metadata.TelemetryBuilder
+	metricAttr  metric.MeasurementOption
+
+	// partitionsMu guards everything declared below.
+	partitionsMu sync.Mutex
+	partitions   map[string]*partition
+	stopped      bool
+	// evictWG tracks the shutdown of the evicted partitions.
+	evictWG sync.WaitGroup
+}
+
+// partition is the batcher of a partition, with the number of callers currently consuming data with it.
+type partition struct {
+	*defaultBatcher
+	users int
+}
+
+func newPartitionBatcher(bCfg BatchConfig, bSet batcherSettings[request.Request], set Settings[request.Request]) (*partitionBatcher, error) {
+	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	pb := &partitionBatcher{
+		cfg:         bCfg,
+		bSet:        bSet,
+		workerPool:  newWorkerPool(bSet.maxWorkers),
+		partitioner: newMetadataPartitioner(bCfg.PartitionBy.MetadataKeys),
+		logger:      set.Telemetry.Logger,
+		tb:          tb,
+		metricAttr:  metric.WithAttributeSet(attribute.NewSet(attribute.String(exporterKey, set.ID.String()))),
+		partitions:  map[string]*partition{},
+	}
+	if len(bCfg.PartitionBy.ResourceAttributes) > 0 || bCfg.PartitionBy.Scope {
+		pb.keyFunc = newResourceScopeKeyFunc(bCfg.PartitionBy.ResourceAttributes, bCfg.PartitionBy.Scope)
+	}
+
+	asyncAttr := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String(exporterKey, set.ID.String()), attribute.String(dataTypeKey, set.Signal.String())))
+	err = tb.RegisterExporterQueueBatchPartitionsCallback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(int64(pb.numPartitions()), asyncAttr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+func (pb *partitionBatcher) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (pb *partitionBatcher) Consume(ctx context.Context, req request.Request, done Done) {
+	key := pb.partitioner.GetKey(ctx, req)
+
+	parts := map[string]request.Request{"": req}
+	if pb.keyFunc != nil {
+		if splitter, ok := req.(request.ResourceScopeSplitter); ok {
+			parts = splitter.SplitByResourceScope(pb.keyFunc)
+		}
+	}
+
+	// If the request is split, call done only when all the parts are done.
+	if len(parts) > 1 {
+		done = newRefCountDone(done, int64(len(parts)))
+	}
+	for partKey, part := range parts {
+		p, err := pb.getPartition(ctx, key+partKey)
+		if err != nil {
+			itemsCount := part.ItemsCount()
+			pb.logger.Error("Failed to batch data. Dropping data.", zap.Error(err), zap.Int("dropped_items", itemsCount))
+			pb.tb.ExporterQueueBatchPartitionRejectedItems.Add(ctx, int64(itemsCount), pb.metricAttr)
+			done.OnDone(err)
+			continue
+		}
+		p.Consume(ctx, part, done)
+		pb.releasePartition(p)
+	}
+}
+
+// getPartition returns the partition with the given key, it is created and started if necessary.
+// The partition cannot be evicted until it is released with releasePartition.
+func (pb *partitionBatcher) getPartition(ctx context.Context, key string) (*partition, error) {
+	pb.partitionsMu.Lock()
+	defer pb.partitionsMu.Unlock()
+	if p, ok := pb.partitions[key]; ok {
+		p.users++
+		return p, nil
+	}
+	if pb.stopped {
+		return nil, errors.New("the batcher is stopped")
+	}
+	if pb.cfg.PartitionBy.CardinalityLimit > 0 && len(pb.partitions) >= pb.cfg.PartitionBy.CardinalityLimit {
+		pb.evictIdlePartitions()
+		if len(pb.partitions) >= pb.cfg.PartitionBy.CardinalityLimit {
+			return nil, errTooManyPartitions
+		}
+	}
+
+	// The context of the batches does not keep the client information, so the metadata used for partitioning
+	// is added back to the context of the flushed batches. Like this, the exporter can still use it.
+	bSet := pb.bSet
+	if len(pb.cfg.PartitionBy.MetadataKeys) > 0 {
+		info := client.FromContext(ctx)
+		md := make(map[string][]string, len(pb.cfg.PartitionBy.MetadataKeys))
+		for _, k := range pb.cfg.PartitionBy.MetadataKeys {
+			md[k] = info.Metadata.Get(k)
+		}
+		partitionInfo := client.Info{Metadata: client.NewMetadata(md)}
+		bSet.next = func(ctx context.Context, req request.Request) error {
+			return pb.bSet.next(client.NewContext(ctx, partitionInfo), req)
+		}
+	}
+	b := newDefaultBatcherWithWorkerPool(pb.cfg, bSet, pb.workerPool)
+	// Start does not use the host and never fails.
+	_ = b.Start(context.Background(), nil)
+	p := &partition{defaultBatcher: b, users: 1}
+	pb.partitions[key] = p
+	return p, nil
+}
+
+func (pb *partitionBatcher) releasePartition(p *partition) {
+	pb.partitionsMu.Lock()
+	defer pb.partitionsMu.Unlock()
+	p.users--
+}
+
+// evictIdlePartitions removes the partitions that are not used by any caller and have no pending batch, then
+// shuts them down in the background, as they may still be flushing. Callers MUST hold partitionsMu.
+func (pb *partitionBatcher) evictIdlePartitions() {
+	for key, p := range pb.partitions {
+		if p.users > 0 || p.hasPendingBatch() {
+			continue
+		}
+		delete(pb.partitions, key)
+		pb.evictWG.Add(1)
+		go func() {
+			defer pb.evictWG.Done()
+			_ = p.Shutdown(context.Background())
+		}()
+	}
+}
+
+func (pb *partitionBatcher) numPartitions() int {
+	pb.partitionsMu.Lock()
+	defer pb.partitionsMu.Unlock()
+	return len(pb.partitions)
+}
+
+// Shutdown flushes and stops all the partitions.
+func (pb *partitionBatcher) Shutdown(ctx context.Context) error {
+	defer pb.tb.Shutdown()
+
+	pb.partitionsMu.Lock()
+	pb.stopped = true
+	partitions := pb.partitions
+	pb.partitionsMu.Unlock()
+
+	var errs error
+	for _, p := range partitions {
+		errs = errors.Join(errs, p.Shutdown(ctx))
+	}
+	pb.evictWG.Wait()
+	return errs
+}
+
+// newMetadataPartitioner returns a Partitioner that uses the values of the given client.Metadata keys as the key.
+func newMetadataPartitioner(keys []string) Partitioner[request.Request] {
+	return NewPartitioner(func(ctx context.Context, _ request.Request) string {
+		if len(keys) == 0 {
+			return ""
+		}
+		info := client.FromContext(ctx)
+		var buf []byte
+		for _, k := range keys {
+			buf = appendValues(buf, info.Metadata.Get(k))
+		}
+		return string(buf)
+	})
+}
+
+// newResourceScopeKeyFunc returns a function that uses the values of the given resource attributes and
+// optionally the instrumentation scope name and version as the key.
+func newResourceScopeKeyFunc(attrs []string, scope bool) request.ResourceScopeKeyFunc {
+	return func(res pcommon.Resource, is pcommon.InstrumentationScope) string {
+		var buf []byte
+		for _, k := range attrs {
+			if v, ok := res.Attributes().Get(k); ok {
+				buf = appendValues(buf, []string{v.AsString()})
+			} else {
+				buf = appendValues(buf, nil)
+			}
+		}
+		if scope {
+			buf = appendValues(buf, []string{is.Name(), is.Version()})
+		}
+		return string(buf)
+	}
+}
+
+// appendValues appends an unambiguous representation of the given values to the key,
+// so that the key is different for a missing value and for an empty value.
+func appendValues(buf []byte, vals []string) []byte {
+	buf = append(buf, '[')
+	for i, v := range vals {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendQuote(buf, v)
+	}
+	return append(buf, ']')
+}
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// partitionSink records the number of items exported per value of the "tenant" metadata key.
+type partitionSink struct {
+	mu    sync.Mutex
+	items map[string][]int
+}
+
+func newPartitionSink() *partitionSink {
+	return &partitionSink{items: map[string][]int{}}
+}
+
+func (s *partitionSink) export(ctx context.Context, req request.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tenant := ""
+	if vals := client.FromContext(ctx).Metadata.Get("tenant"); len(vals) > 0 {
+		tenant = vals[0]
+	}
+	s.items[tenant] = append(s.items[tenant], req.ItemsCount())
+	return nil
+}
+
+func (s *partitionSink) exported() map[string][]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make(map[string][]int, len(s.items))
+	for k, v := range s.items {
+		res[k] = append([]int(nil), v...)
+	}
+	return res
+}
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+	})
+}
+
+// fakeResourceRequest is a request with a number of items per value of the "tenant" resource attribute.
+type fakeResourceRequest struct {
+	requesttest.FakeRequest
+	tenants map[string]int
+}
+
+func (r *fakeResourceRequest) SplitByResourceScope(keyFunc request.ResourceScopeKeyFunc) map[string]request.Request {
+	res := map[string]request.Request{}
+	for tenant, items := range r.tenants {
+		resource := pcommon.NewResource()
+		resource.Attributes().PutStr("tenant", tenant)
+		res[keyFunc(resource, pcommon.NewInstrumentationScope())] = &requesttest.FakeRequest{Items: items}
+	}
+	return res
+}
+
+func newTestPartitionBatcher(t *testing.T, cfg BatchConfig, next func(context.Context, request.Request) error) *partitionBatcher {
+	pb, err := newPartitionBatcher(cfg, batcherSettings[request.Request]{
+		sizerType:  request.SizerTypeItems,
+		sizer:      request.NewItemsSizer(),
+		next:       next,
+		maxWorkers: 2,
+	}, newFakeRequestSettings())
+	require.NoError(t, err)
+	require.NoError(t, pb.Start(context.Background(), componenttest.NewNopHost()))
+	return pb
+}
+
+func TestPartitionBatcher_MetadataKeys(t *testing.T) {
+	sink := newPartitionSink()
+	pb := newTestPartitionBatcher(t, BatchConfig{
+		FlushTimeout: time.Hour,
+		MinSize:      10,
+		PartitionBy:  PartitionConfig{MetadataKeys: []string{"tenant"}},
+	}, sink.export)
+
+	done := newFakeDone()
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 5}, done)
+	pb.Consume(tenantContext("b"), &requesttest.FakeRequest{Items: 5}, done)
+	pb.Consume(context.Background(), &requesttest.FakeRequest{Items: 5}, done)
+	assert.Equal(t, 3, pb.numPartitions())
+	// Only the batch of the partition "a" reaches the minimum size.
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 6}, done)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string][]int{"a": {11}}, sink.exported())
+	}, 1*time.Second, 10*time.Millisecond)
+
+	// The pending batches are flushed on shutdown, the data of different partitions is never mixed.
+	require.NoError(t, pb.Shutdown(context.Background()))
+	assert.Equal(t, map[string][]int{"a": {11}, "b": {5}, "": {5}}, sink.exported())
+	assert.EqualValues(t, 4, done.success.Load())
+	assert.EqualValues(t, 0, done.errors.Load())
+}
+
+func TestPartitionBatcher_FlushTimeoutPerPartition(t *testing.T) {
+	sink := newPartitionSink()
+	pb := newTestPartitionBatcher(t, BatchConfig{
+		FlushTimeout: 50 * time.Millisecond,
+		MinSize:      100,
+		PartitionBy:  PartitionConfig{MetadataKeys: []string{"tenant"}},
+	}, sink.export)
+	t.Cleanup(func() { require.NoError(t, pb.Shutdown(context.Background())) })
+
+	done := newFakeDone()
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 3}, done)
+	pb.Consume(tenantContext("b"), &requesttest.FakeRequest{Items: 4}, done)
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 5}, done)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string][]int{"a": {8}, "b": {4}}, sink.exported())
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+func TestPartitionBatcher_CardinalityLimit(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	sink := newPartitionSink()
+	set := newFakeRequestSettings()
+	set.Telemetry = tt.NewTelemetrySettings()
+	pb, err := newPartitionBatcher(BatchConfig{
+		FlushTimeout: time.Hour,
+		MinSize:      100,
+		PartitionBy: PartitionConfig{
+			MetadataKeys:     []string{"tenant"},
+			CardinalityLimit: 2,
+		},
+	}, batcherSettings[request.Request]{
+		sizerType: request.SizerTypeItems,
+		sizer:     request.NewItemsSizer(),
+		next:      sink.export,
+	}, set)
+	require.NoError(t, err)
+	require.NoError(t, pb.Start(context.Background(), componenttest.NewNopHost()))
+
+	done := newFakeDone()
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 1}, done)
+	pb.Consume(tenantContext("b"), &requesttest.FakeRequest{Items: 2}, done)
+	pb.Consume(tenantContext("c"), &requesttest.FakeRequest{Items: 3}, done)
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 4}, done)
+	assert.EqualValues(t, 1, done.errors.Load())
+
+	metadatatest.AssertEqualExporterQueueBatchPartitions(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(exporterKey, exporterID.String()),
+					attribute.String(dataTypeKey, pipeline.SignalMetrics.String())),
+				Value: int64(2),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExporterQueueBatchPartitionRejectedItems(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String(exporterKey, exporterID.String())),
+				Value:      int64(3),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, pb.Shutdown(context.Background()))
+	assert.Equal(t, map[string][]int{"a": {5}, "b": {2}}, sink.exported())
+	assert.EqualValues(t, 3, done.success.Load())
+}
+
+func TestPartitionBatcher_EvictIdlePartitions(t *testing.T) {
+	sink := newPartitionSink()
+	pb := newTestPartitionBatcher(t, BatchConfig{
+		FlushTimeout: time.Hour,
+		MinSize:      10,
+		PartitionBy: PartitionConfig{
+			MetadataKeys:     []string{"tenant"},
+			CardinalityLimit: 2,
+		},
+	}, sink.export)
+
+	done := newFakeDone()
+	// The batch of the partition "a" is flushed right away, the one of the partition "b" is pending.
+	pb.Consume(tenantContext("a"), &requesttest.FakeRequest{Items: 10}, done)
+	pb.Consume(tenantContext("b"), &requesttest.FakeRequest{Items: 1}, done)
+	assert.Equal(t, 2, pb.numPartitions())
+	// Only the partition without pending data is evicted.
+	pb.Consume(tenantContext("c"), &requesttest.FakeRequest{Items: 1}, done)
+	assert.Equal(t, 2, pb.numPartitions())
+	pb.Consume(tenantContext("d"), &requesttest.FakeRequest{Items: 1}, done)
+	assert.EqualValues(t, 1, done.errors.Load())
+
+	require.NoError(t, pb.Shutdown(context.Background()))
+	assert.Equal(t, map[string][]int{"a": {10}, "b": {1}, "c": {1}}, sink.exported())
+	assert.EqualValues(t, 3, done.success.Load())
+}
+
+func TestPartitionBatcher_ResourceAttributes(t *testing.T) {
+	var mu sync.Mutex
+	var exported []int
+	pb := newTestPartitionBatcher(t, BatchConfig{
+		FlushTimeout: time.Hour,
+		MinSize:      10,
+		PartitionBy:  PartitionConfig{ResourceAttributes: []string{"tenant"}},
+	}, func(_ context.Context, req request.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		exported = append(exported, req.ItemsCount())
+		return nil
+	})
+
+	done := newFakeDone()
+	pb.Consume(context.Background(), &fakeResourceRequest{tenants: map[string]int{"a": 4, "b": 3}}, done)
+	pb.Consume(context.Background(), &fakeResourceRequest{tenants: map[string]int{"a": 6, "c": 1}}, done)
+	assert.Equal(t, 3, pb.numPartitions())
+	// The requests that do not support splitting are partitioned by the other keys only.
+	pb.Consume(context.Background(), &requesttest.FakeRequest{Items: 2}, done)
+	assert.Equal(t, 4, pb.numPartitions())
+
+	require.NoError(t, pb.Shutdown(context.Background()))
+	assert.ElementsMatch(t, []int{10, 3, 1, 2}, exported)
+	// Done is called once per consumed request.
+	assert.EqualValues(t, 3, done.success.Load())
+}
+
+func TestPartitionKeys(t *testing.T) {
+	partitioner := newMetadataPartitioner([]string{"a", "b"})
+	ctx := func(md map[string][]string) context.Context {
+		return client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(md)})
+	}
+	keys := map[string]struct{}{}
+	for _, md := range []map[string][]string{
+		{},
+		{"a": {""}},
+		{"b": {""}},
+		{"a": {"x", "y"}},
+		{"a": {"x,y"}},
+		{"a": {"x"}, "b": {"y"}},
+		{"a": {"x"}, "b": {"y", "z"}},
+	} {
+		keys[partitioner.GetKey(ctx(md), nil)] = struct{}{}
+	}
+	assert.Len(t, keys, 7)
+
+	keyFunc := newResourceScopeKeyFunc([]string{"a"}, true)
+	res := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+	keys = map[string]struct{}{}
+	keys[keyFunc(res, scope)] = struct{}{}
+	res.Attributes().PutStr("a", "")
+	keys[keyFunc(res, scope)] = struct{}{}
+	scope.SetName("scope")
+	keys[keyFunc(res, scope)] = struct{}{}
+	scope.SetVersion("v1")
+	keys[keyFunc(res, scope)] = struct{}{}
+	res.Attributes().PutInt("a", 1)
+	keys[keyFunc(res, scope)] = struct{}{}
+	assert.Len(t, keys, 5)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 
@@ -48,6 +49,7 @@ type persistentQueueSettings[T any] struct {
 	sizer           request.Sizer[T]
 	capacity        int64
 	blockOnOverflow bool
+	waitForResult   bool
 	signal          pipeline.Signal
 	storageID       component.ID
 	encoding        Encoding[T]
@@ -95,6 +97,21 @@ type persistentQueue[T any] struct {
 	queueSize                int64
 	refClient                int64
 	stopped                  bool
+
+	// dispatchedSize is the part of queueSize used by the currently dispatched items.
+	dispatchedSize int64
+	// sizedFromIndex is the index of the first item counted in queueSize. The items dispatched before the queue
+	// was last drained are no longer part of it.
+	sizedFromIndex uint64
+	// waiters holds the callers of Offer waiting for the result of the items with the given index.
+	// Only used if waitForResult is enabled.
+	waiters map[uint64]*persistentWaiter
+}
+
+// persistentWaiter is a caller of Offer waiting for the result of an item.
+type persistentWaiter struct {
+	ctx context.Context
+	ch  chan error
 }
 
 // newPersistentQueue creates a new queue backed by file storage; name and signal must be a unique combination that identifies the queue storage
@@ -104,6 +121,7 @@ func newPersistentQueue[T any](set persistentQueueSettings[T]) readableQueue[T]
 		set:            set,
 		logger:         set.telemetry.Logger,
 		isRequestSized: isRequestSized,
+		waiters:        map[uint64]*persistentWaiter{},
 	}
 	pq.hasMoreElements = sync.NewCond(&pq.mu)
 	pq.hasMoreSpace = newCond(&pq.mu)
@@ -203,11 +221,26 @@ func (pq *persistentQueue[T]) Shutdown(ctx context.Context) error {
 	// Mark this queue as stopped, so consumer don't start any more work.
 	pq.stopped = true
 	pq.hasMoreElements.Broadcast()
+	pq.releaseWaiters()
 	return errors.Join(backupErr, pq.unrefClient(ctx))
 }
 
+// releaseWaiters unblocks the callers waiting for the items that are not dispatched. These items stay in the
+// storage and are exported after restart, so there is no error to report. Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) releaseWaiters() {
+	for index, w := range pq.waiters {
+		if slices.Contains(pq.currentlyDispatchedItems, index) {
+			// The result is reported when the processing is finished.
+			continue
+		}
+		w.ch <- nil
+		delete(pq.waiters, index)
+	}
+}
+
 // backupQueueSize writes the current queue size to storage. The value is used to recover the queue size
 // in case if the collector is killed.
+// The currently dispatched items are excluded, because they are moved back to the queue on restart and counted again.
 func (pq *persistentQueue[T]) backupQueueSize(ctx context.Context) error {
 	// No need to write the queue size if the queue is sized by the number of requests.
 	// That information is already stored as difference between read and write indexes.
@@ -216,7 +249,7 @@ func (pq *persistentQueue[T]) backupQueueSize(ctx context.Context) error {
 	}
 
 	//nolint:gosec
-	return pq.client.Set(ctx, queueSizeKey, itemIndexToBytes(uint64(pq.queueSize)))
+	return pq.client.Set(ctx, queueSizeKey, itemIndexToBytes(uint64(max(pq.queueSize-pq.dispatchedSize, 0))))
 }
 
 // unrefClient unrefs the client, and closes if no more references. Callers MUST hold the mutex.
@@ -232,10 +265,30 @@ func (pq *persistentQueue[T]) unrefClient(ctx context.Context) error {
 // Offer inserts the specified element into this queue if it is possible to do so immediately
 // without violating capacity restrictions. If success returns no error.
 // It returns ErrQueueIsFull if no space is currently available.
+// The element is always written to the storage before returning. If waitForResult is enabled,
+// it also blocks until the element is processed and returns the result of the processing.
 func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
 	pq.mu.Lock()
-	defer pq.mu.Unlock()
-	return pq.putInternal(ctx, req)
+	if err := pq.putInternal(ctx, req); err != nil || !pq.set.waitForResult {
+		pq.mu.Unlock()
+		return err
+	}
+
+	// The mutex is held since the item was added, so it is still the last one and not dispatched yet.
+	index := pq.writeIndex - 1
+	w := &persistentWaiter{ctx: ctx, ch: make(chan error, 1)}
+	pq.waiters[index] = w
+	pq.mu.Unlock()
+
+	select {
+	case err := <-w.ch:
+		return err
+	case <-ctx.Done():
+		pq.mu.Lock()
+		delete(pq.waiters, index)
+		pq.mu.Unlock()
+		return ctx.Err()
+	}
 }
 
 // putInternal is the internal version that requires caller to hold the mutex lock.
@@ -292,14 +345,28 @@ func (pq *persistentQueue[T]) Read(ctx context.Context) (context.Context, T, Don
 		// Read until either a successful retrieved element or no more elements in the storage.
 		for pq.readIndex != pq.writeIndex {
 			index, req, consumed := pq.getNextItem(ctx)
-			// Ensure the used size and the channel size are in sync.
+			// Ensure the used size and the channel size are in sync. The dispatched size is reset as well,
+			// otherwise the items dispatched so far are subtracted twice from the size of the queue.
 			if pq.readIndex == pq.writeIndex {
 				pq.queueSize = 0
+				pq.dispatchedSize = 0
+				pq.sizedFromIndex = pq.readIndex
 				pq.hasMoreSpace.Signal()
 			}
 			if consumed {
+				size := pq.set.sizer.Sizeof(req)
+				if index >= pq.sizedFromIndex {
+					pq.dispatchedSize += size
+				}
 				id := indexDonePool.Get().(*indexDone)
-				id.reset(index, pq.set.sizer.Sizeof(req), pq)
+				id.reset(index, size, pq)
+				// Propagate the context of the caller waiting for the result, if any.
+				// Otherwise, the original context is lost when the item is persisted.
+				// The cancellation is not propagated, a caller giving up waiting must not fail the export
+				// and lose the persisted item.
+				if w, ok := pq.waiters[index]; ok {
+					return context.WithoutCancel(w.ctx), req, id, true
+				}
 				return context.Background(), req, id, true
 			}
 		}
@@ -358,7 +425,11 @@ func (pq *persistentQueue[T]) onDone(index uint64, elSize int64, consumeErr erro
 		pq.mu.Unlock()
 	}()
 
-	pq.queueSize -= elSize
+	// The items dispatched before the queue was drained are already excluded from its size.
+	if index >= pq.sizedFromIndex {
+		pq.queueSize -= elSize
+		pq.dispatchedSize -= elSize
+	}
 	// The size might be not in sync with the queue in case it's restored from the disk
 	// because we don't flush the current queue size on the disk on every read/write.
 	// In that case we need to make sure it doesn't go below 0.
@@ -367,6 +438,16 @@ func (pq *persistentQueue[T]) onDone(index uint64, elSize int64, consumeErr erro
 	}
 	pq.hasMoreSpace.Signal()
 
+	if w, ok := pq.waiters[index]; ok {
+		delete(pq.waiters, index)
+		if experr.IsShutdownErr(consumeErr) {
+			// The item stays in the storage and is exported after restart.
+			w.ch <- nil
+		} else {
+			w.ch <- consumeErr
+		}
+	}
+
 	if experr.IsShutdownErr(consumeErr) {
 		// The queue is shutting down, don't mark the item as dispatched, so it's picked up again after restart.
 		// TODO: Handle partially delivered requests by updating their values in the storage.
@@ -1050,6 +1050,46 @@ func TestPersistentQueue_ItemsCapacityUsageRestoredOnShutdown(t *testing.T) {
 	require.NoError(t, newPQ.Shutdown(context.Background()))
 }
 
+// This test covers the case when the queue is shut down while some items are dispatched. The dispatched items are
+// moved back to the queue on restart, so their size must not be counted twice.
+func TestPersistentQueue_ItemsCapacityUsageRestoredWithDispatchedItems(t *testing.T) {
+	for _, doneBeforeShutdown := range []bool{false, true} {
+		t.Run(fmt.Sprintf("done_before_shutdown=%v", doneBeforeShutdown), func(t *testing.T) {
+			ext := storagetest.NewMockStorageExtension(nil)
+			pq := createTestPersistentQueueWithItemsCapacity(t, ext, 100)
+
+			require.NoError(t, pq.Offer(context.Background(), uint64(40)))
+			require.NoError(t, pq.Offer(context.Background(), uint64(20)))
+			require.NoError(t, pq.Offer(context.Background(), uint64(10)))
+			assert.Equal(t, int64(70), pq.Size())
+
+			_, val, done, ok := pq.Read(context.Background())
+			require.True(t, ok)
+			assert.Equal(t, uint64(40), val)
+
+			if doneBeforeShutdown {
+				done.OnDone(experr.NewShutdownErr(nil))
+				require.NoError(t, pq.Shutdown(context.Background()))
+			} else {
+				require.NoError(t, pq.Shutdown(context.Background()))
+				done.OnDone(experr.NewShutdownErr(nil))
+			}
+
+			newPQ := createTestPersistentQueueWithItemsCapacity(t, ext, 100)
+			assert.Equal(t, int64(70), newPQ.Size())
+
+			for _, expected := range []uint64{20, 10, 40} {
+				assert.True(t, consume(newPQ, func(_ context.Context, val uint64) error {
+					assert.Equal(t, expected, val)
+					return nil
+				}))
+			}
+			assert.Equal(t, int64(0), newPQ.Size())
+			require.NoError(t, newPQ.Shutdown(context.Background()))
+		})
+	}
+}
+
 // This test covers the case when the items capacity queue is enabled for the first time.
 func TestPersistentQueue_ItemsCapacityUsageIsNotPreserved(t *testing.T) {
 	ext := storagetest.NewMockStorageExtension(nil)
@@ -1200,6 +1240,170 @@ func TestPersistentQueue_RestoredUsedSizeIsCorrectedOnDrain(t *testing.T) {
 	require.NoError(t, pq.Shutdown(context.Background()))
 }
 
+// This test covers the case when the queue is drained while items are still dispatched.
+func TestPersistentQueue_BackupSizeAfterDrainWithDispatchedItems(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWithItemsCapacity(t, ext, 1000)
+
+	require.NoError(t, pq.Offer(context.Background(), uint64(100)))
+	_, _, done, ok := pq.Read(context.Background())
+	require.True(t, ok)
+	// The queue is drained, the dispatched items are not part of its size anymore.
+	assert.Equal(t, int64(0), pq.Size())
+
+	require.NoError(t, pq.Offer(context.Background(), uint64(50)))
+	assert.Equal(t, int64(50), pq.Size())
+	requireBackupQueueSize(t, pq, 50)
+
+	done.OnDone(nil)
+	assert.Equal(t, int64(50), pq.Size())
+	requireBackupQueueSize(t, pq, 50)
+
+	assert.True(t, consume(pq, func(context.Context, uint64) error { return nil }))
+	assert.Equal(t, int64(0), pq.Size())
+	requireBackupQueueSize(t, pq, 0)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func requireBackupQueueSize(t *testing.T, pq *persistentQueue[uint64], expected uint64) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	require.NoError(t, pq.backupQueueSize(context.Background()))
+	val, err := pq.client.Get(context.Background(), queueSizeKey)
+	require.NoError(t, err)
+	size, err := bytesToItemIndex(val)
+	require.NoError(t, err)
+	assert.Equal(t, expected, size)
+}
+
+func createTestPersistentQueueWaitForResult(t *testing.T, ext storage.Extension) *persistentQueue[uint64] {
+	pq := newPersistentQueue[uint64](persistentQueueSettings[uint64]{
+		sizer:         request.RequestsSizer[uint64]{},
+		capacity:      100,
+		waitForResult: true,
+		signal:        pipeline.SignalTraces,
+		storageID:     component.ID{},
+		encoding:      uint64Encoding{},
+		id:            component.NewID(exportertest.NopType),
+		telemetry:     componenttest.NewNopTelemetrySettings(),
+	}).(*persistentQueue[uint64])
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: ext})))
+	return pq
+}
+
+type testCtxKey struct{}
+
+func TestPersistentQueue_WaitForResult(t *testing.T) {
+	pq := createTestPersistentQueueWaitForResult(t, storagetest.NewMockStorageExtension(nil))
+	exportErr := errors.New("export failed")
+
+	ctx := context.WithValue(context.Background(), testCtxKey{}, "value")
+	offerErr := make(chan error, 1)
+	go func() {
+		offerErr <- pq.Offer(ctx, uint64(1))
+	}()
+
+	readCtx, val, done, ok := pq.Read(context.Background())
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), val)
+	// The context of the caller is propagated to the consumer.
+	assert.Equal(t, "value", readCtx.Value(testCtxKey{}))
+
+	select {
+	case <-offerErr:
+		t.Fatal("Offer must block until the item is processed")
+	case <-time.After(10 * time.Millisecond):
+	}
+	done.OnDone(exportErr)
+	require.ErrorIs(t, <-offerErr, exportErr)
+
+	go func() {
+		offerErr <- pq.Offer(context.Background(), uint64(2))
+	}()
+	assert.True(t, consume(pq, func(context.Context, uint64) error { return nil }))
+	require.NoError(t, <-offerErr)
+	assert.Equal(t, int64(0), pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_WaitForResultCanceled(t *testing.T) {
+	pq := createTestPersistentQueueWaitForResult(t, storagetest.NewMockStorageExtension(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	offerErr := make(chan error, 1)
+	go func() {
+		offerErr <- pq.Offer(ctx, uint64(1))
+	}()
+	assert.Eventually(t, func() bool { return pq.Size() == 1 }, 1*time.Second, 10*time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-offerErr, context.Canceled)
+
+	// The item is persisted and still processed, without the canceled context.
+	assert.True(t, consume(pq, func(ctx context.Context, val uint64) error {
+		assert.Equal(t, uint64(1), val)
+		return ctx.Err()
+	}))
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_WaitForResultCanceledWhileExporting(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWaitForResult(t, ext)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testCtxKey{}, "value"))
+	offerErr := make(chan error, 1)
+	go func() {
+		offerErr <- pq.Offer(ctx, uint64(1))
+	}()
+
+	readCtx, _, done, ok := pq.Read(context.Background())
+	require.True(t, ok)
+	cancel()
+	require.ErrorIs(t, <-offerErr, context.Canceled)
+
+	// The export is not canceled with the caller, and the item stays in the storage until it is processed.
+	require.NoError(t, readCtx.Err())
+	assert.Equal(t, "value", readCtx.Value(testCtxKey{}))
+	require.NoError(t, pq.Shutdown(context.Background()))
+	done.OnDone(experr.NewShutdownErr(readCtx.Err()))
+
+	newPQ := createTestPersistentQueueWaitForResult(t, ext)
+	assert.Equal(t, int64(1), newPQ.Size())
+	require.NoError(t, newPQ.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_WaitForResultShutdown(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	pq := createTestPersistentQueueWaitForResult(t, ext)
+
+	offerErrs := make(chan error, 2)
+	for i := 1; i <= 2; i++ {
+		go func() {
+			offerErrs <- pq.Offer(context.Background(), uint64(i))
+		}()
+		assert.Eventually(t, func() bool { return pq.Size() == int64(i) }, 1*time.Second, 10*time.Millisecond)
+	}
+
+	_, _, done, ok := pq.Read(context.Background())
+	require.True(t, ok)
+
+	// The callers waiting for the items that are not dispatched are released on shutdown,
+	// the items stay in the storage.
+	require.NoError(t, pq.Shutdown(context.Background()))
+	require.NoError(t, <-offerErrs)
+	select {
+	case <-offerErrs:
+		t.Fatal("Offer must block until the dispatched item is processed")
+	case <-time.After(10 * time.Millisecond):
+	}
+	done.OnDone(experr.NewShutdownErr(nil))
+	require.NoError(t, <-offerErrs)
+
+	newPQ := createTestPersistentQueueWaitForResult(t, ext)
+	assert.Equal(t, int64(2), newPQ.Size())
+	require.NoError(t, newPQ.Shutdown(context.Background()))
+}
+
 func requireCurrentlyDispatchedItemsEqual(t *testing.T, pq *persistentQueue[uint64], compare []uint64) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
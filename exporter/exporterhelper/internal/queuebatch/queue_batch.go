@@ -61,29 +61,33 @@ func newQueueBatch(
 		return nil, fmt.Errorf("queue_batch: unsupported sizer %q", cfg.Sizer)
 	}
 
+	if err := cfg.ShardBy.validateSignal(set.Signal); err != nil {
+		return nil, err
+	}
+
 	var b Batcher[request.Request]
-	if cfg.Batch != nil {
-		if oldBatcher {
-			// If user configures the old batcher we only can support "items" sizer.
-			b = newDefaultBatcher(*cfg.Batch, batcherSettings[request.Request]{
-				sizerType:  request.SizerTypeItems,
-				sizer:      request.NewItemsSizer(),
-				next:       next,
-				maxWorkers: cfg.NumConsumers,
-			})
-		} else {
-			b = newDefaultBatcher(*cfg.Batch, batcherSettings[request.Request]{
-				sizerType:  cfg.Sizer,
-				sizer:      sizer,
-				next:       next,
-				maxWorkers: cfg.NumConsumers,
-			})
+	if cfg.ShardBy.enabled() {
+		// Every shard is consumed sequentially by its own batcher.
+		batchers := make([]Batcher[request.Request], cfg.NumConsumers)
+		for i := range batchers {
+			var err error
+			if batchers[i], err = newBatcher(set, cfg, sizer, next, oldBatcher, true); err != nil {
+				return nil, err
+			}
 		}
-		// Keep the number of queue consumers to 1 if batching is enabled until we support sharding as described in
-		// https://github.com/open-telemetry/opentelemetry-collector/issues/12473
+		b = newShardBatcher(cfg.ShardBy, batchers)
+		// A single queue consumer dispatches the requests to the shards to preserve the order.
 		cfg.NumConsumers = 1
 	} else {
-		b = newDisabledBatcher[request.Request](next)
+		var err error
+		if b, err = newBatcher(set, cfg, sizer, next, oldBatcher, false); err != nil {
+			return nil, err
+		}
+		if cfg.Batch != nil {
+			// Keep the number of queue consumers to 1 if batching is enabled until we support sharding as described in
+			// https://github.com/open-telemetry/opentelemetry-collector/issues/12473
+			cfg.NumConsumers = 1
+		}
 	}
 
 	var q Queue[request.Request]
@@ -100,6 +104,7 @@ func newQueueBatch(
 			sizer:           sizer,
 			capacity:        cfg.QueueSize,
 			blockOnOverflow: cfg.BlockOnOverflow,
+			waitForResult:   cfg.WaitForResult,
 			signal:          set.Signal,
 			storageID:       *cfg.StorageID,
 			encoding:        set.Encoding,
@@ -116,6 +121,48 @@ func newQueueBatch(
 	return &QueueBatch{queue: oq, batcher: b}, nil
 }
 
+// newBatcher creates the Batcher configured by the given config. If sequential is set, the batches are flushed
+// synchronously in the order they are formed.
+func newBatcher(
+	set Settings[request.Request],
+	cfg Config,
+	sizer request.Sizer[request.Request],
+	next sender.SendFunc[request.Request],
+	oldBatcher bool,
+	sequential bool,
+) (Batcher[request.Request], error) {
+	if cfg.Batch == nil {
+		return newDisabledBatcher[request.Request](next), nil
+	}
+
+	maxWorkers := cfg.NumConsumers
+	if sequential {
+		maxWorkers = 0
+	}
+	if oldBatcher {
+		// If user configures the old batcher we only can support "items" sizer.
+		return newDefaultBatcher(*cfg.Batch, batcherSettings[request.Request]{
+			sizerType:  request.SizerTypeItems,
+			sizer:      request.NewItemsSizer(),
+			next:       next,
+			maxWorkers: maxWorkers,
+			sequential: sequential,
+		}), nil
+	}
+
+	bSet := batcherSettings[request.Request]{
+		sizerType:  cfg.Sizer,
+		sizer:      sizer,
+		next:       next,
+		maxWorkers: maxWorkers,
+		sequential: sequential,
+	}
+	if cfg.Batch.PartitionBy.enabled() {
+		return newPartitionBatcher(*cfg.Batch, bSet, set)
+	}
+	return newDefaultBatcher(*cfg.Batch, bSet), nil
+}
+
 // Start is invoked during service startup.
 func (qs *QueueBatch) Start(ctx context.Context, host component.Host) error {
 	if err := qs.batcher.Start(ctx, host); err != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"runtime"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -227,6 +228,154 @@ func TestQueueBatchPersistentEnabled_NoDataLossOnShutdown(t *testing.T) {
 	require.NoError(t, qb.Shutdown(context.Background()))
 }
 
+func TestQueueBatchPersistentEnabled_BytesSizeRestored(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Sizer = request.SizerTypeBytes
+	cfg.QueueSize = 1000
+	cfg.BlockOnOverflow = false
+	cfg.Batch = nil
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+
+	mockReq := &requesttest.FakeRequest{Items: 2, Bytes: 300}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+
+	cfg.NumConsumers = 1
+	dispatched := make(chan struct{})
+	unblock := make(chan struct{})
+	qb, err := NewQueueBatch(qSet, cfg, func(context.Context, request.Request) error {
+		dispatched <- struct{}{}
+		<-unblock
+		return experr.NewShutdownErr(errors.New("could not export data"))
+	})
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	assert.Equal(t, int64(900), qb.queue.Size())
+	// The queue is limited by the number of bytes.
+	require.ErrorIs(t, qb.Send(context.Background(), mockReq), ErrQueueIsFull)
+
+	// Shut down while a request is dispatched, all the requests must be preserved.
+	<-dispatched
+	go func() {
+		// Let the consumer finish after the queue is stopped.
+		for range dispatched {
+		}
+	}()
+	close(unblock)
+	require.NoError(t, qb.Shutdown(context.Background()))
+	close(dispatched)
+
+	unblock = make(chan struct{})
+	qb, err = NewQueueBatch(qSet, cfg, func(context.Context, request.Request) error {
+		<-unblock
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), host))
+	assert.Equal(t, int64(900), qb.queue.Size())
+	require.ErrorIs(t, qb.Send(context.Background(), mockReq), ErrQueueIsFull)
+
+	close(unblock)
+	assert.Eventually(t, func() bool {
+		return qb.queue.Size() == 0
+	}, 1*time.Second, 10*time.Millisecond)
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchPersistentEnabled_WaitForResult(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.WaitForResult = true
+	cfg.Batch = nil
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+
+	mockReq := &requesttest.FakeRequest{Items: 2}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(qSet, cfg, sink.Export)
+	require.NoError(t, err)
+
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	require.NoError(t, qb.Start(context.Background(), host))
+
+	// The result of the export is returned to the caller.
+	require.NoError(t, qb.Send(context.Background(), mockReq))
+	assert.Equal(t, 1, sink.RequestsCount())
+	exportErr := errors.New("export failed")
+	sink.SetExportErr(exportErr)
+	require.ErrorIs(t, qb.Send(context.Background(), mockReq), exportErr)
+	assert.Equal(t, 1, sink.RequestsCount())
+	assert.Equal(t, int64(0), qb.queue.Size())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatch_PartitionByMetadata(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch.MinSize = 10
+	cfg.Batch.FlushTimeout = time.Hour
+	cfg.Batch.PartitionBy = PartitionConfig{MetadataKeys: []string{"tenant"}}
+	sink := newPartitionSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, qb.Send(tenantContext("a"), &requesttest.FakeRequest{Items: 6}))
+	require.NoError(t, qb.Send(tenantContext("b"), &requesttest.FakeRequest{Items: 6}))
+	require.NoError(t, qb.Send(tenantContext("a"), &requesttest.FakeRequest{Items: 6}))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string][]int{"a": {12}}, sink.exported())
+	}, 1*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, map[string][]int{"a": {12}, "b": {6}}, sink.exported())
+}
+
+func TestQueueBatch_ShardByOrdering(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumConsumers = 4
+	cfg.Batch.MinSize = 0
+	cfg.ShardBy = ShardConfig{MetadataKeys: []string{"tenant"}}
+
+	var mu sync.Mutex
+	// The items count encodes the tenant and the sequence number of the request.
+	exported := map[int][]int{}
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, func(_ context.Context, req request.Request) error {
+		// Vary the export duration to detect reordering.
+		time.Sleep(time.Duration(req.ItemsCount()%5) * 100 * time.Microsecond)
+		mu.Lock()
+		defer mu.Unlock()
+		tenant := req.ItemsCount() / 1000
+		exported[tenant] = append(exported[tenant], req.ItemsCount()%1000)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	for seq := 0; seq < 100; seq++ {
+		for tenant := 1; tenant <= 8; tenant++ {
+			ctx := tenantContext(strconv.Itoa(tenant))
+			require.NoError(t, qb.Send(ctx, &requesttest.FakeRequest{Items: tenant*1000 + seq}))
+		}
+	}
+	require.NoError(t, qb.Shutdown(context.Background()))
+
+	require.Len(t, exported, 8)
+	for tenant, seqs := range exported {
+		require.Len(t, seqs, 100, "tenant %d", tenant)
+		assert.IsIncreasing(t, seqs, "tenant %d", tenant)
+	}
+}
+
 func TestQueueBatchNoStartShutdown(t *testing.T) {
 	qs, err := NewQueueBatch(newFakeRequestSettings(), newTestConfig(), sendertest.NewNopSenderFunc[request.Request]())
 	require.NoError(t, err)
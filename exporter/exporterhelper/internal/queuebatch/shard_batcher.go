@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type shardItem struct {
+	ctx  context.Context
+	req  request.Request
+	done Done
+}
+
+// shard is the FIFO of the requests assigned to a shard and not consumed yet. It is not bounded, the requests
+// are only released from the queue once they are done, so the queue capacity limits the size of all the shards.
+type shard struct {
+	mu      sync.Mutex
+	hasMore *sync.Cond
+	items   []shardItem
+	closed  bool
+}
+
+func newShard() *shard {
+	s := &shard{}
+	s.hasMore = sync.NewCond(&s.mu)
+	return s
+}
+
+func (s *shard) push(item shardItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, item)
+	s.hasMore.Signal()
+}
+
+// pop returns the oldest request of the shard, waiting for one if necessary. It returns false once the shard is
+// closed and all its requests are consumed.
+func (s *shard) pop() (shardItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.items) == 0 {
+		if s.closed {
+			return shardItem{}, false
+		}
+		s.hasMore.Wait()
+	}
+	item := s.items[0]
+	s.items[0] = shardItem{}
+	s.items = s.items[1:]
+	return item, true
+}
+
+func (s *shard) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.hasMore.Broadcast()
+}
+
+// shardBatcher is a Batcher that assigns every request to a shard based on the hash of the configured key.
+// Every shard has its own Batcher that is consumed sequentially by a dedicated goroutine, so the order of
+// the requests with the same key is preserved as long as Consume is not called concurrently.
+// Consume never waits for the shards, so a slow shard does not block the other ones.
+type shardBatcher struct {
+	partitioner Partitioner[request.Request]
+	resourceKey request.ResourceScopeKeyFunc
+	traceID     bool
+	shards      []*shard
+	batchers    []Batcher[request.Request]
+	stopWG      sync.WaitGroup
+}
+
+func newShardBatcher(cfg ShardConfig, batchers []Batcher[request.Request]) *shardBatcher {
+	sb := &shardBatcher{
+		partitioner: newMetadataPartitioner(cfg.MetadataKeys),
+		traceID:     cfg.TraceID,
+		shards:      make([]*shard, len(batchers)),
+		batchers:    batchers,
+	}
+	if len(cfg.ResourceAttributes) > 0 {
+		sb.resourceKey = newResourceScopeKeyFunc(cfg.ResourceAttributes, false)
+	}
+	for i := range sb.shards {
+		sb.shards[i] = newShard()
+	}
+	return sb
+}
+
+func (sb *shardBatcher) Start(ctx context.Context, host component.Host) error {
+	for i, b := range sb.batchers {
+		if err := b.Start(ctx, host); err != nil {
+			return errors.Join(err, sb.shutdownBatchers(ctx, sb.batchers[:i]))
+		}
+	}
+	for i := range sb.shards {
+		sb.stopWG.Add(1)
+		go func() {
+			defer sb.stopWG.Done()
+			for {
+				item, ok := sb.shards[i].pop()
+				if !ok {
+					return
+				}
+				sb.batchers[i].Consume(item.ctx, item.req, item.done)
+			}
+		}()
+	}
+	return nil
+}
+
+func (sb *shardBatcher) Consume(ctx context.Context, req request.Request, done Done) {
+	key := sb.partitioner.GetKey(ctx, req)
+
+	var parts map[string]request.Request
+	if splitter, ok := req.(request.ResourceScopeSplitter); ok && sb.resourceKey != nil {
+		parts = splitter.SplitByResourceScope(func(res pcommon.Resource, scope pcommon.InstrumentationScope) string {
+			return sb.shardKey(key + sb.resourceKey(res, scope))
+		})
+	} else if splitter, ok := req.(request.TraceIDSplitter); ok && sb.traceID {
+		parts = splitter.SplitByTraceID(func(traceID pcommon.TraceID) string {
+			return sb.shardKey(key + string(traceID[:]))
+		})
+	} else {
+		parts = map[string]request.Request{sb.shardKey(key): req}
+	}
+
+	// If the request is split, call done only when all the parts are done.
+	if len(parts) > 1 {
+		done = newRefCountDone(done, int64(len(parts)))
+	}
+	for shardKey, part := range parts {
+		idx, _ := strconv.Atoi(shardKey)
+		sb.shards[idx].push(shardItem{ctx: ctx, req: part, done: done})
+	}
+}
+
+// shardKey returns the index of the shard for the given key formatted as a string.
+func (sb *shardBatcher) shardKey(key string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return strconv.FormatUint(h.Sum64()%uint64(len(sb.shards)), 10)
+}
+
+// Shutdown waits for the shards to consume the pending requests and shuts down their batchers.
+// It must not be called concurrently with Consume.
+func (sb *shardBatcher) Shutdown(ctx context.Context) error {
+	for _, s := range sb.shards {
+		s.close()
+	}
+	sb.stopWG.Wait()
+	return sb.shutdownBatchers(ctx, sb.batchers)
+}
+
+func (sb *shardBatcher) shutdownBatchers(ctx context.Context, batchers []Batcher[request.Request]) error {
+	var errs error
+	for _, b := range batchers {
+		errs = errors.Join(errs, b.Shutdown(ctx))
+	}
+	return errs
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// orderedSink records the exported requests per shard.
+type orderedSink struct {
+	mu     sync.Mutex
+	shards map[int][]int
+}
+
+func (s *orderedSink) exportFunc(shard int) func(context.Context, request.Request) error {
+	return func(_ context.Context, req request.Request) error {
+		// Give a chance to the other shards to run concurrently.
+		time.Sleep(time.Millisecond)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.shards[shard] = append(s.shards[shard], req.ItemsCount())
+		return nil
+	}
+}
+
+func (s *orderedSink) exported() map[int][]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make(map[int][]int, len(s.shards))
+	for k, v := range s.shards {
+		res[k] = append([]int(nil), v...)
+	}
+	return res
+}
+
+// fakeTraceIDRequest is a request with a number of items per trace ID, identified by its first byte.
+type fakeTraceIDRequest struct {
+	requesttest.FakeRequest
+	traces map[byte]int
+}
+
+func (r *fakeTraceIDRequest) SplitByTraceID(keyFunc request.TraceIDKeyFunc) map[string]request.Request {
+	res := map[string]request.Request{}
+	for id, items := range r.traces {
+		key := keyFunc(pcommon.TraceID{id})
+		if prev, ok := res[key]; ok {
+			items += prev.ItemsCount()
+		}
+		res[key] = &requesttest.FakeRequest{Items: items}
+	}
+	return res
+}
+
+func newTestShardBatcher(t *testing.T, cfg ShardConfig, numShards int, sink *orderedSink) *shardBatcher {
+	batchers := make([]Batcher[request.Request], numShards)
+	for i := range batchers {
+		batchers[i] = newDisabledBatcher[request.Request](sink.exportFunc(i))
+	}
+	sb := newShardBatcher(cfg, batchers)
+	require.NoError(t, sb.Start(context.Background(), componenttest.NewNopHost()))
+	return sb
+}
+
+func TestShardBatcher_MetadataKeysOrdering(t *testing.T) {
+	sink := &orderedSink{shards: map[int][]int{}}
+	sb := newTestShardBatcher(t, ShardConfig{MetadataKeys: []string{"tenant"}}, 4, sink)
+
+	done := newFakeDone()
+	expected := map[int][]int{}
+	for i := 1; i <= 100; i++ {
+		tenant := strconv.Itoa(i % 7)
+		shard, err := strconv.Atoi(sb.shardKey(sb.partitioner.GetKey(tenantContext(tenant), nil)))
+		require.NoError(t, err)
+		expected[shard] = append(expected[shard], i)
+		sb.Consume(tenantContext(tenant), &requesttest.FakeRequest{Items: i}, done)
+	}
+	require.NoError(t, sb.Shutdown(context.Background()))
+
+	// The requests of every shard are exported in order.
+	assert.Equal(t, expected, sink.exported())
+	assert.EqualValues(t, 100, done.success.Load())
+}
+
+func TestShardBatcher_TraceID(t *testing.T) {
+	sink := &orderedSink{shards: map[int][]int{}}
+	sb := newTestShardBatcher(t, ShardConfig{TraceID: true}, 3, sink)
+
+	reqs := []map[byte]int{
+		{1: 1, 2: 10, 3: 100, 4: 1000},
+		{1: 2, 2: 20, 3: 200, 4: 2000},
+	}
+	expected := map[int][]int{}
+	done := newFakeDone()
+	for _, traces := range reqs {
+		// The data of the same trace is always assigned to the same shard.
+		items := map[int]int{}
+		for id, n := range traces {
+			traceID := pcommon.TraceID{id}
+			shard, err := strconv.Atoi(sb.shardKey(string(traceID[:])))
+			require.NoError(t, err)
+			items[shard] += n
+		}
+		for shard, n := range items {
+			expected[shard] = append(expected[shard], n)
+		}
+		sb.Consume(context.Background(), &fakeTraceIDRequest{traces: traces}, done)
+	}
+
+	// The requests that do not support splitting are assigned to a shard as a whole.
+	shard, err := strconv.Atoi(sb.shardKey(""))
+	require.NoError(t, err)
+	expected[shard] = append(expected[shard], 5)
+	sb.Consume(context.Background(), &requesttest.FakeRequest{Items: 5}, done)
+
+	require.NoError(t, sb.Shutdown(context.Background()))
+	assert.Equal(t, expected, sink.exported())
+	assert.EqualValues(t, 3, done.success.Load())
+}
+
+func TestShardBatcher_SlowShardDoesNotBlockOthers(t *testing.T) {
+	unblock := make(chan struct{})
+	exported := make(chan int, 10)
+	batchers := make([]Batcher[request.Request], 2)
+	for i := range batchers {
+		batchers[i] = newDisabledBatcher[request.Request](func(_ context.Context, req request.Request) error {
+			if i == 0 {
+				<-unblock
+			}
+			exported <- req.ItemsCount()
+			return nil
+		})
+	}
+	sb := newShardBatcher(ShardConfig{MetadataKeys: []string{"tenant"}}, batchers)
+	require.NoError(t, sb.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Find a tenant for each shard.
+	tenants := map[string]string{}
+	for i := 0; len(tenants) < 2; i++ {
+		tenant := strconv.Itoa(i)
+		tenants[sb.shardKey(sb.partitioner.GetKey(tenantContext(tenant), nil))] = tenant
+	}
+
+	done := newFakeDone()
+	// The first shard is blocked, the requests of the second shard are still exported.
+	for i := 1; i <= 3; i++ {
+		sb.Consume(tenantContext(tenants["0"]), &requesttest.FakeRequest{Items: i}, done)
+	}
+	sb.Consume(tenantContext(tenants["1"]), &requesttest.FakeRequest{Items: 10}, done)
+	assert.Equal(t, 10, <-exported)
+
+	close(unblock)
+	require.NoError(t, sb.Shutdown(context.Background()))
+	close(exported)
+	var items []int
+	for n := range exported {
+		items = append(items, n)
+	}
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.EqualValues(t, 4, done.success.Load())
+}
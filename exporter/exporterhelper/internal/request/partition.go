@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package request // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceScopeKeyFunc returns the partition key for the data of the given resource and instrumentation scope.
+type ResourceScopeKeyFunc func(pcommon.Resource, pcommon.InstrumentationScope) string
+
+// ResourceScopeSplitter is an optional interface that can be implemented by Request to allow partitioning the data
+// by resource and instrumentation scope.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type ResourceScopeSplitter interface {
+	Request
+	// SplitByResourceScope splits the request into one request per distinct key returned by the given function.
+	// The original request MAY be returned as is if all the data has the same key, otherwise it MUST NOT be used
+	// after this call.
+	SplitByResourceScope(ResourceScopeKeyFunc) map[string]Request
+}
+
+// TraceIDKeyFunc returns the partition key for the data with the given trace ID.
+type TraceIDKeyFunc func(pcommon.TraceID) string
+
+// TraceIDSplitter is an optional interface that can be implemented by Request to allow partitioning the data
+// by trace ID.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type TraceIDSplitter interface {
+	Request
+	// SplitByTraceID splits the request into one request per distinct key returned by the given function.
+	// The original request MAY be returned as is if all the data has the same key, otherwise it MUST NOT be used
+	// after this call.
+	SplitByTraceID(TraceIDKeyFunc) map[string]Request
+}
@@ -5,8 +5,8 @@ package internal // import "go.opentelemetry.io/collector/exporter/exporterhelpe
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
@@ -23,34 +23,17 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 )
 
-// TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
-type throttleRetry struct {
-	err   error
-	delay time.Duration
-}
-
-func (t throttleRetry) Error() string {
-	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
-}
-
-func (t throttleRetry) Unwrap() error {
-	return t.err
-}
-
-// NewThrottleRetry creates a new throttle retry error.
-func NewThrottleRetry(err error, delay time.Duration) error {
-	return throttleRetry{
-		err:   err,
-		delay: delay,
-	}
-}
-
 type retrySender struct {
 	component.StartFunc
 	cfg    configretry.BackOffConfig
 	stopCh chan struct{}
 	logger *zap.Logger
 	next   sender.Sender[request.Request]
+
+	// throttledUntil is the time until which no request is sent, because the backend is throttling the data.
+	// It is shared by all the requests, so that they back off together.
+	throttledUntilMu sync.Mutex
+	throttledUntil   time.Time
 }
 
 func newRetrySender(config configretry.BackOffConfig, set exporter.Settings, next sender.Sender[request.Request]) *retrySender {
@@ -84,6 +67,11 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 		maxElapsedTime = time.Now().Add(rs.cfg.MaxElapsedTime)
 	}
 	for {
+		// Wait until the backend stops throttling the data, unless shutting down.
+		if err := rs.waitThrottled(ctx); err != nil {
+			return err
+		}
+
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(attribute.Int64("retry_num", retryNum)))
@@ -98,6 +86,11 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 			return fmt.Errorf("not retryable error: %w", err)
 		}
 
+		// Do not retry while the circuit breaker is open, the backend is known to be failing.
+		if experr.IsCircuitOpenErr(err) {
+			return err
+		}
+
 		if errReq, ok := req.(request.ErrorHandler); ok {
 			req = errReq.OnError(err)
 		}
@@ -107,9 +100,9 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 			return fmt.Errorf("no more retries left: %w", err)
 		}
 
-		throttleErr := throttleRetry{}
-		if errors.As(err, &throttleErr) {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+		if throttleDelay, ok := consumererror.ThrottleDelay(err); ok {
+			backoffDelay = max(backoffDelay, throttleDelay)
+			rs.setThrottled(time.Now().Add(throttleDelay))
 		}
 
 		nextRetryTime := time.Now().Add(backoffDelay)
@@ -147,3 +140,34 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 		}
 	}
 }
+
+// setThrottled pauses all the requests until the given time.
+func (rs *retrySender) setThrottled(until time.Time) {
+	rs.throttledUntilMu.Lock()
+	defer rs.throttledUntilMu.Unlock()
+	if until.After(rs.throttledUntil) {
+		rs.throttledUntil = until
+	}
+}
+
+// waitThrottled waits until the time set by setThrottled, but gets interrupted when the request is cancelled or
+// timed out. The requests are not paused anymore when shutting down, so that every request is sent once.
+func (rs *retrySender) waitThrottled(ctx context.Context) error {
+	rs.throttledUntilMu.Lock()
+	delay := time.Until(rs.throttledUntil)
+	rs.throttledUntilMu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(
+		"Exporting is throttled. Will send the request after interval.",
+		trace.WithAttributes(attribute.String("interval", delay.String())))
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request is cancelled or timed out while exporting is throttled: %w", ctx.Err())
+	case <-rs.stopCh:
+	case <-time.After(delay):
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -82,7 +83,7 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	sink := requesttest.NewSink()
 	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(sink.Export))
 	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
-	retry := fmt.Errorf("wrappe error: %w", NewThrottleRetry(errors.New("throttle error"), 100*time.Millisecond))
+	retry := fmt.Errorf("wrappe error: %w", consumererror.NewThrottle(errors.New("throttle error"), 100*time.Millisecond))
 	start := time.Now()
 	sink.SetExportErr(retry)
 	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
@@ -93,6 +94,56 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	require.NoError(t, rs.Shutdown(context.Background()))
 }
 
+func TestRetrySenderThrottleAllRequests(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	var mu sync.Mutex
+	var sendTimes []time.Time
+	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(func(context.Context, request.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sendTimes = append(sendTimes, time.Now())
+		if len(sendTimes) == 1 {
+			return consumererror.NewThrottle(errors.New("throttle error"), 100*time.Millisecond)
+		}
+		return nil
+	}))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	}()
+	var throttledUntil time.Time
+	require.Eventually(t, func() bool {
+		rs.throttledUntilMu.Lock()
+		defer rs.throttledUntilMu.Unlock()
+		throttledUntil = rs.throttledUntil
+		return !throttledUntil.IsZero()
+	}, time.Second, time.Millisecond)
+
+	// The other requests are not sent until the end of the throttling either.
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.NoError(t, <-errCh)
+	mu.Lock()
+	require.Len(t, sendTimes, 3)
+	for _, sendTime := range sendTimes[1:] {
+		assert.False(t, sendTime.Before(throttledUntil))
+	}
+	mu.Unlock()
+
+	// The requests are cancelled while waiting, but sent once when shutting down.
+	rs.setThrottled(time.Now().Add(time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, rs.Send(ctx, &requesttest.FakeRequest{Items: 1}), context.DeadlineExceeded)
+	require.NoError(t, rs.Shutdown(context.Background()))
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	mu.Lock()
+	assert.Len(t, sendTimes, 4)
+	mu.Unlock()
+}
+
 func TestRetrySenderWithContextTimeout(t *testing.T) {
 	const testTimeout = 10 * time.Second
 	rCfg := configretry.NewDefaultBackOffConfig()
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
@@ -158,3 +159,61 @@ func extractScopeLogs(srcSL plog.ScopeLogs, capacity int, sz sizer.LogsSizer) (p
 	})
 	return destSL, removedSize
 }
+
+// SplitByResourceScope splits the logs request into one request per distinct key returned by keyFunc.
+func (req *logsRequest) SplitByResourceScope(keyFunc request.ResourceScopeKeyFunc) map[string]Request {
+	var firstKey string
+	first, single := true, true
+	for _, rl := range req.ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			key := keyFunc(rl.Resource(), sl.Scope())
+			if first {
+				firstKey, first = key, false
+			}
+			single = single && key == firstKey
+		}
+	}
+	if single {
+		return map[string]Request{firstKey: req}
+	}
+
+	res := plog.SplitByScope(req.ld, func(rl plog.ResourceLogs, sl plog.ScopeLogs) string {
+		return keyFunc(rl.Resource(), sl.Scope())
+	})
+	reqs := make(map[string]Request, len(res))
+	for key, ld := range res {
+		reqs[key] = newLogsRequest(ld)
+	}
+	return reqs
+}
+
+// SplitByTraceID splits the logs request into one request per distinct key returned by keyFunc.
+func (req *logsRequest) SplitByTraceID(keyFunc request.TraceIDKeyFunc) map[string]Request {
+	var firstKey string
+	first, single := true, true
+	for i := 0; i < req.ld.ResourceLogs().Len() && single; i++ {
+		sls := req.ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len() && single; j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len() && single; k++ {
+				key := keyFunc(lrs.At(k).TraceID())
+				if first {
+					firstKey, first = key, false
+				}
+				single = key == firstKey
+			}
+		}
+	}
+	if single {
+		return map[string]Request{firstKey: req}
+	}
+
+	res := plog.SplitByLogRecord(req.ld, func(_ plog.ResourceLogs, _ plog.ScopeLogs, lr plog.LogRecord) string {
+		return keyFunc(lr.TraceID())
+	})
+	reqs := make(map[string]Request, len(res))
+	for key, ld := range res {
+		reqs[key] = newLogsRequest(ld)
+	}
+	return reqs
+}
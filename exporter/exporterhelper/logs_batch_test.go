@@ -5,12 +5,14 @@ package exporterhelper
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -364,3 +366,61 @@ func BenchmarkSplittingBasedOnByteSizeHugeLogs(b *testing.B) {
 		assert.Len(b, merged, 10)
 	}
 }
+
+func TestSplitByResourceScopeLogs(t *testing.T) {
+	keyFunc := func(res pcommon.Resource, _ pcommon.InstrumentationScope) string {
+		v, _ := res.Attributes().Get("resource-attr")
+		return v.AsString()
+	}
+
+	// All the data has the same key, the original request is returned.
+	req := newLogsRequest(testdata.GenerateLogs(3))
+	assert.Equal(t, map[string]Request{"resource-attr-val-1": req}, req.(*logsRequest).SplitByResourceScope(keyFunc))
+
+	ld := testdata.GenerateLogs(5)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).CopyTo(ld.ResourceLogs().At(0).ScopeLogs().AppendEmpty().LogRecords().AppendEmpty())
+	rl := ld.ResourceLogs().AppendEmpty()
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).CopyTo(rl.ScopeLogs().AppendEmpty())
+	rl.Resource().Attributes().PutStr("resource-attr", "other")
+	ld.ResourceLogs().At(0).CopyTo(ld.ResourceLogs().AppendEmpty())
+
+	res := newLogsRequest(ld).(*logsRequest).SplitByResourceScope(keyFunc)
+	require.Len(t, res, 2)
+	assert.Equal(t, 12, res["resource-attr-val-1"].ItemsCount())
+	assert.Equal(t, 2, res["resource-attr-val-1"].(*logsRequest).ld.ResourceLogs().Len())
+	assert.Equal(t, 2, res["resource-attr-val-1"].(*logsRequest).ld.ResourceLogs().At(0).ScopeLogs().Len())
+	assert.Equal(t, 5, res["other"].ItemsCount())
+	assert.Equal(t, 1, res["other"].(*logsRequest).ld.ResourceLogs().Len())
+}
+
+func TestSplitByTraceIDLogs(t *testing.T) {
+	keyFunc := func(traceID pcommon.TraceID) string {
+		return strconv.Itoa(int(traceID[0] % 2))
+	}
+
+	// All the data has the same key, the original request is returned.
+	req := newLogsRequest(testdata.GenerateLogs(1))
+	assert.Equal(t, map[string]Request{"0": req}, req.(*logsRequest).SplitByTraceID(keyFunc))
+
+	ld := testdata.GenerateLogs(5)
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < lrs.Len(); i++ {
+		lrs.At(i).SetTraceID(pcommon.TraceID{byte(i)})
+	}
+	orig := plog.NewLogs()
+	ld.CopyTo(orig)
+	res := newLogsRequest(ld).(*logsRequest).SplitByTraceID(keyFunc)
+	require.Len(t, res, 2)
+	for key, expected := range map[string][]byte{"0": {0, 2, 4}, "1": {1, 3}} {
+		rs := res[key].(*logsRequest).ld.ResourceLogs()
+		require.Equal(t, 1, rs.Len())
+		assert.Equal(t, orig.ResourceLogs().At(0).Resource().Attributes().AsRaw(), rs.At(0).Resource().Attributes().AsRaw())
+		require.Equal(t, 1, rs.At(0).ScopeLogs().Len())
+		assert.Equal(t, orig.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name(), rs.At(0).ScopeLogs().At(0).Scope().Name())
+		got := rs.At(0).ScopeLogs().At(0).LogRecords()
+		require.Equal(t, len(expected), got.Len())
+		for i, id := range expected {
+			assert.Equal(t, pcommon.TraceID{id}, got.At(i).TraceID())
+		}
+	}
+}
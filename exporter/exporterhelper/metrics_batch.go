@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -323,3 +324,30 @@ func extractSummaryDataPoints(srcSummary pmetric.Summary, capacity int, sz sizer
 	})
 	return m, removedSize
 }
+
+// SplitByResourceScope splits the metrics request into one request per distinct key returned by keyFunc.
+func (req *metricsRequest) SplitByResourceScope(keyFunc request.ResourceScopeKeyFunc) map[string]Request {
+	var firstKey string
+	first, single := true, true
+	for _, rm := range req.md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			key := keyFunc(rm.Resource(), sm.Scope())
+			if first {
+				firstKey, first = key, false
+			}
+			single = single && key == firstKey
+		}
+	}
+	if single {
+		return map[string]Request{firstKey: req}
+	}
+
+	res := pmetric.SplitByScope(req.md, func(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics) string {
+		return keyFunc(rm.Resource(), sm.Scope())
+	})
+	reqs := make(map[string]Request, len(res))
+	for key, md := range res {
+		reqs[key] = newMetricsRequest(md)
+	}
+	return reqs
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -584,3 +585,29 @@ func (m *mockMetricsSizer) ScopeMetricsSize(_ pmetric.ScopeMetrics) int {
 func (m *mockMetricsSizer) DeltaSize(size int) int {
 	return size
 }
+
+func TestSplitByResourceScopeMetrics(t *testing.T) {
+	keyFunc := func(res pcommon.Resource, _ pcommon.InstrumentationScope) string {
+		v, _ := res.Attributes().Get("resource-attr")
+		return v.AsString()
+	}
+
+	// All the data has the same key, the original request is returned.
+	req := newMetricsRequest(testdata.GenerateMetrics(3))
+	assert.Equal(t, map[string]Request{"resource-attr-val-1": req}, req.(*metricsRequest).SplitByResourceScope(keyFunc))
+
+	md := testdata.GenerateMetrics(5)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).CopyTo(md.ResourceMetrics().At(0).ScopeMetrics().AppendEmpty().Metrics().AppendEmpty())
+	rm := md.ResourceMetrics().AppendEmpty()
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).CopyTo(rm.ScopeMetrics().AppendEmpty())
+	rm.Resource().Attributes().PutStr("resource-attr", "other")
+	md.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+
+	res := newMetricsRequest(md).(*metricsRequest).SplitByResourceScope(keyFunc)
+	require.Len(t, res, 2)
+	assert.Equal(t, 24, res["resource-attr-val-1"].ItemsCount())
+	assert.Equal(t, 2, res["resource-attr-val-1"].(*metricsRequest).md.ResourceMetrics().Len())
+	assert.Equal(t, 2, res["resource-attr-val-1"].(*metricsRequest).md.ResourceMetrics().At(0).ScopeMetrics().Len())
+	assert.Equal(t, 10, res["other"].ItemsCount())
+	assert.Equal(t, 1, res["other"].(*metricsRequest).md.ResourceMetrics().Len())
+}
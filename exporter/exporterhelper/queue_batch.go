@@ -34,6 +34,12 @@ type QueueBatchConfig = queuebatch.Config
 // BatchConfig defines a configuration for batching requests based on a timeout and a minimum number of items.
 type BatchConfig = queuebatch.BatchConfig
 
+// AdaptiveConcurrencyConfig defines a configuration for adapting the number of concurrent exports.
+type AdaptiveConcurrencyConfig = queuebatch.AdaptiveConcurrencyConfig
+
+// NewDefaultAdaptiveConcurrencyConfig returns the default config for AdaptiveConcurrencyConfig.
+var NewDefaultAdaptiveConcurrencyConfig = queuebatch.NewDefaultAdaptiveConcurrencyConfig
+
 // QueueBatchEncoding defines the encoding to be used if persistent queue is configured.
 // Duplicate definition with queuebatch.Encoding since aliasing generics is not supported by default.
 type QueueBatchEncoding[T any] interface {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -163,3 +164,61 @@ func extractScopeSpans(srcSS ptrace.ScopeSpans, capacity int, sz sizer.TracesSiz
 	})
 	return destSS, removedSize
 }
+
+// SplitByResourceScope splits the traces request into one request per distinct key returned by keyFunc.
+func (req *tracesRequest) SplitByResourceScope(keyFunc request.ResourceScopeKeyFunc) map[string]Request {
+	var firstKey string
+	first, single := true, true
+	for _, rs := range req.td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			key := keyFunc(rs.Resource(), ss.Scope())
+			if first {
+				firstKey, first = key, false
+			}
+			single = single && key == firstKey
+		}
+	}
+	if single {
+		return map[string]Request{firstKey: req}
+	}
+
+	res := ptrace.SplitByScope(req.td, func(rs ptrace.ResourceSpans, ss ptrace.ScopeSpans) string {
+		return keyFunc(rs.Resource(), ss.Scope())
+	})
+	reqs := make(map[string]Request, len(res))
+	for key, td := range res {
+		reqs[key] = newTracesRequest(td)
+	}
+	return reqs
+}
+
+// SplitByTraceID splits the traces request into one request per distinct key returned by keyFunc.
+func (req *tracesRequest) SplitByTraceID(keyFunc request.TraceIDKeyFunc) map[string]Request {
+	var firstKey string
+	first, single := true, true
+	for i := 0; i < req.td.ResourceSpans().Len() && single; i++ {
+		sss := req.td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < sss.Len() && single; j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len() && single; k++ {
+				key := keyFunc(spans.At(k).TraceID())
+				if first {
+					firstKey, first = key, false
+				}
+				single = key == firstKey
+			}
+		}
+	}
+	if single {
+		return map[string]Request{firstKey: req}
+	}
+
+	res := ptrace.SplitBySpan(req.td, func(_ ptrace.ResourceSpans, _ ptrace.ScopeSpans, span ptrace.Span) string {
+		return keyFunc(span.TraceID())
+	})
+	reqs := make(map[string]Request, len(res))
+	for key, td := range res {
+		reqs[key] = newTracesRequest(td)
+	}
+	return reqs
+}
@@ -5,12 +5,14 @@ package exporterhelper
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -335,3 +337,61 @@ func BenchmarkSplittingBasedOnItemCountHugeTraces(b *testing.B) {
 		assert.Len(b, merged, 10)
 	}
 }
+
+func TestSplitByResourceScopeTraces(t *testing.T) {
+	keyFunc := func(res pcommon.Resource, _ pcommon.InstrumentationScope) string {
+		v, _ := res.Attributes().Get("resource-attr")
+		return v.AsString()
+	}
+
+	// All the data has the same key, the original request is returned.
+	req := newTracesRequest(testdata.GenerateTraces(3))
+	assert.Equal(t, map[string]Request{"resource-attr-val-1": req}, req.(*tracesRequest).SplitByResourceScope(keyFunc))
+
+	td := testdata.GenerateTraces(5)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).CopyTo(td.ResourceSpans().At(0).ScopeSpans().AppendEmpty().Spans().AppendEmpty())
+	rs := td.ResourceSpans().AppendEmpty()
+	td.ResourceSpans().At(0).ScopeSpans().At(0).CopyTo(rs.ScopeSpans().AppendEmpty())
+	rs.Resource().Attributes().PutStr("resource-attr", "other")
+	td.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+
+	res := newTracesRequest(td).(*tracesRequest).SplitByResourceScope(keyFunc)
+	require.Len(t, res, 2)
+	assert.Equal(t, 12, res["resource-attr-val-1"].ItemsCount())
+	assert.Equal(t, 2, res["resource-attr-val-1"].(*tracesRequest).td.ResourceSpans().Len())
+	assert.Equal(t, 2, res["resource-attr-val-1"].(*tracesRequest).td.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, 5, res["other"].ItemsCount())
+	assert.Equal(t, 1, res["other"].(*tracesRequest).td.ResourceSpans().Len())
+}
+
+func TestSplitByTraceIDTraces(t *testing.T) {
+	keyFunc := func(traceID pcommon.TraceID) string {
+		return strconv.Itoa(int(traceID[0] % 2))
+	}
+
+	// All the data has the same key, the original request is returned.
+	req := newTracesRequest(testdata.GenerateTraces(1))
+	assert.Equal(t, map[string]Request{"1": req}, req.(*tracesRequest).SplitByTraceID(keyFunc))
+
+	td := testdata.GenerateTraces(5)
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		spans.At(i).SetTraceID(pcommon.TraceID{byte(i)})
+	}
+	orig := ptrace.NewTraces()
+	td.CopyTo(orig)
+	res := newTracesRequest(td).(*tracesRequest).SplitByTraceID(keyFunc)
+	require.Len(t, res, 2)
+	for key, expected := range map[string][]byte{"0": {0, 2, 4}, "1": {1, 3}} {
+		rs := res[key].(*tracesRequest).td.ResourceSpans()
+		require.Equal(t, 1, rs.Len())
+		assert.Equal(t, orig.ResourceSpans().At(0).Resource().Attributes().AsRaw(), rs.At(0).Resource().Attributes().AsRaw())
+		require.Equal(t, 1, rs.At(0).ScopeSpans().Len())
+		assert.Equal(t, orig.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name(), rs.At(0).ScopeSpans().At(0).Scope().Name())
+		got := rs.At(0).ScopeSpans().At(0).Spans()
+		require.Equal(t, len(expected), got.Len())
+		for i, id := range expected {
+			assert.Equal(t, pcommon.TraceID{id}, got.At(i).TraceID())
+		}
+	}
+}
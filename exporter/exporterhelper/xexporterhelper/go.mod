@@ -43,7 +43,10 @@ require (
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/collector/client v1.31.0 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.125.0 // indirect
 	go.opentelemetry.io/collector/confmap v1.31.0 // indirect
+	go.opentelemetry.io/collector/confmap/xconfmap v0.125.0 // indirect
 	go.opentelemetry.io/collector/extension v1.31.0 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.125.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.31.0 // indirect
@@ -88,6 +91,8 @@ replace go.opentelemetry.io/collector/component => ../../../component
 
 replace go.opentelemetry.io/collector/component/componenttest => ../../../component/componenttest
 
+replace go.opentelemetry.io/collector/component/componentstatus => ../../../component/componentstatus
+
 replace go.opentelemetry.io/collector/receiver/xreceiver => ../../../receiver/xreceiver
 
 replace go.opentelemetry.io/collector/receiver/receivertest => ../../../receiver/receivertest
@@ -119,3 +124,5 @@ replace go.opentelemetry.io/collector/confmap => ../../../confmap
 replace go.opentelemetry.io/collector/internal/telemetry => ../../../internal/telemetry
 
 replace go.opentelemetry.io/collector/client => ../../../client
+
+replace go.opentelemetry.io/collector/confmap/xconfmap => ../../../confmap/xconfmap
@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sizer"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 )
@@ -162,3 +163,51 @@ func extractScopeProfiles(srcSS pprofile.ScopeProfiles, capacity int, sz sizer.P
 	})
 	return destSS, removedSize
 }
+
+// SplitByResourceScope splits the profiles request into one request per distinct key returned by keyFunc.
+func (req *profilesRequest) SplitByResourceScope(keyFunc request.ResourceScopeKeyFunc) map[string]exporterhelper.Request {
+	keys := make([][]string, req.pd.ResourceProfiles().Len())
+	var firstKey string
+	single := true
+	for i := 0; i < req.pd.ResourceProfiles().Len(); i++ {
+		rp := req.pd.ResourceProfiles().At(i)
+		keys[i] = make([]string, rp.ScopeProfiles().Len())
+		for j := 0; j < rp.ScopeProfiles().Len(); j++ {
+			keys[i][j] = keyFunc(rp.Resource(), rp.ScopeProfiles().At(j).Scope())
+			if i == 0 && j == 0 {
+				firstKey = keys[i][j]
+			}
+			single = single && keys[i][j] == firstKey
+		}
+	}
+	if single {
+		return map[string]exporterhelper.Request{firstKey: req}
+	}
+
+	res := map[string]pprofile.Profiles{}
+	for i := 0; i < req.pd.ResourceProfiles().Len(); i++ {
+		srcRP := req.pd.ResourceProfiles().At(i)
+		destRPs := map[string]pprofile.ResourceProfiles{}
+		for j := 0; j < srcRP.ScopeProfiles().Len(); j++ {
+			key := keys[i][j]
+			destRP, ok := destRPs[key]
+			if !ok {
+				pd, ok := res[key]
+				if !ok {
+					pd = pprofile.NewProfiles()
+					res[key] = pd
+				}
+				destRP = pd.ResourceProfiles().AppendEmpty()
+				destRP.SetSchemaUrl(srcRP.SchemaUrl())
+				srcRP.Resource().CopyTo(destRP.Resource())
+				destRPs[key] = destRP
+			}
+			srcRP.ScopeProfiles().At(j).MoveTo(destRP.ScopeProfiles().AppendEmpty())
+		}
+	}
+	reqs := make(map[string]exporterhelper.Request, len(res))
+	for key, pd := range res {
+		reqs[key] = newProfilesRequest(pd)
+	}
+	return reqs
+}
@@ -25,6 +25,9 @@ type Config struct {
 	RetryConfig   configretry.BackOffConfig       `mapstructure:"retry_on_failure"`
 	ClientConfig  configgrpc.ClientConfig         `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
+	// DeadLetterConfig keeps the data that failed to be exported instead of dropping it.
+	DeadLetterConfig exporterhelper.DeadLetterConfig `mapstructure:"dead_letter"`
+
 	// Experimental: This configuration is at the early stage of development and may change without backward compatibility
 	// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved
 	//
@@ -50,6 +50,11 @@ func TestUnmarshalConfig(t *testing.T) {
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
 			},
+			DeadLetterConfig: exporterhelper.DeadLetterConfig{
+				Enabled:       true,
+				Directory:     "/var/lib/otelcol/dead_letter",
+				ReplayOnStart: true,
+			},
 			QueueConfig: exporterhelper.QueueBatchConfig{
 				Enabled:      true,
 				Sizer:        exporterhelper.RequestSizerTypeItems,
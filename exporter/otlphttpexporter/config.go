@@ -49,6 +49,9 @@ type Config struct {
 	QueueConfig  exporterhelper.QueueBatchConfig `mapstructure:"sending_queue"`
 	RetryConfig  configretry.BackOffConfig       `mapstructure:"retry_on_failure"`
 
+	// DeadLetterConfig keeps the data that failed to be exported instead of dropping it.
+	DeadLetterConfig exporterhelper.DeadLetterConfig `mapstructure:"dead_letter"`
+
 	// The URL to send traces to. If omitted the Endpoint + "/v1/traces" will be used.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
 
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Config has the configuration for the basic authenticator extension.
+// Either Htpasswd or ClientAuth must be set.
+type Config struct {
+	// Htpasswd configures the credentials accepted by the server authenticator.
+	Htpasswd *HtpasswdSettings `mapstructure:"htpasswd"`
+
+	// ClientAuth configures the credentials sent by the client authenticator.
+	ClientAuth *ClientAuthSettings `mapstructure:"client_auth"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// HtpasswdSettings has the users accepted by the server authenticator, in the htpasswd format.
+// The entries of File and Inline are combined, the entries of Inline take precedence.
+type HtpasswdSettings struct {
+	// File is the path of an htpasswd file, read again when it changes.
+	File string `mapstructure:"file"`
+
+	// Inline is the content of an htpasswd file.
+	Inline string `mapstructure:"inline"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ClientAuthSettings has the credentials sent by the client authenticator.
+type ClientAuthSettings struct {
+	// Username is the username sent by the client.
+	Username string `mapstructure:"username"`
+
+	// Password is the password sent by the client.
+	Password configopaque.String `mapstructure:"password"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.Htpasswd == nil && cfg.ClientAuth == nil:
+		return errors.New("either htpasswd or client_auth must be set")
+	case cfg.Htpasswd != nil && cfg.ClientAuth != nil:
+		return errors.New("htpasswd and client_auth cannot be set together")
+	case cfg.Htpasswd != nil && cfg.Htpasswd.File == "" && cfg.Htpasswd.Inline == "":
+		return errors.New("htpasswd must have a file or inline entries")
+	case cfg.ClientAuth != nil && cfg.ClientAuth.Username == "":
+		return errors.New("client_auth::username must not be empty")
+	case cfg.ClientAuth != nil && strings.Contains(cfg.ClientAuth.Username, ":"):
+		// The username and the password are separated with a colon, see RFC 7617.
+		return errors.New("client_auth::username must not contain a colon")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Htpasswd: &HtpasswdSettings{
+				File:   "/etc/otelcol/htpasswd",
+				Inline: "frontend:$2y$05$7XumfXP3Hp9S3k1rJdSnXuV4Cv3kRFEd6IhSVaYNhbyEsgMWy4uwi\n",
+			},
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{
+			name: "htpasswd",
+			cfg:  &Config{Htpasswd: &HtpasswdSettings{File: "htpasswd"}},
+		},
+		{
+			name: "client_auth",
+			cfg:  &Config{ClientAuth: &ClientAuthSettings{Username: "user", Password: "password"}},
+		},
+		{
+			name:    "empty",
+			cfg:     &Config{},
+			wantErr: "either htpasswd or client_auth must be set",
+		},
+		{
+			name: "htpasswd and client_auth",
+			cfg: &Config{
+				Htpasswd:   &HtpasswdSettings{File: "htpasswd"},
+				ClientAuth: &ClientAuthSettings{Username: "user"},
+			},
+			wantErr: "htpasswd and client_auth cannot be set together",
+		},
+		{
+			name:    "empty htpasswd",
+			cfg:     &Config{Htpasswd: &HtpasswdSettings{}},
+			wantErr: "htpasswd must have a file or inline entries",
+		},
+		{
+			name:    "no username",
+			cfg:     &Config{ClientAuth: &ClientAuthSettings{Password: "password"}},
+			wantErr: "client_auth::username must not be empty",
+		},
+		{
+			name:    "username with colon",
+			cfg:     &Config{ClientAuth: &ClientAuthSettings{Username: "user:name"}},
+			wantErr: "client_auth::username must not contain a colon",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
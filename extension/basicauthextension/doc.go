@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package basicauthextension implements an authenticator extension that authenticates
+// the requests with the HTTP Basic authentication scheme, checking the credentials of
+// the clients against an htpasswd file on the server side.
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/internal/filewatcher"
+)
+
+var (
+	errMissingCredentials = errors.New("missing basic authentication credentials")
+	errInvalidCredentials = errors.New("invalid basic authentication credentials")
+	errNotServer          = errors.New("the basic authenticator is not configured with htpasswd")
+	errNotClient          = errors.New("the basic authenticator is not configured with client_auth")
+)
+
+var (
+	_ extension.Extension      = (*basicAuth)(nil)
+	_ extensionauth.Server     = (*basicAuth)(nil)
+	_ extensionauth.HTTPClient = (*basicAuth)(nil)
+	_ extensionauth.GRPCClient = (*basicAuth)(nil)
+)
+
+// basicAuth authenticates the requests with the HTTP Basic authentication scheme, see RFC 7617.
+type basicAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// htpasswd is nil until the server authenticator is started.
+	htpasswd atomic.Pointer[htpasswd]
+	watcher  *filewatcher.Watcher
+}
+
+func newBasicAuth(cfg *Config, logger *zap.Logger) *basicAuth {
+	return &basicAuth{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (b *basicAuth) Start(context.Context, component.Host) error {
+	if b.cfg.Htpasswd == nil {
+		return nil
+	}
+
+	var fileContent []byte
+	if b.cfg.Htpasswd.File != "" {
+		var err error
+		if fileContent, err = os.ReadFile(b.cfg.Htpasswd.File); err != nil {
+			return fmt.Errorf("failed to read the htpasswd file: %w", err)
+		}
+	}
+	h, err := parseHtpasswd(string(fileContent), b.cfg.Htpasswd.Inline)
+	if err != nil {
+		return fmt.Errorf("failed to parse the htpasswd entries: %w", err)
+	}
+	b.htpasswd.Store(h)
+
+	if b.cfg.Htpasswd.File == "" {
+		return nil
+	}
+	b.watcher, err = filewatcher.New(b.cfg.Htpasswd.File, fileContent, b.reload, b.logger)
+	if err != nil {
+		return fmt.Errorf("failed to watch the htpasswd file: %w", err)
+	}
+	return nil
+}
+
+func (b *basicAuth) Shutdown(context.Context) error {
+	if b.watcher == nil {
+		return nil
+	}
+	return b.watcher.Close()
+}
+
+// reload replaces the users with the new content of the htpasswd file, the users are kept if it is invalid.
+func (b *basicAuth) reload(fileContent []byte) {
+	h, err := parseHtpasswd(string(fileContent), b.cfg.Htpasswd.Inline)
+	if err != nil {
+		b.logger.Warn("Failed to parse the htpasswd file, the previous users are kept", zap.String("filename", b.cfg.Htpasswd.File), zap.Error(err))
+		return
+	}
+	b.htpasswd.Store(h)
+	b.logger.Info("Htpasswd file reloaded", zap.String("filename", b.cfg.Htpasswd.File))
+}
+
+// Authenticate checks the credentials of the Authorization header, and adds the username to the client.Info.
+func (b *basicAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	h := b.htpasswd.Load()
+	if h == nil {
+		return ctx, errNotServer
+	}
+
+	values := getHeader(headers, "Authorization")
+	if len(values) == 0 {
+		return ctx, errMissingCredentials
+	}
+	username, password, ok := parseBasicAuth(values[0])
+	if !ok {
+		return ctx, errMissingCredentials
+	}
+	if !h.match(username, password) {
+		return ctx, errInvalidCredentials
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{username: username}
+	return client.NewContext(ctx, cl), nil
+}
+
+// getHeader returns the values of the header, the names of the headers are case-insensitive.
+func getHeader(headers map[string][]string, name string) []string {
+	if values, ok := headers[name]; ok {
+		return values
+	}
+	for key, values := range headers {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// parseBasicAuth parses the credentials of an Authorization header, like http.Request.BasicAuth.
+func parseBasicAuth(value string) (username, password string, ok bool) {
+	scheme, encoded, found := strings.Cut(value, " ")
+	// The scheme is case-insensitive, see RFC 9110.
+	if !found || !strings.EqualFold(scheme, "Basic") {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// RoundTripper returns a RoundTripper adding the credentials to the HTTP requests.
+func (b *basicAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	if b.cfg.ClientAuth == nil {
+		return nil, errNotClient
+	}
+	return &roundTripper{base: base, cfg: b.cfg.ClientAuth}, nil
+}
+
+// PerRPCCredentials returns the credentials adding the credentials to the gRPC requests.
+func (b *basicAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	if b.cfg.ClientAuth == nil {
+		return nil, errNotClient
+	}
+	return &perRPCCredentials{cfg: b.cfg.ClientAuth}, nil
+}
+
+type roundTripper struct {
+	base http.RoundTripper
+	cfg  *ClientAuthSettings
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request.
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.cfg.Username, string(rt.cfg.Password))
+	return rt.base.RoundTrip(req)
+}
+
+type perRPCCredentials struct {
+	cfg *ClientAuthSettings
+}
+
+func (c *perRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(c.cfg.Username + ":" + string(c.cfg.Password)))
+	return map[string]string{"authorization": "Basic " + encoded}, nil
+}
+
+// RequireTransportSecurity returns true, the credentials must not be sent over an insecure connection.
+func (c *perRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+const (
+	subjectAttribute  = "subject"
+	usernameAttribute = "username"
+)
+
+// authData is the client.AuthData of the authenticated clients, the subject is the username.
+type authData struct {
+	username string
+}
+
+func (a *authData) GetAttribute(name string) any {
+	switch name {
+	case subjectAttribute, usernameAttribute:
+		return a.username
+	default:
+		return nil
+	}
+}
+
+func (a *authData) GetAttributeNames() []string {
+	return []string{subjectAttribute, usernameAttribute}
+}
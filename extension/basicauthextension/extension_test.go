@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/internal/filewatcher"
+)
+
+func startAuth(t *testing.T, cfg *Config) *basicAuth {
+	ext, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(component.MustNewType("basicauth")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, ext.Shutdown(context.Background())) })
+	return ext.(*basicAuth)
+}
+
+func basicAuthHeader(username, password string) map[string][]string {
+	return map[string][]string{
+		"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	auth := startAuth(t, &Config{Htpasswd: &HtpasswdSettings{Inline: htpasswdEntry(t, "alice", "secret")}})
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			headers: basicAuthHeader("alice", "secret"),
+		},
+		{
+			name: "lowercase header and scheme",
+			headers: map[string][]string{
+				"authorization": {"basic " + base64.StdEncoding.EncodeToString([]byte("alice:secret"))},
+			},
+		},
+		{
+			name:    "missing header",
+			headers: map[string][]string{},
+			wantErr: errMissingCredentials,
+		},
+		{
+			name:    "other scheme",
+			headers: map[string][]string{"Authorization": {"Bearer token"}},
+			wantErr: errMissingCredentials,
+		},
+		{
+			name:    "invalid base64",
+			headers: map[string][]string{"Authorization": {"Basic !!!"}},
+			wantErr: errMissingCredentials,
+		},
+		{
+			name:    "no password",
+			headers: map[string][]string{"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("alice"))}},
+			wantErr: errMissingCredentials,
+		},
+		{
+			name:    "invalid password",
+			headers: basicAuthHeader("alice", "other"),
+			wantErr: errInvalidCredentials,
+		},
+		{
+			name:    "unknown user",
+			headers: basicAuthHeader("bob", "secret"),
+			wantErr: errInvalidCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := auth.Authenticate(context.Background(), tt.headers)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			authData := client.FromContext(ctx).Auth
+			require.NotNil(t, authData)
+			assert.Equal(t, "alice", authData.GetAttribute("subject"))
+			assert.Equal(t, "alice", authData.GetAttribute("username"))
+			assert.Nil(t, authData.GetAttribute("password"))
+			assert.Equal(t, []string{"subject", "username"}, authData.GetAttributeNames())
+		})
+	}
+}
+
+func TestAuthenticateWithoutHtpasswd(t *testing.T) {
+	auth := startAuth(t, &Config{ClientAuth: &ClientAuthSettings{Username: "alice"}})
+	_, err := auth.Authenticate(context.Background(), basicAuthHeader("alice", ""))
+	require.ErrorIs(t, err, errNotServer)
+}
+
+func TestHtpasswdFile(t *testing.T) {
+	oldDelay := filewatcher.DebounceDelay
+	filewatcher.DebounceDelay = 10 * time.Millisecond
+	defer func() { filewatcher.DebounceDelay = oldDelay }()
+
+	filename := filepath.Join(t.TempDir(), "htpasswd")
+	require.NoError(t, os.WriteFile(filename, []byte(htpasswdEntry(t, "alice", "first")), 0o600))
+	auth := startAuth(t, &Config{Htpasswd: &HtpasswdSettings{
+		File:   filename,
+		Inline: htpasswdEntry(t, "bob", "inline"),
+	}})
+
+	_, err := auth.Authenticate(context.Background(), basicAuthHeader("alice", "first"))
+	require.NoError(t, err)
+	_, err = auth.Authenticate(context.Background(), basicAuthHeader("bob", "inline"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filename, []byte(htpasswdEntry(t, "alice", "second")), 0o600))
+	require.Eventually(t, func() bool {
+		_, err = auth.Authenticate(context.Background(), basicAuthHeader("alice", "second"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = auth.Authenticate(context.Background(), basicAuthHeader("alice", "first"))
+	require.ErrorIs(t, err, errInvalidCredentials)
+	_, err = auth.Authenticate(context.Background(), basicAuthHeader("bob", "inline"))
+	require.NoError(t, err)
+
+	// The users are kept while the file is invalid.
+	require.NoError(t, os.WriteFile(filename, []byte("alice"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	_, err = auth.Authenticate(context.Background(), basicAuthHeader("alice", "second"))
+	require.NoError(t, err)
+}
+
+func TestHtpasswdFileErrors(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "htpasswd")
+	require.NoError(t, os.WriteFile(invalid, []byte("alice:plain"), 0o600))
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{
+			name:    "missing",
+			file:    filepath.Join(t.TempDir(), "missing"),
+			wantErr: "failed to read the htpasswd file",
+		},
+		{
+			name:    "invalid",
+			file:    invalid,
+			wantErr: "failed to parse the htpasswd entries",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := newBasicAuth(&Config{Htpasswd: &HtpasswdSettings{File: tt.file}}, zap.NewNop())
+			require.ErrorContains(t, auth.Start(context.Background(), componenttest.NewNopHost()), tt.wantErr)
+			require.NoError(t, auth.Shutdown(context.Background()))
+		})
+	}
+}
+
+type recordingRoundTripper struct {
+	req *http.Request
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestRoundTripper(t *testing.T) {
+	var httpClient extensionauth.HTTPClient = startAuth(t, &Config{ClientAuth: &ClientAuthSettings{Username: "alice", Password: "secret"}})
+
+	base := &recordingRoundTripper{}
+	rt, err := httpClient.RoundTripper(base)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:4318/v1/traces", http.NoBody)
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	username, password, ok := base.req.BasicAuth()
+	require.True(t, ok)
+	assert.Equal(t, "alice", username)
+	assert.Equal(t, "secret", password)
+	assert.Empty(t, req.Header.Get("Authorization"), "the original request must not be modified")
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	var grpcClient extensionauth.GRPCClient = startAuth(t, &Config{ClientAuth: &ClientAuthSettings{Username: "alice", Password: "secret"}})
+
+	creds, err := grpcClient.PerRPCCredentials()
+	require.NoError(t, err)
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Basic YWxpY2U6c2VjcmV0"}, md)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	// The credentials sent by the client are accepted by a server with the same user.
+	server := startAuth(t, &Config{Htpasswd: &HtpasswdSettings{Inline: htpasswdEntry(t, "alice", "secret")}})
+	_, err = server.Authenticate(context.Background(), map[string][]string{"authorization": {md["authorization"]}})
+	require.NoError(t, err)
+}
+
+func TestClientWithoutClientAuth(t *testing.T) {
+	auth := startAuth(t, &Config{Htpasswd: &HtpasswdSettings{Inline: htpasswdEntry(t, "alice", "secret")}})
+	_, err := auth.RoundTripper(http.DefaultTransport)
+	require.ErrorIs(t, err, errNotClient)
+	_, err = auth.PerRPCCredentials()
+	require.ErrorIs(t, err, errNotClient)
+}
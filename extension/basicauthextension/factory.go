@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/basicauthextension/internal/metadata"
+)
+
+// NewFactory creates a factory for the basic authenticator extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newBasicAuth(cfg.(*Config), set.Logger), nil
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// htpasswd holds the users of an htpasswd file, with their bcrypt password hash.
+type htpasswd struct {
+	users map[string][]byte
+
+	// verified holds the SHA-256 digest of the last password verified for each user, since
+	// verifying a bcrypt hash for every request is too expensive.
+	mu       sync.Mutex
+	verified map[string][sha256.Size]byte
+}
+
+// parseHtpasswd parses the given htpasswd contents, the entries of the last ones take precedence.
+// Only the bcrypt hashes, created with "htpasswd -B", are supported.
+func parseHtpasswd(contents ...string) (*htpasswd, error) {
+	h := &htpasswd{
+		users:    map[string][]byte{},
+		verified: map[string][sha256.Size]byte{},
+	}
+	for _, content := range contents {
+		scanner := bufio.NewScanner(strings.NewReader(content))
+		for lineNum := 1; scanner.Scan(); lineNum++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			user, hash, found := strings.Cut(line, ":")
+			if !found || user == "" {
+				return nil, fmt.Errorf("invalid htpasswd entry on line %d", lineNum)
+			}
+			if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+				return nil, fmt.Errorf("unsupported password hash for user %q, only bcrypt hashes are supported: %w", user, err)
+			}
+			h.users[user] = []byte(hash)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// match returns whether the password of the user matches.
+func (h *htpasswd) match(user, password string) bool {
+	hash, ok := h.users[user]
+	if !ok {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(password))
+	h.mu.Lock()
+	verified, ok := h.verified[user]
+	h.mu.Unlock()
+	if ok && subtle.ConstantTimeCompare(digest[:], verified[:]) == 1 {
+		return true
+	}
+
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil {
+		return false
+	}
+	h.mu.Lock()
+	h.verified[user] = digest
+	h.mu.Unlock()
+	return true
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// htpasswdEntry returns an htpasswd entry for the user, with the minimum bcrypt cost to keep the tests fast.
+func htpasswdEntry(t *testing.T, user, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return user + ":" + string(hash) + "\n"
+}
+
+func TestParseHtpasswd(t *testing.T) {
+	file := "# users\n" + htpasswdEntry(t, "alice", "first") + "\n" + htpasswdEntry(t, "bob", "second")
+	inline := htpasswdEntry(t, "bob", "third")
+	h, err := parseHtpasswd(file, inline)
+	require.NoError(t, err)
+
+	assert.True(t, h.match("alice", "first"))
+	assert.False(t, h.match("alice", "second"))
+	// The inline entries take precedence.
+	assert.True(t, h.match("bob", "third"))
+	assert.False(t, h.match("bob", "second"))
+	assert.False(t, h.match("carol", "first"))
+}
+
+func TestParseHtpasswdErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no separator",
+			content: "alice",
+			wantErr: "invalid htpasswd entry on line 1",
+		},
+		{
+			name:    "no user",
+			content: "\n:$2y$05$7XumfXP3Hp9S3k1rJdSnXuV4Cv3kRFEd6IhSVaYNhbyEsgMWy4uwi",
+			wantErr: "invalid htpasswd entry on line 2",
+		},
+		{
+			name:    "SHA hash",
+			content: "alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+			wantErr: `unsupported password hash for user "alice", only bcrypt hashes are supported`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseHtpasswd(tt.content)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHtpasswdVerifiedCache(t *testing.T) {
+	h, err := parseHtpasswd(htpasswdEntry(t, "alice", "first"))
+	require.NoError(t, err)
+
+	assert.True(t, h.match("alice", "first"))
+	assert.Len(t, h.verified, 1)
+	// The cached digest only matches the verified password.
+	assert.True(t, h.match("alice", "first"))
+	assert.False(t, h.match("alice", "other"))
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Config has the configuration for the bearer token authenticator extension.
+type Config struct {
+	// Header is the name of the header carrying the token.
+	Header string `mapstructure:"header"`
+
+	// Scheme is the authentication scheme preceding the token in the header, none if empty.
+	Scheme string `mapstructure:"scheme"`
+
+	// Token is the token sent by the clients and expected by the servers.
+	Token configopaque.String `mapstructure:"token"`
+
+	// Filename is the path of a file containing the token, instead of Token.
+	// The token is read again when the file changes.
+	Filename string `mapstructure:"filename"`
+
+	// Subject is the principal of the clients authenticated by a server, available as the
+	// "subject" attribute of the client.Info.Auth. The ID of the extension if empty.
+	Subject string `mapstructure:"subject"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Header == "" {
+		return errors.New("header must not be empty")
+	}
+	if cfg.Token == "" && cfg.Filename == "" {
+		return errors.New("either token or filename must be set")
+	}
+	if cfg.Token != "" && cfg.Filename != "" {
+		return errors.New("token and filename cannot be set together")
+	}
+	return nil
+}
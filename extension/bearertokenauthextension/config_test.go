@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Header:   "X-Api-Key",
+			Filename: "/etc/otelcol/token",
+			Subject:  "tenant-a",
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "token",
+			modify: func(cfg *Config) { cfg.Token = "my-token" },
+		},
+		{
+			name:   "filename",
+			modify: func(cfg *Config) { cfg.Filename = "token" },
+		},
+		{
+			name:    "no token",
+			modify:  func(*Config) {},
+			wantErr: "either token or filename must be set",
+		},
+		{
+			name: "token and filename",
+			modify: func(cfg *Config) {
+				cfg.Token = "my-token"
+				cfg.Filename = "token"
+			},
+			wantErr: "token and filename cannot be set together",
+		},
+		{
+			name: "no header",
+			modify: func(cfg *Config) {
+				cfg.Token = "my-token"
+				cfg.Header = ""
+			},
+			wantErr: "header must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
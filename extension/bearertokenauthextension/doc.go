@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package bearertokenauthextension implements an authenticator extension that
+// authenticates the requests with a static bearer token, for both clients and servers.
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/internal/filewatcher"
+)
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid bearer token")
+	errNoToken      = errors.New("the bearer token is not loaded yet")
+)
+
+var (
+	_ extension.Extension      = (*bearerTokenAuth)(nil)
+	_ extensionauth.Server     = (*bearerTokenAuth)(nil)
+	_ extensionauth.HTTPClient = (*bearerTokenAuth)(nil)
+	_ extensionauth.GRPCClient = (*bearerTokenAuth)(nil)
+)
+
+// bearerTokenAuth authenticates the requests with a static token, sent in a header.
+type bearerTokenAuth struct {
+	cfg     *Config
+	subject string
+	logger  *zap.Logger
+
+	// token is empty until the extension is started.
+	token   atomic.Pointer[string]
+	watcher *filewatcher.Watcher
+}
+
+func newBearerTokenAuth(cfg *Config, id component.ID, logger *zap.Logger) *bearerTokenAuth {
+	subject := cfg.Subject
+	if subject == "" {
+		subject = id.String()
+	}
+	return &bearerTokenAuth{
+		cfg:     cfg,
+		subject: subject,
+		logger:  logger,
+	}
+}
+
+func (b *bearerTokenAuth) Start(context.Context, component.Host) error {
+	if b.cfg.Filename == "" {
+		b.setToken([]byte(b.cfg.Token))
+		return nil
+	}
+
+	content, err := os.ReadFile(b.cfg.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to read the token file: %w", err)
+	}
+	b.setToken(content)
+	b.watcher, err = filewatcher.New(b.cfg.Filename, content, func(content []byte) {
+		b.setToken(content)
+		b.logger.Info("Bearer token reloaded", zap.String("filename", b.cfg.Filename))
+	}, b.logger)
+	if err != nil {
+		return fmt.Errorf("failed to watch the token file: %w", err)
+	}
+	return nil
+}
+
+func (b *bearerTokenAuth) Shutdown(context.Context) error {
+	if b.watcher == nil {
+		return nil
+	}
+	return b.watcher.Close()
+}
+
+// setToken sets the token, surrounding whitespaces, like a trailing newline in a file, are removed.
+func (b *bearerTokenAuth) setToken(content []byte) {
+	token := strings.TrimSpace(string(content))
+	b.token.Store(&token)
+}
+
+// currentToken returns the current token, or an empty string if not loaded yet.
+func (b *bearerTokenAuth) currentToken() string {
+	if token := b.token.Load(); token != nil {
+		return *token
+	}
+	return ""
+}
+
+// headerValue returns the value of the header for the current token.
+func (b *bearerTokenAuth) headerValue() (string, error) {
+	token := b.currentToken()
+	if token == "" {
+		return "", errNoToken
+	}
+	if b.cfg.Scheme == "" {
+		return token, nil
+	}
+	return b.cfg.Scheme + " " + token, nil
+}
+
+// Authenticate checks that the header carries the expected token, and adds the subject to the client.Info.
+func (b *bearerTokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	values := getHeader(headers, b.cfg.Header)
+	if len(values) == 0 {
+		return ctx, errMissingToken
+	}
+	token := values[0]
+	if b.cfg.Scheme != "" {
+		// The scheme is case-insensitive, see RFC 9110.
+		scheme, rest, found := strings.Cut(token, " ")
+		if !found || !strings.EqualFold(scheme, b.cfg.Scheme) {
+			return ctx, errMissingToken
+		}
+		token = strings.TrimSpace(rest)
+	}
+
+	expected := b.currentToken()
+	if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		return ctx, errInvalidToken
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{subject: b.subject}
+	return client.NewContext(ctx, cl), nil
+}
+
+// getHeader returns the values of the header, the names of the headers are case-insensitive.
+func getHeader(headers map[string][]string, name string) []string {
+	if values, ok := headers[name]; ok {
+		return values
+	}
+	for key, values := range headers {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// RoundTripper returns a RoundTripper adding the token to the HTTP requests.
+func (b *bearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &roundTripper{base: base, auth: b}, nil
+}
+
+// PerRPCCredentials returns the credentials adding the token to the gRPC requests.
+func (b *bearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCCredentials{auth: b}, nil
+}
+
+type roundTripper struct {
+	base http.RoundTripper
+	auth *bearerTokenAuth
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	value, err := rt.auth.headerValue()
+	if err != nil {
+		return nil, err
+	}
+	// A RoundTripper must not modify the request.
+	req = req.Clone(req.Context())
+	req.Header.Set(rt.auth.cfg.Header, value)
+	return rt.base.RoundTrip(req)
+}
+
+type perRPCCredentials struct {
+	auth *bearerTokenAuth
+}
+
+func (c *perRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	value, err := c.auth.headerValue()
+	if err != nil {
+		return nil, err
+	}
+	// The gRPC metadata keys are lowercase.
+	return map[string]string{strings.ToLower(c.auth.cfg.Header): value}, nil
+}
+
+// RequireTransportSecurity returns true, the token must not be sent over an insecure connection.
+func (c *perRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// authData is the client.AuthData of the clients authenticated with the token.
+type authData struct {
+	subject string
+}
+
+const subjectAttribute = "subject"
+
+func (a *authData) GetAttribute(name string) any {
+	if name == subjectAttribute {
+		return a.subject
+	}
+	return nil
+}
+
+func (a *authData) GetAttributeNames() []string {
+	return []string{subjectAttribute}
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/internal/filewatcher"
+)
+
+func startAuth(t *testing.T, cfg *Config) *bearerTokenAuth {
+	set := extensiontest.NewNopSettings(component.MustNewType("bearertokenauth"))
+	set.ID = component.MustNewID("bearertokenauth")
+	ext, err := NewFactory().Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, ext.Shutdown(context.Background())) })
+	return ext.(*bearerTokenAuth)
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Token = "my-token"
+	auth := startAuth(t, cfg)
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			headers: map[string][]string{"Authorization": {"Bearer my-token"}},
+		},
+		{
+			name:    "lowercase header and scheme",
+			headers: map[string][]string{"authorization": {"bearer my-token"}},
+		},
+		{
+			name:    "missing header",
+			headers: map[string][]string{},
+			wantErr: errMissingToken,
+		},
+		{
+			name:    "missing scheme",
+			headers: map[string][]string{"Authorization": {"my-token"}},
+			wantErr: errMissingToken,
+		},
+		{
+			name:    "other scheme",
+			headers: map[string][]string{"Authorization": {"Basic my-token"}},
+			wantErr: errMissingToken,
+		},
+		{
+			name:    "invalid token",
+			headers: map[string][]string{"Authorization": {"Bearer other-token"}},
+			wantErr: errInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := auth.Authenticate(context.Background(), tt.headers)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			authData := client.FromContext(ctx).Auth
+			require.NotNil(t, authData)
+			assert.Equal(t, "bearertokenauth", authData.GetAttribute("subject"))
+			assert.Equal(t, []string{"subject"}, authData.GetAttributeNames())
+		})
+	}
+}
+
+func TestAuthenticateCustomHeader(t *testing.T) {
+	cfg := &Config{Header: "X-Api-Key", Token: "my-token", Subject: "tenant-a"}
+	auth := startAuth(t, cfg)
+
+	ctx, err := auth.Authenticate(context.Background(), map[string][]string{"x-api-key": {"my-token"}})
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", client.FromContext(ctx).Auth.GetAttribute("subject"))
+
+	_, err = auth.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer my-token"}})
+	require.ErrorIs(t, err, errMissingToken)
+}
+
+func TestAuthenticateNotStarted(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Token = "my-token"
+	auth := newBearerTokenAuth(cfg, component.MustNewID("bearertokenauth"), zap.NewNop())
+
+	_, err := auth.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer "}})
+	require.ErrorIs(t, err, errInvalidToken)
+}
+
+type recordingRoundTripper struct {
+	req *http.Request
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestRoundTripper(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Token = "my-token"
+	var httpClient extensionauth.HTTPClient = startAuth(t, cfg)
+
+	base := &recordingRoundTripper{}
+	rt, err := httpClient.RoundTripper(base)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:4318/v1/traces", http.NoBody)
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "Bearer my-token", base.req.Header.Get("Authorization"))
+	assert.Empty(t, req.Header.Get("Authorization"), "the original request must not be modified")
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	cfg := &Config{Header: "X-Api-Key", Token: "my-token"}
+	var grpcClient extensionauth.GRPCClient = startAuth(t, cfg)
+
+	creds, err := grpcClient.PerRPCCredentials()
+	require.NoError(t, err)
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"x-api-key": "my-token"}, md)
+	assert.True(t, creds.RequireTransportSecurity())
+}
+
+func TestClientNotStarted(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Token = "my-token"
+	auth := newBearerTokenAuth(cfg, component.MustNewID("bearertokenauth"), zap.NewNop())
+
+	creds, err := auth.PerRPCCredentials()
+	require.NoError(t, err)
+	_, err = creds.GetRequestMetadata(context.Background())
+	require.ErrorIs(t, err, errNoToken)
+}
+
+func TestTokenFile(t *testing.T) {
+	oldDelay := filewatcher.DebounceDelay
+	filewatcher.DebounceDelay = 10 * time.Millisecond
+	defer func() { filewatcher.DebounceDelay = oldDelay }()
+
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("first-token\n"), 0o600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filename = filename
+	auth := startAuth(t, cfg)
+
+	_, err := auth.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer first-token"}})
+	require.NoError(t, err)
+
+	// The file is replaced with a rename, like most tools do.
+	tmp := filepath.Join(filepath.Dir(filename), "token.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("second-token\n"), 0o600))
+	require.NoError(t, os.Rename(tmp, filename))
+
+	require.Eventually(t, func() bool {
+		_, err = auth.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer second-token"}})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = auth.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer first-token"}})
+	require.ErrorIs(t, err, errInvalidToken)
+
+	// The previous token is kept while the file is missing.
+	require.NoError(t, os.Remove(filename))
+	time.Sleep(50 * time.Millisecond)
+	_, err = auth.Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer second-token"}})
+	require.NoError(t, err)
+}
+
+func TestTokenFileMissing(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filename = filepath.Join(t.TempDir(), "missing")
+	ext, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(component.MustNewType("bearertokenauth")), cfg)
+	require.NoError(t, err)
+	require.ErrorContains(t, ext.Start(context.Background(), componenttest.NewNopHost()), "failed to read the token file")
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
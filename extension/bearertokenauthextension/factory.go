@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/bearertokenauthextension/internal/metadata"
+)
+
+const (
+	defaultHeader = "Authorization"
+	defaultScheme = "Bearer"
+)
+
+// NewFactory creates a factory for the bearer token authenticator extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Header: defaultHeader,
+		Scheme: defaultScheme,
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newBearerTokenAuth(cfg.(*Config), set.ID, set.Logger), nil
+}
@@ -0,0 +1,601 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// Every client file starts with a fixed header followed by a sequence of frames.
+// Each frame is written with a single write call and holds the set and delete
+// entries of one batch:
+//
+//	frame: | payload length (uint32) | payload CRC-32C (uint32) | payload |
+//	entry: | op (byte) | key length (uvarint) | key | [value length (uvarint) | value] |
+//
+// A frame with an invalid checksum or a truncated payload can only be the result of
+// an interrupted write, so it is discarded together with everything after it when
+// the file is opened. This makes every batch atomic.
+const (
+	fileHeader      = "OTELFS\x00\x01"
+	frameHeaderSize = 8
+
+	// maxCompactedFrameSize limits the size of the frames written during compaction.
+	maxCompactedFrameSize = 1 << 20
+
+	opSet    byte = 1
+	opDelete byte = 2
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errClientClosed  = errors.New("storage client is closed")
+	errInvalidHeader = errors.New("file is not a valid file storage file")
+)
+
+// valueRef locates the value of a key in the client file.
+type valueRef struct {
+	offset int64
+	size   int
+	// entrySize is the number of bytes used by the whole entry in the file.
+	entrySize int64
+}
+
+// pendingValue holds the value written for a key by the batch that is being processed.
+type pendingValue struct {
+	value   []byte
+	deleted bool
+}
+
+type fileClient struct {
+	logger     *zap.Logger
+	path       string
+	fsync      FSyncConfig
+	compaction CompactionConfig
+	onClose    func()
+
+	closeOnce sync.Once
+	closeErr  error
+	stopSync  chan struct{}
+	syncDone  chan struct{}
+
+	// mu guards everything declared below.
+	mu    sync.Mutex
+	file  *os.File
+	index map[string]valueRef
+	// size is the offset at which the next frame is written.
+	size int64
+	// garbage is the number of bytes used by overwritten or deleted entries.
+	garbage int64
+	dirty   bool
+	closed  bool
+}
+
+var _ storage.Client = (*fileClient)(nil)
+
+func newFileClient(logger *zap.Logger, path string, cfg *Config, onClose func()) (*fileClient, error) {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &fileClient{
+		logger:     logger,
+		path:       path,
+		fsync:      cfg.FSync,
+		compaction: cfg.Compaction,
+		onClose:    onClose,
+		file:       file,
+		index:      map[string]valueRef{},
+	}
+
+	if err = c.load(); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	if c.compaction.OnStart && c.size > int64(len(fileHeader)) {
+		if err = c.compact(); err != nil {
+			c.logger.Error("Failed to compact the storage file", zap.String("path", c.path), zap.Error(err))
+		}
+	}
+
+	if c.fsync.Policy == FSyncPolicyInterval {
+		c.stopSync = make(chan struct{})
+		c.syncDone = make(chan struct{})
+		go c.syncLoop()
+	}
+
+	return c, nil
+}
+
+// load builds the index from the content of the file, discarding an incomplete frame at the end.
+func (c *fileClient) load() error {
+	info, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() == 0 {
+		if _, err = c.file.WriteAt([]byte(fileHeader), 0); err != nil {
+			return err
+		}
+		c.size = int64(len(fileHeader))
+		return c.file.Sync()
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(c.file, 0, info.Size()))
+	header := make([]byte, len(fileHeader))
+	if _, err = io.ReadFull(reader, header); err != nil || string(header) != fileHeader {
+		return fmt.Errorf("%w: %s", errInvalidHeader, c.path)
+	}
+
+	c.size = int64(len(fileHeader))
+	frameHeader := make([]byte, frameHeaderSize)
+	var payload []byte
+	for c.size < info.Size() {
+		if _, err = io.ReadFull(reader, frameHeader); err != nil {
+			break
+		}
+		payloadSize := int64(binary.LittleEndian.Uint32(frameHeader))
+		if c.size+frameHeaderSize+payloadSize > info.Size() {
+			break
+		}
+		if int64(cap(payload)) < payloadSize {
+			payload = make([]byte, payloadSize)
+		}
+		payload = payload[:payloadSize]
+		if _, err = io.ReadFull(reader, payload); err != nil {
+			break
+		}
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(frameHeader[4:]) {
+			break
+		}
+		if err = c.applyFrame(c.size+frameHeaderSize, payload); err != nil {
+			break
+		}
+		c.size += frameHeaderSize + payloadSize
+	}
+
+	if c.size < info.Size() {
+		c.logger.Warn("Discarding incomplete data at the end of the storage file",
+			zap.String("path", c.path), zap.Int64("bytes", info.Size()-c.size))
+		if err = c.file.Truncate(c.size); err != nil {
+			return err
+		}
+		return c.file.Sync()
+	}
+	return nil
+}
+
+// applyFrame updates the index with the entries of a frame whose payload starts at the given offset.
+func (c *fileClient) applyFrame(offset int64, payload []byte) error {
+	refs := map[string]*valueRef{}
+	var order []string
+	var deleted int64
+	pos := 0
+	for pos < len(payload) {
+		start := pos
+		op := payload[pos]
+		pos++
+		key, n, err := readBytes(payload[pos:])
+		if err != nil {
+			return err
+		}
+		pos += n
+		switch op {
+		case opSet:
+			value, vn, err := readBytes(payload[pos:])
+			if err != nil {
+				return err
+			}
+			pos += vn
+			if prev, ok := refs[string(key)]; !ok {
+				order = append(order, string(key))
+			} else if prev != nil {
+				deleted += prev.entrySize
+			}
+			refs[string(key)] = &valueRef{
+				offset:    offset + int64(pos-len(value)),
+				size:      len(value),
+				entrySize: int64(pos - start),
+			}
+		case opDelete:
+			if prev, ok := refs[string(key)]; !ok {
+				order = append(order, string(key))
+			} else if prev != nil {
+				deleted += prev.entrySize
+			}
+			refs[string(key)] = nil
+			deleted += int64(pos - start)
+		default:
+			return fmt.Errorf("invalid operation %d", op)
+		}
+	}
+
+	// The whole frame is validated before updating the index.
+	for _, key := range order {
+		c.setRef(key, refs[key])
+	}
+	c.garbage += deleted
+	return nil
+}
+
+// setRef replaces the location of the value of the key, nil removes the key.
+func (c *fileClient) setRef(key string, ref *valueRef) {
+	if old, ok := c.index[key]; ok {
+		c.garbage += old.entrySize
+	}
+	if ref == nil {
+		delete(c.index, key)
+		return
+	}
+	c.index[key] = *ref
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *fileClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *fileClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete will delete data associated with the specified key
+func (c *fileClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch executes the specified operations in order. All the set and delete operations
+// are written together, either all of them are persisted or none of them.
+func (c *fileClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errClientClosed
+	}
+
+	var payload []byte
+	var pending map[string]pendingValue
+	type write struct {
+		key       string
+		start     int
+		end       int
+		valueSize int
+		op        byte
+	}
+	var writes []write
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			if p, ok := pending[op.Key]; ok {
+				op.Value = cloneValue(p.value, p.deleted)
+				continue
+			}
+			value, err := c.read(op.Key)
+			if err != nil {
+				return err
+			}
+			op.Value = value
+		case storage.Set:
+			start := len(payload)
+			payload = append(payload, opSet)
+			payload = appendBytes(payload, []byte(op.Key))
+			payload = appendBytes(payload, op.Value)
+			writes = append(writes, write{key: op.Key, start: start, end: len(payload), valueSize: len(op.Value), op: opSet})
+			if pending == nil {
+				pending = map[string]pendingValue{}
+			}
+			pending[op.Key] = pendingValue{value: op.Value}
+		case storage.Delete:
+			start := len(payload)
+			payload = append(payload, opDelete)
+			payload = appendBytes(payload, []byte(op.Key))
+			writes = append(writes, write{key: op.Key, start: start, end: len(payload), op: opDelete})
+			if pending == nil {
+				pending = map[string]pendingValue{}
+			}
+			pending[op.Key] = pendingValue{deleted: true}
+		default:
+			return fmt.Errorf("wrong operation type: %d", op.Type)
+		}
+	}
+
+	if len(writes) == 0 {
+		return nil
+	}
+
+	if err := c.writeFrame(payload); err != nil {
+		return err
+	}
+
+	payloadOffset := c.size + frameHeaderSize
+	for _, w := range writes {
+		if w.op == opDelete {
+			c.garbage += int64(w.end - w.start)
+			c.setRef(w.key, nil)
+			continue
+		}
+		// The value is always the last part of a set entry.
+		c.setRef(w.key, &valueRef{
+			offset:    payloadOffset + int64(w.end-w.valueSize),
+			size:      w.valueSize,
+			entrySize: int64(w.end - w.start),
+		})
+	}
+	c.size = payloadOffset + int64(len(payload))
+
+	if c.shouldCompact() {
+		if err := c.compact(); err != nil {
+			c.logger.Error("Failed to compact the storage file", zap.String("path", c.path), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// writeFrame appends a frame with the given payload at the end of the file.
+func (c *fileClient) writeFrame(payload []byte) error {
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(payload))
+	//nolint:gosec
+	binary.LittleEndian.PutUint32(frame, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:], crc32.Checksum(payload, crcTable))
+	frame = append(frame, payload...)
+
+	if _, err := c.file.WriteAt(frame, c.size); err != nil {
+		// Remove any partially written data, so the next frame is written at the right offset.
+		if truncErr := c.file.Truncate(c.size); truncErr != nil {
+			c.logger.Error("Failed to discard partially written data", zap.String("path", c.path), zap.Error(truncErr))
+		}
+		if errors.Is(err, syscall.ENOSPC) {
+			return errors.Join(storage.ErrStorageFull, err)
+		}
+		return err
+	}
+
+	if c.fsync.Policy == FSyncPolicyAlways {
+		return c.file.Sync()
+	}
+	c.dirty = true
+	return nil
+}
+
+// read returns a copy of the value of the key, or nil if the key is not found. Callers MUST hold the mutex.
+func (c *fileClient) read(key string) ([]byte, error) {
+	ref, ok := c.index[key]
+	if !ok {
+		return nil, nil
+	}
+	value := make([]byte, ref.size)
+	if _, err := c.file.ReadAt(value, ref.offset); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (c *fileClient) shouldCompact() bool {
+	if !c.compaction.Auto || c.size < c.compaction.MinSize {
+		return false
+	}
+	return float64(c.garbage)/float64(c.size) >= c.compaction.MaxGarbageRatio
+}
+
+// compact rewrites the file with only the live entries. Callers MUST hold the mutex.
+func (c *fileClient) compact() error {
+	start := time.Now()
+	tmpPath := c.path + ".compact"
+	tmp, err := os.OpenFile(filepath.Clean(tmpPath), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	index, size, err := c.writeCompacted(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err = errors.Join(err, tmp.Close()); err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+
+	// The file must be closed before renaming on some platforms.
+	if err = c.file.Close(); err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+	renameErr := os.Rename(tmpPath, c.path)
+	if renameErr != nil {
+		renameErr = errors.Join(renameErr, os.Remove(tmpPath))
+	} else {
+		syncDir(filepath.Dir(c.path))
+	}
+
+	file, err := os.OpenFile(filepath.Clean(c.path), os.O_RDWR, 0o600)
+	if err != nil {
+		// The client cannot be used anymore.
+		c.closed = true
+		return errors.Join(renameErr, err)
+	}
+	c.file = file
+	if renameErr != nil {
+		return renameErr
+	}
+
+	oldSize := c.size
+	c.index = index
+	c.size = size
+	c.garbage = 0
+	c.dirty = false
+	c.logger.Debug("Compacted the storage file", zap.String("path", c.path),
+		zap.Int64("old_size", oldSize), zap.Int64("new_size", size), zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// writeCompacted writes all the live entries into the given file and returns the new index and file size.
+func (c *fileClient) writeCompacted(dst *os.File) (map[string]valueRef, int64, error) {
+	w := bufio.NewWriter(dst)
+	if _, err := w.WriteString(fileHeader); err != nil {
+		return nil, 0, err
+	}
+
+	index := make(map[string]valueRef, len(c.index))
+	size := int64(len(fileHeader))
+	var payload []byte
+	type entry struct {
+		key   string
+		start int
+		end   int
+	}
+	var entries []entry
+	flush := func() error {
+		if len(payload) == 0 {
+			return nil
+		}
+		header := make([]byte, frameHeaderSize)
+		//nolint:gosec
+		binary.LittleEndian.PutUint32(header, uint32(len(payload)))
+		binary.LittleEndian.PutUint32(header[4:], crc32.Checksum(payload, crcTable))
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+		if _, err := w.Write(payload); err != nil {
+			return err
+		}
+		payloadOffset := size + frameHeaderSize
+		for _, e := range entries {
+			ref := c.index[e.key]
+			index[e.key] = valueRef{
+				offset:    payloadOffset + int64(e.end-ref.size),
+				size:      ref.size,
+				entrySize: int64(e.end - e.start),
+			}
+		}
+		size = payloadOffset + int64(len(payload))
+		payload = payload[:0]
+		entries = entries[:0]
+		return nil
+	}
+
+	for key := range c.index {
+		value, err := c.read(key)
+		if err != nil {
+			return nil, 0, err
+		}
+		start := len(payload)
+		payload = append(payload, opSet)
+		payload = appendBytes(payload, []byte(key))
+		payload = appendBytes(payload, value)
+		entries = append(entries, entry{key: key, start: start, end: len(payload)})
+		if len(payload) >= maxCompactedFrameSize {
+			if err = flush(); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, 0, err
+	}
+	return index, size, w.Flush()
+}
+
+func (c *fileClient) syncLoop() {
+	defer close(c.syncDone)
+	ticker := time.NewTicker(c.fsync.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.mu.Lock()
+			if !c.closed && c.dirty {
+				if err := c.file.Sync(); err != nil {
+					c.logger.Error("Failed to sync the storage file", zap.String("path", c.path), zap.Error(err))
+				} else {
+					c.dirty = false
+				}
+			}
+			c.mu.Unlock()
+		case <-c.stopSync:
+			return
+		}
+	}
+}
+
+// Close will close the file and release the client, it is safe to call it multiple times.
+func (c *fileClient) Close(context.Context) error {
+	c.closeOnce.Do(func() {
+		if c.stopSync != nil {
+			close(c.stopSync)
+			<-c.syncDone
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !c.closed {
+			c.closed = true
+			if c.dirty && c.fsync.Policy != FSyncPolicyNever {
+				c.closeErr = c.file.Sync()
+			}
+			c.closeErr = errors.Join(c.closeErr, c.file.Close())
+		}
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return c.closeErr
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// readBytes reads a length-prefixed byte slice and returns it along with the number of bytes consumed.
+func readBytes(buf []byte) ([]byte, int, error) {
+	size, n := binary.Uvarint(buf)
+	if n <= 0 || uint64(len(buf)-n) < size {
+		return nil, 0, errors.New("invalid entry")
+	}
+	end := n + int(size) //nolint:gosec
+	return buf[n:end], end, nil
+}
+
+func cloneValue(value []byte, deleted bool) []byte {
+	if deleted {
+		return nil
+	}
+	return append([]byte{}, value...)
+}
+
+// syncDir flushes the directory entry changes to stable storage, errors are ignored
+// because not all the platforms support it.
+func syncDir(dir string) {
+	d, err := os.Open(filepath.Clean(dir))
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func newTestClient(t *testing.T, path string, modify func(*Config)) *fileClient {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = filepath.Dir(path)
+	if modify != nil {
+		modify(cfg)
+	}
+	client, err := newFileClient(zap.NewNop(), path, cfg, nil)
+	require.NoError(t, err)
+	return client
+}
+
+func TestClientOperations(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, filepath.Join(t.TempDir(), "client"), nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	val, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("new value")))
+	val, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), val)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	val, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	// Deleting a key that does not exist is a no-op.
+	require.NoError(t, client.Delete(ctx, "unknown"))
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, filepath.Join(t.TempDir(), "client"), nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+
+	getSet := storage.GetOperation("key1")
+	getDeleted := storage.GetOperation("deleted")
+	getBefore := storage.GetOperation("key2")
+	require.NoError(t, client.Batch(ctx,
+		getBefore,
+		storage.SetOperation("key1", []byte("value1")),
+		storage.SetOperation("key2", []byte("value2")),
+		storage.DeleteOperation("deleted"),
+		getSet,
+		getDeleted,
+	))
+	assert.Nil(t, getBefore.Value)
+	assert.Equal(t, []byte("value1"), getSet.Value)
+	assert.Nil(t, getDeleted.Value)
+
+	get1 := storage.GetOperation("key1")
+	get2 := storage.GetOperation("key2")
+	require.NoError(t, client.Batch(ctx, get1, get2))
+	assert.Equal(t, []byte("value1"), get1.Value)
+	assert.Equal(t, []byte("value2"), get2.Value)
+
+	assert.ErrorContains(t, client.Batch(ctx, &storage.Operation{Key: "key", Type: storage.OpType(42)}), "wrong operation type")
+}
+
+func TestClientPersistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "client")
+	client := newTestClient(t, path, nil)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(ctx, strconv.Itoa(i), []byte("value"+strconv.Itoa(i))))
+	}
+	for i := 0; i < 100; i += 2 {
+		require.NoError(t, client.Delete(ctx, strconv.Itoa(i)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	client = newTestClient(t, path, nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+	for i := 0; i < 100; i++ {
+		val, err := client.Get(ctx, strconv.Itoa(i))
+		require.NoError(t, err)
+		if i%2 == 0 {
+			assert.Nil(t, val)
+		} else {
+			assert.Equal(t, []byte("value"+strconv.Itoa(i)), val)
+		}
+	}
+}
+
+func TestClientDiscardsIncompleteFrame(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "client")
+	client := newTestClient(t, path, func(cfg *Config) { cfg.FSync.Policy = FSyncPolicyAlways })
+	require.NoError(t, client.Set(ctx, "complete", []byte("value")))
+	sizeBefore := client.size
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("incomplete1", []byte("value")),
+		storage.SetOperation("incomplete2", []byte("value"))))
+	require.NoError(t, client.Close(ctx))
+
+	// Simulate a crash in the middle of writing the last batch.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-3))
+
+	client = newTestClient(t, path, nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+	assert.Equal(t, sizeBefore, client.size)
+
+	get := storage.GetOperation("complete")
+	get1 := storage.GetOperation("incomplete1")
+	get2 := storage.GetOperation("incomplete2")
+	require.NoError(t, client.Batch(ctx, get, get1, get2))
+	assert.Equal(t, []byte("value"), get.Value)
+	assert.Nil(t, get1.Value)
+	assert.Nil(t, get2.Value)
+
+	// New writes are appended after the last complete frame.
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+}
+
+func TestClientDiscardsCorruptedFrame(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "client")
+	client := newTestClient(t, path, nil)
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Set(ctx, "key2", []byte("value2")))
+	require.NoError(t, client.Close(ctx))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xFF
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	client = newTestClient(t, path, nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+	val, err := client.Get(ctx, "key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	val, err = client.Get(ctx, "key2")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestClientInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "client")
+	require.NoError(t, os.WriteFile(path, []byte("not a storage file"), 0o600))
+	cfg := createDefaultConfig().(*Config)
+	_, err := newFileClient(zap.NewNop(), path, cfg, nil)
+	require.ErrorIs(t, err, errInvalidHeader)
+}
+
+func TestClientAutoCompaction(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "client")
+	client := newTestClient(t, path, func(cfg *Config) {
+		cfg.Compaction.MinSize = 4096
+		cfg.Compaction.MaxGarbageRatio = 0.5
+	})
+	value := make([]byte, 100)
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, client.Set(ctx, "key"+strconv.Itoa(i%10), value))
+		// The file never grows much past the minimum size since most of it is garbage.
+		assert.Less(t, client.size, int64(2*4096))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	client = newTestClient(t, path, nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+	for i := 0; i < 10; i++ {
+		val, err := client.Get(ctx, "key"+strconv.Itoa(i))
+		require.NoError(t, err)
+		assert.Equal(t, value, val)
+	}
+}
+
+func TestClientCompactionOnStart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "client")
+	client := newTestClient(t, path, func(cfg *Config) { cfg.Compaction.Auto = false })
+	for i := 0; i < 100; i++ {
+		require.NoError(t, client.Set(ctx, "key", []byte(strconv.Itoa(i))))
+	}
+	require.NoError(t, client.Close(ctx))
+	sizeBefore := client.size
+
+	client = newTestClient(t, path, func(cfg *Config) { cfg.Compaction.OnStart = true })
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+	assert.Less(t, client.size, sizeBefore)
+	assert.Zero(t, client.garbage)
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("99"), val)
+
+	_, err = os.Stat(path + ".compact")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestClientClosed(t *testing.T) {
+	ctx := context.Background()
+	closed := 0
+	cfg := createDefaultConfig().(*Config)
+	client, err := newFileClient(zap.NewNop(), filepath.Join(t.TempDir(), "client"), cfg, func() { closed++ })
+	require.NoError(t, err)
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, client.Close(ctx))
+	assert.Equal(t, 1, closed)
+
+	_, err = client.Get(ctx, "key")
+	require.ErrorIs(t, err, errClientClosed)
+	require.ErrorIs(t, client.Set(ctx, "key", []byte("value")), errClientClosed)
+	require.ErrorIs(t, client.Delete(ctx, "key"), errClientClosed)
+}
+
+func TestClientCanceledContext(t *testing.T) {
+	client := newTestClient(t, filepath.Join(t.TempDir(), "client"), nil)
+	t.Cleanup(func() { require.NoError(t, client.Close(context.Background())) })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, client.Set(ctx, "key", []byte("value")), context.Canceled)
+}
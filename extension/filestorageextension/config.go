@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*FSyncPolicy)(nil)
+	_ encoding.TextUnmarshaler = (*FSyncPolicy)(nil)
+)
+
+// FSyncPolicy determines when the data written by a client is flushed to stable storage.
+type FSyncPolicy struct {
+	val string
+}
+
+const (
+	fsyncPolicyAlways   = "always"
+	fsyncPolicyInterval = "interval"
+	fsyncPolicyNever    = "never"
+)
+
+var (
+	// FSyncPolicyAlways flushes the data to stable storage after every write.
+	FSyncPolicyAlways = FSyncPolicy{val: fsyncPolicyAlways}
+	// FSyncPolicyInterval flushes the data to stable storage periodically, see FSyncConfig.Interval.
+	FSyncPolicyInterval = FSyncPolicy{val: fsyncPolicyInterval}
+	// FSyncPolicyNever leaves flushing the data to the operating system.
+	FSyncPolicyNever = FSyncPolicy{val: fsyncPolicyNever}
+)
+
+// UnmarshalText implements TextUnmarshaler interface.
+func (p *FSyncPolicy) UnmarshalText(text []byte) error {
+	switch str := string(text); str {
+	case fsyncPolicyAlways:
+		*p = FSyncPolicyAlways
+	case fsyncPolicyInterval:
+		*p = FSyncPolicyInterval
+	case fsyncPolicyNever:
+		*p = FSyncPolicyNever
+	default:
+		return fmt.Errorf("invalid fsync policy: %q", str)
+	}
+	return nil
+}
+
+// MarshalText implements TextMarshaler interface.
+func (p *FSyncPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.val), nil
+}
+
+// CustomizeSchema adds the supported policies to the JSON Schema of the FSyncPolicy.
+func (*FSyncPolicy) CustomizeSchema(schema *xconfmap.Schema) {
+	schema.Enum = []any{fsyncPolicyAlways, fsyncPolicyInterval, fsyncPolicyNever}
+}
+
+// Config has the configuration for the file storage extension.
+type Config struct {
+	// Directory is the path of the directory where the client files are stored.
+	Directory string `mapstructure:"directory"`
+
+	// CreateDirectory indicates whether the directory is created on start if it does not exist.
+	// (default = false)
+	CreateDirectory bool `mapstructure:"create_directory"`
+
+	// Timeout is the maximum time to wait for the lock on the directory.
+	// Only one collector process can use the same directory at a time.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// FSync configures when the written data is flushed to stable storage.
+	FSync FSyncConfig `mapstructure:"fsync"`
+
+	// Compaction configures when the client files are rewritten to reclaim the space
+	// used by deleted or overwritten values.
+	Compaction CompactionConfig `mapstructure:"compaction"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// FSyncConfig has the configuration for flushing the written data to stable storage.
+type FSyncConfig struct {
+	// Policy is one of "always", "interval" or "never".
+	Policy FSyncPolicy `mapstructure:"policy"`
+
+	// Interval is the period between flushes when the policy is "interval".
+	Interval time.Duration `mapstructure:"interval"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// CompactionConfig has the configuration for the compaction of the client files.
+type CompactionConfig struct {
+	// OnStart indicates whether the client files are compacted when they are opened.
+	// (default = false)
+	OnStart bool `mapstructure:"on_start"`
+
+	// Auto indicates whether the client files are compacted after a write once they
+	// reach MinSize and the ratio of reclaimable space reaches MaxGarbageRatio.
+	// (default = true)
+	Auto bool `mapstructure:"auto"`
+
+	// MinSize is the minimum size in bytes of a client file before it is automatically compacted.
+	MinSize int64 `mapstructure:"min_size"`
+
+	// MaxGarbageRatio is the ratio, between 0 and 1, of reclaimable space in a client
+	// file that triggers an automatic compaction.
+	MaxGarbageRatio float64 `mapstructure:"max_garbage_ratio"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// CustomizeSchema adds the values accepted by Validate to the JSON Schema of the CompactionConfig.
+func (cfg *CompactionConfig) CustomizeSchema(schema *xconfmap.Schema) {
+	zero, one := 0.0, 1.0
+	schema.Properties["min_size"].Minimum = &zero
+	schema.Properties["max_garbage_ratio"].ExclusiveMinimum = &zero
+	schema.Properties["max_garbage_ratio"].Maximum = &one
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Directory == "" {
+		errs = errors.Join(errs, errors.New("`directory` must be specified"))
+	}
+	if cfg.Timeout < 0 {
+		errs = errors.Join(errs, errors.New("`timeout` must be non-negative"))
+	}
+	if cfg.FSync.Policy == FSyncPolicyInterval && cfg.FSync.Interval <= 0 {
+		errs = errors.Join(errs, errors.New("`fsync::interval` must be positive when `fsync::policy` is \"interval\""))
+	}
+	if cfg.Compaction.MinSize < 0 {
+		errs = errors.Join(errs, errors.New("`compaction::min_size` must be non-negative"))
+	}
+	if cfg.Compaction.MaxGarbageRatio <= 0 || cfg.Compaction.MaxGarbageRatio > 1 {
+		errs = errors.Join(errs, errors.New("`compaction::max_garbage_ratio` must be in the range (0, 1]"))
+	}
+	return errs
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Directory:       "/var/lib/otelcol/mydir",
+			CreateDirectory: true,
+			Timeout:         2 * time.Second,
+			FSync: FSyncConfig{
+				Policy:   FSyncPolicyAlways,
+				Interval: time.Second,
+			},
+			Compaction: CompactionConfig{
+				OnStart:         true,
+				Auto:            false,
+				MinSize:         1024,
+				MaxGarbageRatio: 0.25,
+			},
+		}, cfg)
+}
+
+func TestUnmarshalInvalidFSyncPolicy(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.ErrorContains(t, confmap.NewFromStringMap(map[string]any{
+		"fsync": map[string]any{"policy": "sometimes"},
+	}).Unmarshal(&cfg), "invalid fsync policy: \"sometimes\"")
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:    "missing directory",
+			modify:  func(cfg *Config) { cfg.Directory = "" },
+			wantErr: "`directory` must be specified",
+		},
+		{
+			name:    "negative timeout",
+			modify:  func(cfg *Config) { cfg.Timeout = -time.Second },
+			wantErr: "`timeout` must be non-negative",
+		},
+		{
+			name:    "zero fsync interval",
+			modify:  func(cfg *Config) { cfg.FSync.Interval = 0 },
+			wantErr: "`fsync::interval` must be positive",
+		},
+		{
+			name: "zero fsync interval with always policy",
+			modify: func(cfg *Config) {
+				cfg.FSync.Policy = FSyncPolicyAlways
+				cfg.FSync.Interval = 0
+			},
+		},
+		{
+			name:    "negative min size",
+			modify:  func(cfg *Config) { cfg.Compaction.MinSize = -1 },
+			wantErr: "`compaction::min_size` must be non-negative",
+		},
+		{
+			name:    "invalid garbage ratio",
+			modify:  func(cfg *Config) { cfg.Compaction.MaxGarbageRatio = 1.5 },
+			wantErr: "`compaction::max_garbage_ratio` must be in the range (0, 1]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			tt.modify(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, cfg.Validate())
+				return
+			}
+			assert.ErrorContains(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	schema := xconfmap.NewSchema(createDefaultConfig())
+	assert.Equal(t, []any{"always", "interval", "never"}, schema.Properties["fsync"].Properties["policy"].Enum)
+	compaction := schema.Properties["compaction"]
+	assert.InDelta(t, 0, *compaction.Properties["min_size"].Minimum, 0)
+	assert.InDelta(t, 0, *compaction.Properties["max_garbage_ratio"].ExclusiveMinimum, 0)
+	assert.InDelta(t, 1, *compaction.Properties["max_garbage_ratio"].Maximum, 0)
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package filestorageextension implements a storage extension that persists
+// the state of other components in files on the local filesystem.
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+const (
+	lockFileName      = ".lock"
+	lockRetryInterval = 10 * time.Millisecond
+)
+
+var (
+	errLocked     = errors.New("the directory is locked by another process")
+	errNotStarted = errors.New("the file storage extension is not started")
+
+	// lockedDirs holds the directories locked by this process, file locks do not
+	// prevent concurrent use of the same directory within a process on every platform.
+	lockedDirs   = map[string]struct{}{}
+	lockedDirsMu sync.Mutex
+)
+
+type fileStorage struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// mu guards everything declared below.
+	mu       sync.Mutex
+	dir      string
+	lockFile *os.File
+	clients  map[string]*fileClient
+}
+
+var _ storage.Extension = (*fileStorage)(nil)
+
+func newFileStorage(cfg *Config, logger *zap.Logger) *fileStorage {
+	return &fileStorage{
+		cfg:     cfg,
+		logger:  logger,
+		clients: map[string]*fileClient{},
+	}
+}
+
+// Start creates the directory if configured and locks it for this process.
+func (fs *fileStorage) Start(ctx context.Context, _ component.Host) error {
+	dir, err := filepath.Abs(fs.cfg.Directory)
+	if err != nil {
+		return err
+	}
+
+	if fs.cfg.CreateDirectory {
+		if err = os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create directory %q: %w", dir, err)
+		}
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("directory %q is not accessible: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", dir)
+	}
+
+	lockFile, err := lockDir(ctx, dir, fs.cfg.Timeout)
+	if err != nil {
+		return err
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.dir = dir
+	fs.lockFile = lockFile
+	return nil
+}
+
+// Shutdown closes the clients that are still open and releases the directory.
+func (fs *fileStorage) Shutdown(ctx context.Context) error {
+	fs.mu.Lock()
+	clients := make([]*fileClient, 0, len(fs.clients))
+	for name, client := range fs.clients {
+		fs.logger.Warn("Closing a storage client that was not closed by its component", zap.String("client", name))
+		clients = append(clients, client)
+	}
+	fs.mu.Unlock()
+
+	var errs error
+	for _, client := range clients {
+		errs = errors.Join(errs, client.Close(ctx))
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.lockFile != nil {
+		errs = errors.Join(errs, unlockDir(fs.dir, fs.lockFile))
+		fs.lockFile = nil
+	}
+	return errs
+}
+
+// GetClient returns a storage client for an individual component. Every client is
+// backed by its own file, named after the component kind, ID and the storage name.
+func (fs *fileStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, storageName string) (storage.Client, error) {
+	name := clientName(kind, id, storageName)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.lockFile == nil {
+		return nil, errNotStarted
+	}
+	if _, ok := fs.clients[name]; ok {
+		return nil, fmt.Errorf("storage client %q is already in use", name)
+	}
+
+	client, err := newFileClient(fs.logger.With(zap.String("client", name)), filepath.Join(fs.dir, name), fs.cfg, func() {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		delete(fs.clients, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fs.clients[name] = client
+	return client, nil
+}
+
+// clientName returns the name of the file used by a client, e.g. "exporter_otlp_backend_traces".
+func clientName(kind component.Kind, id component.ID, storageName string) string {
+	name := strings.ToLower(kind.String()) + "_" + id.Type().String()
+	if id.Name() != "" {
+		name += "_" + id.Name()
+	}
+	if storageName != "" {
+		name += "_" + storageName
+	}
+	return sanitize(name)
+}
+
+// sanitize replaces the characters that are not safe to use in a file name with
+// the "~" character followed by the hexadecimal code of the replaced character.
+func sanitize(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == '.' {
+			b.WriteRune(r)
+			continue
+		}
+		fmt.Fprintf(&b, "~%04X", r)
+	}
+	return b.String()
+}
+
+// lockDir acquires an exclusive lock on the directory, retrying until the timeout expires.
+func lockDir(ctx context.Context, dir string, timeout time.Duration) (*os.File, error) {
+	lockedDirsMu.Lock()
+	if _, ok := lockedDirs[dir]; ok {
+		lockedDirsMu.Unlock()
+		return nil, fmt.Errorf("directory %q is already used by another file storage extension", dir)
+	}
+	lockedDirs[dir] = struct{}{}
+	lockedDirsMu.Unlock()
+
+	f, err := lockFileWithTimeout(ctx, filepath.Join(dir, lockFileName), timeout)
+	if err != nil {
+		lockedDirsMu.Lock()
+		delete(lockedDirs, dir)
+		lockedDirsMu.Unlock()
+		return nil, fmt.Errorf("failed to lock directory %q: %w", dir, err)
+	}
+	return f, nil
+}
+
+func lockFileWithTimeout(ctx context.Context, path string, timeout time.Duration) (*os.File, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := tryLockPath(path)
+		if !errors.Is(err, errLocked) || !time.Now().Before(deadline) {
+			return f, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// tryLockPath opens and locks the file at the given path. The file is removed when the
+// lock is released, so it verifies the locked file is still the one at the path.
+func tryLockPath(path string) (*os.File, error) {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err = tryLockFile(f); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+
+	fileInfo, fErr := f.Stat()
+	pathInfo, pErr := os.Stat(path)
+	if fErr == nil && pErr == nil && os.SameFile(fileInfo, pathInfo) {
+		return f, nil
+	}
+	// The file was removed by the previous owner after we opened it.
+	_ = unlockFile(f)
+	_ = f.Close()
+	return nil, errLocked
+}
+
+func unlockDir(dir string, f *os.File) error {
+	defer func() {
+		lockedDirsMu.Lock()
+		delete(lockedDirs, dir)
+		lockedDirsMu.Unlock()
+	}()
+	// The file is removed while still holding the lock, see tryLockPath. Removing it is
+	// best effort, some platforms do not allow removing an open file.
+	_ = os.Remove(f.Name())
+	return errors.Join(unlockFile(f), f.Close())
+}
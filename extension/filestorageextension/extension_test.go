@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func newTestExtension(t *testing.T, dir string) storage.Extension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = dir
+	cfg.Timeout = 0
+	ext, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(NewFactory().Type()), cfg)
+	require.NoError(t, err)
+	return ext.(storage.Extension)
+}
+
+func TestExtensionClients(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ext := newTestExtension(t, dir)
+	require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+
+	id := component.MustNewIDWithName("otlp", "backend")
+	tracesClient, err := ext.GetClient(ctx, component.KindExporter, id, "traces")
+	require.NoError(t, err)
+	logsClient, err := ext.GetClient(ctx, component.KindExporter, id, "logs")
+	require.NoError(t, err)
+
+	// The same storage cannot be used twice at the same time.
+	_, err = ext.GetClient(ctx, component.KindExporter, id, "traces")
+	require.ErrorContains(t, err, "already in use")
+
+	require.NoError(t, tracesClient.Set(ctx, "key", []byte("traces")))
+	require.NoError(t, logsClient.Set(ctx, "key", []byte("logs")))
+	require.NoError(t, tracesClient.Close(ctx))
+	require.NoError(t, logsClient.Close(ctx))
+
+	assert.FileExists(t, filepath.Join(dir, "exporter_otlp_backend_traces"))
+	assert.FileExists(t, filepath.Join(dir, "exporter_otlp_backend_logs"))
+
+	tracesClient, err = ext.GetClient(ctx, component.KindExporter, id, "traces")
+	require.NoError(t, err)
+	val, err := tracesClient.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("traces"), val)
+
+	// Clients that are still open are closed on shutdown.
+	require.NoError(t, ext.Shutdown(ctx))
+	_, err = tracesClient.Get(ctx, "key")
+	require.ErrorIs(t, err, errClientClosed)
+}
+
+func TestExtensionNotStarted(t *testing.T) {
+	ext := newTestExtension(t, t.TempDir())
+	_, err := ext.GetClient(context.Background(), component.KindReceiver, component.MustNewID("otlp"), "")
+	require.ErrorIs(t, err, errNotStarted)
+}
+
+func TestExtensionDirectoryLocked(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	first := newTestExtension(t, dir)
+	require.NoError(t, first.Start(ctx, componenttest.NewNopHost()))
+
+	second := newTestExtension(t, dir)
+	require.ErrorContains(t, second.Start(ctx, componenttest.NewNopHost()), "already used by another file storage extension")
+
+	require.NoError(t, first.Shutdown(ctx))
+	require.NoError(t, second.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, second.Shutdown(ctx))
+}
+
+func TestExtensionLockTimeout(t *testing.T) {
+	dir := t.TempDir()
+	lockFile, err := tryLockPath(filepath.Join(dir, lockFileName))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, unlockDir(dir, lockFile)) }()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = dir
+	cfg.Timeout = 50 * time.Millisecond
+	ext := newFileStorage(cfg, zap.NewNop())
+	require.ErrorIs(t, ext.Start(context.Background(), componenttest.NewNopHost()), errLocked)
+}
+
+func TestExtensionCreateDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	ext := newTestExtension(t, dir)
+	require.Error(t, ext.Start(ctx, componenttest.NewNopHost()))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = dir
+	cfg.CreateDirectory = true
+	ext = newFileStorage(cfg, zap.NewNop())
+	require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, ext.Shutdown(ctx))
+	assert.DirExists(t, dir)
+}
+
+func TestExtensionDirectoryIsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	ext := newTestExtension(t, path)
+	require.ErrorContains(t, ext.Start(context.Background(), componenttest.NewNopHost()), "is not a directory")
+}
+
+func TestClientName(t *testing.T) {
+	assert.Equal(t, "receiver_otlp", clientName(component.KindReceiver, component.MustNewID("otlp"), ""))
+	assert.Equal(t, "exporter_otlp_backend_traces", clientName(component.KindExporter, component.MustNewIDWithName("otlp", "backend"), "traces"))
+	assert.Equal(t, "processor_batch_a~002Fb_c~003A..", clientName(component.KindProcessor, component.MustNewIDWithName("batch", "a/b"), "c:.."))
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/filestorageextension/internal/metadata"
+)
+
+const (
+	defaultTimeout         = time.Second
+	defaultFSyncInterval   = time.Second
+	defaultMinSize         = 1 << 20 // 1MiB
+	defaultMaxGarbageRatio = 0.5
+)
+
+// NewFactory creates a factory for the file storage extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Directory: defaultDirectory(),
+		Timeout:   defaultTimeout,
+		FSync: FSyncConfig{
+			Policy:   FSyncPolicyInterval,
+			Interval: defaultFSyncInterval,
+		},
+		Compaction: CompactionConfig{
+			Auto:            true,
+			MinSize:         defaultMinSize,
+			MaxGarbageRatio: defaultMaxGarbageRatio,
+		},
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newFileStorage(cfg.(*Config), set.Logger), nil
+}
+
+func defaultDirectory() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), "Otelcol", "FileStorage")
+	}
+	return filepath.Join("/var", "lib", "otelcol", "file_storage")
+}
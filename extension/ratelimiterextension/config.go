@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension // import "go.opentelemetry.io/collector/extension/ratelimiterextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+// ThrottleBy defines the key of the requests sharing a rate limit.
+type ThrottleBy string
+
+const (
+	// ThrottleByClientAddress limits the requests of every client address.
+	ThrottleByClientAddress ThrottleBy = "client_address"
+	// ThrottleByMetadata limits the requests of every value of a metadata key, e.g. a tenant header.
+	ThrottleByMetadata ThrottleBy = "metadata"
+	// ThrottleByAuth limits the requests of every value of an attribute of the authenticated client.
+	ThrottleByAuth ThrottleBy = "auth"
+)
+
+// CustomizeSchema adds the supported keys to the JSON Schema of the ThrottleBy.
+func (*ThrottleBy) CustomizeSchema(schema *xconfmap.Schema) {
+	schema.Enum = []any{string(ThrottleByClientAddress), string(ThrottleByMetadata), string(ThrottleByAuth)}
+}
+
+// Config has the configuration for the rate limiter extension.
+type Config struct {
+	// Rate is the number of requests per second allowed for every key.
+	Rate float64 `mapstructure:"rate"`
+
+	// Burst is the number of requests allowed at once for every key, the rate rounded up by default.
+	Burst int `mapstructure:"burst"`
+
+	// ThrottleBy is the key of the requests sharing a rate limit.
+	ThrottleBy ThrottleBy `mapstructure:"throttle_by"`
+
+	// MetadataKey is the metadata key, e.g. an HTTP header, used as key when ThrottleBy is "metadata".
+	// The servers must include the metadata of the requests, with include_metadata.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// AuthAttribute is the attribute of the authenticated client used as key when ThrottleBy is "auth".
+	AuthAttribute string `mapstructure:"auth_attribute"`
+
+	// MaxKeys is the maximum number of keys tracked, the least recently used keys are forgotten
+	// beyond this limit.
+	MaxKeys int `mapstructure:"max_keys"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Rate <= 0 {
+		errs = append(errs, errors.New("rate must be greater than zero"))
+	}
+	if cfg.Burst < 0 {
+		errs = append(errs, errors.New("burst must not be negative"))
+	}
+	switch cfg.ThrottleBy {
+	case ThrottleByClientAddress:
+	case ThrottleByMetadata:
+		if cfg.MetadataKey == "" {
+			errs = append(errs, errors.New("metadata_key must be set to throttle by metadata"))
+		}
+	case ThrottleByAuth:
+		if cfg.AuthAttribute == "" {
+			errs = append(errs, errors.New("auth_attribute must be set to throttle by auth"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported throttle_by %q, must be one of %q, %q or %q",
+			cfg.ThrottleBy, ThrottleByClientAddress, ThrottleByMetadata, ThrottleByAuth))
+	}
+	if cfg.MaxKeys <= 0 {
+		errs = append(errs, errors.New("max_keys must be greater than zero"))
+	}
+	return errors.Join(errs...)
+}
+
+// CustomizeSchema adds the values accepted by Validate to the JSON Schema of the Config.
+func (cfg *Config) CustomizeSchema(schema *xconfmap.Schema) {
+	zero, one := 0.0, 1.0
+	schema.Properties["rate"].ExclusiveMinimum = &zero
+	schema.Properties["burst"].Minimum = &zero
+	schema.Properties["max_keys"].Minimum = &one
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Rate:          50,
+			Burst:         100,
+			ThrottleBy:    ThrottleByMetadata,
+			MetadataKey:   "x-tenant",
+			AuthAttribute: "subject",
+			MaxKeys:       1000,
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "client address",
+			modify: func(*Config) {},
+		},
+		{
+			name: "metadata",
+			modify: func(cfg *Config) {
+				cfg.ThrottleBy = ThrottleByMetadata
+				cfg.MetadataKey = "x-tenant"
+			},
+		},
+		{
+			name:   "auth",
+			modify: func(cfg *Config) { cfg.ThrottleBy = ThrottleByAuth },
+		},
+		{
+			name:    "no rate",
+			modify:  func(cfg *Config) { cfg.Rate = 0 },
+			wantErr: "rate must be greater than zero",
+		},
+		{
+			name:    "negative burst",
+			modify:  func(cfg *Config) { cfg.Burst = -1 },
+			wantErr: "burst must not be negative",
+		},
+		{
+			name:    "metadata without key",
+			modify:  func(cfg *Config) { cfg.ThrottleBy = ThrottleByMetadata },
+			wantErr: "metadata_key must be set to throttle by metadata",
+		},
+		{
+			name: "auth without attribute",
+			modify: func(cfg *Config) {
+				cfg.ThrottleBy = ThrottleByAuth
+				cfg.AuthAttribute = ""
+			},
+			wantErr: "auth_attribute must be set to throttle by auth",
+		},
+		{
+			name:    "unsupported throttle_by",
+			modify:  func(cfg *Config) { cfg.ThrottleBy = "tenant" },
+			wantErr: `unsupported throttle_by "tenant", must be one of "client_address", "metadata" or "auth"`,
+		},
+		{
+			name:    "no max keys",
+			modify:  func(cfg *Config) { cfg.MaxKeys = 0 },
+			wantErr: "max_keys must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Rate = 10
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	schema := xconfmap.NewSchema(createDefaultConfig())
+	assert.Equal(t, []any{"client_address", "metadata", "auth"}, schema.Properties["throttle_by"].Enum)
+	assert.InDelta(t, 0, *schema.Properties["rate"].ExclusiveMinimum, 0)
+	assert.InDelta(t, 0, *schema.Properties["burst"].Minimum, 0)
+	assert.InDelta(t, 1, *schema.Properties["max_keys"].Minimum, 0)
+}
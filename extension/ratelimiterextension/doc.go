@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package ratelimiterextension implements a server middleware extension that limits
+// the rate of the requests received by the HTTP and gRPC servers with token buckets.
+package ratelimiterextension // import "go.opentelemetry.io/collector/extension/ratelimiterextension"
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension // import "go.opentelemetry.io/collector/extension/ratelimiterextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionmiddleware"
+	"go.opentelemetry.io/collector/extension/ratelimiterextension/internal/metadata"
+)
+
+const retryAfterHeader = "Retry-After"
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+var (
+	_ extension.Extension            = (*rateLimiter)(nil)
+	_ extensionmiddleware.HTTPServer = (*rateLimiter)(nil)
+	_ extensionmiddleware.GRPCServer = (*rateLimiter)(nil)
+)
+
+type rateLimiter struct {
+	component.StartFunc
+
+	cfg              *Config
+	limiter          *limiter
+	telemetryBuilder *metadata.TelemetryBuilder
+	httpAttrs        metric.MeasurementOption
+	grpcAttrs        metric.MeasurementOption
+	now              func() time.Time
+}
+
+func newRateLimiter(cfg *Config, set extension.Settings) (*rateLimiter, error) {
+	burst := cfg.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(cfg.Rate))
+	}
+	rl := &rateLimiter{
+		cfg:     cfg,
+		limiter: newLimiter(cfg.Rate, burst, cfg.MaxKeys),
+		httpAttrs: metric.WithAttributeSet(attribute.NewSet(
+			attribute.String("extension", set.ID.String()), attribute.String("protocol", "http"))),
+		grpcAttrs: metric.WithAttributeSet(attribute.NewSet(
+			attribute.String("extension", set.ID.String()), attribute.String("protocol", "grpc"))),
+		now: time.Now,
+	}
+
+	var err error
+	rl.telemetryBuilder, err = metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	keysAttrs := metric.WithAttributeSet(attribute.NewSet(attribute.String("extension", set.ID.String())))
+	err = rl.telemetryBuilder.RegisterRatelimiterKeysCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		observer.Observe(int64(rl.limiter.numKeys()), keysAttrs)
+		return nil
+	})
+	if err != nil {
+		rl.telemetryBuilder.Shutdown()
+		return nil, err
+	}
+	return rl, nil
+}
+
+// Shutdown unregisters the telemetry of the extension.
+func (rl *rateLimiter) Shutdown(context.Context) error {
+	rl.telemetryBuilder.Shutdown()
+	return nil
+}
+
+// GetHTTPHandler returns a handler rejecting the requests exceeding the rate with the status 429.
+func (rl *rateLimiter) GetHTTPHandler(base http.Handler) (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, retryAfter := rl.allow(r.Context(), rl.httpAttrs); !ok {
+			w.Header().Set(retryAfterHeader, retryAfterSeconds(retryAfter))
+			http.Error(w, errRateLimited.Error(), http.StatusTooManyRequests)
+			return
+		}
+		base.ServeHTTP(w, r)
+	}), nil
+}
+
+// GetGRPCServerOptions returns interceptors rejecting the calls exceeding the rate with the code RESOURCE_EXHAUSTED.
+// The streams are limited when they are opened.
+func (rl *rateLimiter) GetGRPCServerOptions() ([]grpc.ServerOption, error) {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if ok, retryAfter := rl.allow(ctx, rl.grpcAttrs); !ok {
+				_ = grpc.SetHeader(ctx, retryAfterMetadata(retryAfter))
+				return nil, rateLimitedStatus(retryAfter)
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if ok, retryAfter := rl.allow(ss.Context(), rl.grpcAttrs); !ok {
+				_ = ss.SetHeader(retryAfterMetadata(retryAfter))
+				return rateLimitedStatus(retryAfter)
+			}
+			return handler(srv, ss)
+		}),
+	}, nil
+}
+
+// allow takes a token from the bucket of the request, it returns false and the delay until
+// a token is available if the bucket is empty.
+func (rl *rateLimiter) allow(ctx context.Context, attrs metric.MeasurementOption) (bool, time.Duration) {
+	ok, retryAfter := rl.limiter.take(rl.key(ctx), rl.now())
+	if ok {
+		rl.telemetryBuilder.RatelimiterAcceptedRequests.Add(ctx, 1, attrs)
+	} else {
+		rl.telemetryBuilder.RatelimiterRejectedRequests.Add(ctx, 1, attrs)
+	}
+	return ok, retryAfter
+}
+
+// key returns the key of the request from the client information. The requests without key,
+// e.g. without the metadata key, share the same bucket.
+func (rl *rateLimiter) key(ctx context.Context) string {
+	info := client.FromContext(ctx)
+	switch rl.cfg.ThrottleBy {
+	case ThrottleByMetadata:
+		return strings.Join(info.Metadata.Get(rl.cfg.MetadataKey), ",")
+	case ThrottleByAuth:
+		if info.Auth == nil {
+			return ""
+		}
+		if value := info.Auth.GetAttribute(rl.cfg.AuthAttribute); value != nil {
+			return fmt.Sprint(value)
+		}
+		return ""
+	default:
+		if info.Addr == nil {
+			return ""
+		}
+		// The port changes with every connection of the client.
+		addr := info.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return host
+		}
+		return addr
+	}
+}
+
+// retryAfterSeconds returns the delay in seconds, rounded up, as expected by the Retry-After header.
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds()))))
+}
+
+func retryAfterMetadata(retryAfter time.Duration) grpcmetadata.MD {
+	return grpcmetadata.Pairs(strings.ToLower(retryAfterHeader), retryAfterSeconds(retryAfter))
+}
+
+// rateLimitedStatus returns the RESOURCE_EXHAUSTED status, with the delay before retrying as RetryInfo
+// like expected by the OTLP exporters.
+func rateLimitedStatus(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, errRateLimited.Error())
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/ratelimiterextension/internal/metadatatest"
+)
+
+func newTestRateLimiter(t *testing.T, cfg *Config) *rateLimiter {
+	rl, err := newRateLimiter(cfg, extensiontest.NewNopSettings(component.MustNewType("ratelimiter")))
+	require.NoError(t, err)
+	require.NoError(t, rl.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, rl.Shutdown(context.Background())) })
+	return rl
+}
+
+type testAuthData map[string]any
+
+func (d testAuthData) GetAttribute(name string) any {
+	return d[name]
+}
+
+func (d testAuthData) GetAttributeNames() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  func(*Config)
+		info client.Info
+		want string
+	}{
+		{
+			name: "tcp address",
+			cfg:  func(*Config) {},
+			info: client.Info{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 51234}},
+			want: "10.0.0.1",
+		},
+		{
+			name: "ip address",
+			cfg:  func(*Config) {},
+			info: client.Info{Addr: &net.IPAddr{IP: net.ParseIP("::1")}},
+			want: "::1",
+		},
+		{
+			name: "no address",
+			cfg:  func(*Config) {},
+			want: "",
+		},
+		{
+			name: "metadata",
+			cfg: func(cfg *Config) {
+				cfg.ThrottleBy = ThrottleByMetadata
+				cfg.MetadataKey = "X-Tenant"
+			},
+			info: client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"tenant-a"}})},
+			want: "tenant-a",
+		},
+		{
+			name: "auth",
+			cfg:  func(cfg *Config) { cfg.ThrottleBy = ThrottleByAuth },
+			info: client.Info{Auth: testAuthData{"subject": "tenant-b"}},
+			want: "tenant-b",
+		},
+		{
+			name: "auth without attribute",
+			cfg:  func(cfg *Config) { cfg.ThrottleBy = ThrottleByAuth },
+			info: client.Info{Auth: testAuthData{}},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Rate = 1
+			tt.cfg(cfg)
+			rl := newTestRateLimiter(t, cfg)
+			assert.Equal(t, tt.want, rl.key(client.NewContext(context.Background(), tt.info)))
+		})
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rate = 0.5
+	cfg.Burst = 2
+	rl, err := newRateLimiter(cfg, metadatatest.NewSettings(tel))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, rl.Shutdown(context.Background())) })
+	now := time.Unix(0, 0)
+	rl.now = func() time.Time { return now }
+
+	handler, err := rl.GetHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.NoError(t, err)
+
+	send := func(addr string) *httptest.ResponseRecorder {
+		ctx := client.NewContext(context.Background(), client.Info{Addr: &net.IPAddr{IP: net.ParseIP(addr)}})
+		req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/v1/traces", http.NoBody)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, send("10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, send("10.0.0.1").Code)
+	rec := send("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, send("10.0.0.2").Code)
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, http.StatusOK, send("10.0.0.1").Code)
+
+	attrs := attribute.NewSet(attribute.String("extension", "ratelimiter"), attribute.String("protocol", "http"))
+	metadatatest.AssertEqualRatelimiterAcceptedRequests(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 4, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualRatelimiterRejectedRequests(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualRatelimiterKeys(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: attribute.NewSet(attribute.String("extension", "ratelimiter"))}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestGRPCServerOptions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rate = 0.1
+	cfg.Burst = 1
+	rl := newTestRateLimiter(t, cfg)
+
+	opts, err := rl.GetGRPCServerOptions()
+	require.NoError(t, err)
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	healthClient := healthpb.NewHealthClient(conn)
+
+	_, err = healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	var header grpcmetadata.MD
+	_, err = healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.InDelta(t, 10*time.Second, retryInfo.RetryDelay.AsDuration(), float64(time.Second))
+	assert.Equal(t, []string{"10"}, header.Get("retry-after"))
+
+	// The streams are limited when they are opened.
+	stream, err := healthClient.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension // import "go.opentelemetry.io/collector/extension/ratelimiterextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/ratelimiterextension/internal/metadata"
+)
+
+const (
+	defaultAuthAttribute = "subject"
+	defaultMaxKeys       = 10000
+)
+
+// NewFactory creates a factory for the rate limiter extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ThrottleBy:    ThrottleByClientAddress,
+		AuthAttribute: defaultAuthAttribute,
+		MaxKeys:       defaultMaxKeys,
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newRateLimiter(cfg.(*Config), set)
+}
@@ -0,0 +1,16 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("ratelimiter")
+	ScopeName = "go.opentelemetry.io/collector/extension/ratelimiterextension"
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,109 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("go.opentelemetry.io/collector/extension/ratelimiterextension")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("go.opentelemetry.io/collector/extension/ratelimiterextension")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                       metric.Meter
+	mu                          sync.Mutex
+	registrations               []metric.Registration
+	RatelimiterAcceptedRequests metric.Int64Counter
+	RatelimiterKeys             metric.Int64ObservableUpDownCounter
+	RatelimiterRejectedRequests metric.Int64Counter
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// RegisterRatelimiterKeysCallback sets callback for observable RatelimiterKeys metric.
+func (builder *TelemetryBuilder) RegisterRatelimiterKeysCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.RatelimiterKeys, obs: o})
+		return nil
+	}, builder.RatelimiterKeys)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+type observerInt64 struct {
+	embedded.Int64Observer
+	inst metric.Int64Observable
+	obs  metric.Observer
+}
+
+func (oi *observerInt64) Observe(value int64, opts ...metric.ObserveOption) {
+	oi.obs.ObserveInt64(oi.inst, value, opts...)
+}
+
+// Shutdown unregister all registered callbacks for async instruments.
+func (builder *TelemetryBuilder) Shutdown() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	for _, reg := range builder.registrations {
+		reg.Unregister()
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.RatelimiterAcceptedRequests, err = builder.meter.Int64Counter(
+		"otelcol_ratelimiter_accepted_requests",
+		metric.WithDescription("Number of requests accepted by the rate limiter."),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.RatelimiterKeys, err = builder.meter.Int64ObservableUpDownCounter(
+		"otelcol_ratelimiter_keys",
+		metric.WithDescription("Number of keys tracked by the rate limiter."),
+		metric.WithUnit("{keys}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.RatelimiterRejectedRequests, err = builder.meter.Int64Counter(
+		"otelcol_ratelimiter_rejected_requests",
+		metric.WithDescription("Number of requests rejected by the rate limiter because their key exceeded the rate."),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
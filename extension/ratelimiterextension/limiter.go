@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension // import "go.opentelemetry.io/collector/extension/ratelimiterextension"
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// bucket is the token bucket of a key.
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// limiter holds a token bucket for every key, refilled at the same rate. The buckets of the least recently
+// used keys are removed beyond maxKeys, they are full again when the key is seen again.
+type limiter struct {
+	rate    float64
+	burst   float64
+	maxKeys int
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	// lru holds the buckets, the most recently used first.
+	lru *list.List
+}
+
+func newLimiter(rate float64, burst, maxKeys int) *limiter {
+	return &limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		maxKeys: maxKeys,
+		buckets: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// take takes a token from the bucket of the key. If the bucket is empty, it returns false and the delay
+// until a token is available.
+func (l *limiter) take(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var b *bucket
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		b = e.Value.(*bucket)
+		if elapsed := now.Sub(b.last); elapsed > 0 {
+			b.tokens = min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+			b.last = now
+		}
+	} else {
+		if l.lru.Len() >= l.maxKeys {
+			oldest := l.lru.Back()
+			l.lru.Remove(oldest)
+			delete(l.buckets, oldest.Value.(*bucket).key)
+		}
+		b = &bucket{key: key, tokens: l.burst, last: now}
+		l.buckets[key] = l.lru.PushFront(b)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// numKeys returns the number of keys with a bucket.
+func (l *limiter) numKeys() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lru.Len()
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimiterextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterTake(t *testing.T) {
+	l := newLimiter(2, 3, 10)
+	now := time.Unix(0, 0)
+
+	// The burst is available at once.
+	for i := 0; i < 3; i++ {
+		ok, _ := l.take("a", now)
+		assert.True(t, ok)
+	}
+	ok, retryAfter := l.take("a", now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// The other keys have their own bucket.
+	ok, _ = l.take("b", now)
+	assert.True(t, ok)
+
+	// The bucket is refilled at the rate.
+	ok, retryAfter = l.take("a", now.Add(250*time.Millisecond))
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+	ok, _ = l.take("a", now.Add(500*time.Millisecond))
+	assert.True(t, ok)
+
+	// The bucket is not refilled beyond the burst.
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _ = l.take("a", now)
+		assert.True(t, ok)
+	}
+	ok, _ = l.take("a", now)
+	assert.False(t, ok)
+}
+
+func TestLimiterMaxKeys(t *testing.T) {
+	l := newLimiter(1, 1, 2)
+	now := time.Unix(0, 0)
+
+	for _, key := range []string{"a", "b", "a", "c"} {
+		l.take(key, now)
+	}
+	assert.Equal(t, 2, l.numKeys())
+
+	// "b" was the least recently used key, its bucket is full again.
+	ok, _ := l.take("b", now)
+	assert.True(t, ok)
+	// "a" was evicted by "b", "c" is still limited.
+	ok, _ = l.take("c", now)
+	assert.False(t, ok)
+}
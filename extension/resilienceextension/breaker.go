@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	// stateHalfOpen lets a single attempt probe the server.
+	stateHalfOpen
+)
+
+// outcome is the outcome of an attempt, as seen by the circuit breaker.
+type outcome int
+
+const (
+	// outcomeSuccess means that the server handled the request, even if it rejected it.
+	outcomeSuccess outcome = iota
+	// outcomeFailure means that the attempt failed with a retryable failure.
+	outcomeFailure
+	// outcomeCanceled means that the attempt was canceled by the client.
+	outcomeCanceled
+)
+
+// circuitBreaker counts the consecutive failed attempts, and rejects the attempts while it is open.
+// A nil circuitBreaker allows all the attempts.
+type circuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	logger           *zap.Logger
+	now              func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig, logger *zap.Logger) *circuitBreaker {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &circuitBreaker{
+		failureThreshold: cfg.FailureThreshold,
+		openDuration:     cfg.OpenDuration,
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+// allow returns errCircuitOpen if the attempt must not be sent. Every allowed attempt must be followed
+// by a call to done with its outcome.
+func (cb *circuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case stateOpen:
+		if cb.now().Sub(cb.openedAt) < cb.openDuration {
+			return errCircuitOpen
+		}
+		cb.state = stateHalfOpen
+		fallthrough
+	case stateHalfOpen:
+		if cb.probing {
+			return errCircuitOpen
+		}
+		cb.probing = true
+	}
+	return nil
+}
+
+// done records the outcome of an allowed attempt.
+func (cb *circuitBreaker) done(o outcome) {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == stateHalfOpen {
+		cb.probing = false
+	}
+	switch o {
+	case outcomeSuccess:
+		if cb.state == stateHalfOpen {
+			cb.logger.Info("Circuit breaker closed, the server is available again")
+		}
+		cb.state = stateClosed
+		cb.failures = 0
+	case outcomeFailure:
+		cb.failures++
+		if cb.state == stateHalfOpen || (cb.state == stateClosed && cb.failures >= cb.failureThreshold) {
+			if cb.state == stateClosed {
+				cb.logger.Warn("Circuit breaker opened, the requests are rejected until the server is probed again",
+					zap.Int("failures", cb.failures), zap.Duration("open_duration", cb.openDuration))
+			}
+			cb.state = stateOpen
+			cb.openedAt = cb.now()
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cb := newCircuitBreaker(CircuitBreakerConfig{Enabled: true, FailureThreshold: 2, OpenDuration: time.Minute}, zap.NewNop())
+	now := time.Unix(0, 0)
+	cb.now = func() time.Time { return now }
+
+	// A success resets the consecutive failures.
+	for _, o := range []outcome{outcomeFailure, outcomeSuccess, outcomeFailure, outcomeCanceled} {
+		require.NoError(t, cb.allow())
+		cb.done(o)
+	}
+	require.NoError(t, cb.allow())
+	cb.done(outcomeFailure)
+	assert.ErrorIs(t, cb.allow(), errCircuitOpen)
+
+	// A single attempt probes the server once the circuit breaker is half-open.
+	now = now.Add(time.Minute)
+	require.NoError(t, cb.allow())
+	assert.ErrorIs(t, cb.allow(), errCircuitOpen)
+	cb.done(outcomeFailure)
+	assert.ErrorIs(t, cb.allow(), errCircuitOpen)
+
+	// A canceled probe does not change the state.
+	now = now.Add(time.Minute)
+	require.NoError(t, cb.allow())
+	cb.done(outcomeCanceled)
+	require.NoError(t, cb.allow())
+	cb.done(outcomeSuccess)
+
+	// The circuit breaker is closed again.
+	require.NoError(t, cb.allow())
+	require.NoError(t, cb.allow())
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	cb := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Minute}, zap.NewNop())
+	assert.Nil(t, cb)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cb.allow())
+		cb.done(outcomeFailure)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+// Config has the configuration for the resilience extension.
+type Config struct {
+	// Retry configures the retries of the failed requests.
+	Retry RetryConfig `mapstructure:"retry"`
+
+	// Hedging configures the hedged requests, sent while the previous requests are still pending.
+	Hedging HedgingConfig `mapstructure:"hedging"`
+
+	// CircuitBreaker configures the circuit breaker rejecting the requests while the server is failing.
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// RetryConfig defines how the failed requests are retried, with an exponential backoff.
+type RetryConfig struct {
+	configretry.BackOffConfig `mapstructure:",squash"`
+
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// HedgingConfig defines how the hedged requests are sent. A hedged request is a copy of the request,
+// sent when the previous attempts did not get a response after Delay or failed. The first response
+// that is not a retryable failure is used, the other attempts are canceled.
+type HedgingConfig struct {
+	// Enabled indicates whether the hedged requests are sent.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Delay is the time to wait for a response before sending the next hedged request.
+	Delay time.Duration `mapstructure:"delay"`
+
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// CircuitBreakerConfig defines when the circuit breaker opens. While it is open, the requests are rejected
+// without being sent. After OpenDuration, a single request is sent to probe the server: the circuit breaker
+// closes if it succeeds, and opens again otherwise.
+type CircuitBreakerConfig struct {
+	// Enabled indicates whether the circuit breaker is used.
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureThreshold is the number of consecutive failed attempts opening the circuit breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// OpenDuration is the time during which the requests are rejected once the circuit breaker is open.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Retry.Enabled && cfg.Retry.MaxAttempts < 1 {
+		errs = append(errs, errors.New("retry::max_attempts must be greater than zero"))
+	}
+	if cfg.Hedging.Enabled {
+		if cfg.Retry.Enabled {
+			errs = append(errs, errors.New("retry and hedging cannot be enabled together"))
+		}
+		if cfg.Hedging.Delay < 0 {
+			errs = append(errs, errors.New("hedging::delay must not be negative"))
+		}
+		if cfg.Hedging.MaxAttempts < 2 {
+			errs = append(errs, errors.New("hedging::max_attempts must be at least 2"))
+		}
+	}
+	if cfg.CircuitBreaker.Enabled {
+		if cfg.CircuitBreaker.FailureThreshold < 1 {
+			errs = append(errs, errors.New("circuit_breaker::failure_threshold must be greater than zero"))
+		}
+		if cfg.CircuitBreaker.OpenDuration <= 0 {
+			errs = append(errs, errors.New("circuit_breaker::open_duration must be greater than zero"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CustomizeSchema adds the values accepted by Validate to the JSON Schema of the Config.
+func (cfg *Config) CustomizeSchema(schema *xconfmap.Schema) {
+	one, two := 1.0, 2.0
+	schema.Properties["retry"].Properties["max_attempts"].Minimum = &one
+	schema.Properties["hedging"].Properties["max_attempts"].Minimum = &two
+	schema.Properties["circuit_breaker"].Properties["failure_threshold"].Minimum = &one
+}
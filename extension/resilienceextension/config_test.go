@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Retry.Enabled = false
+	expected.Hedging = HedgingConfig{Enabled: true, Delay: 200 * time.Millisecond, MaxAttempts: 3}
+	expected.CircuitBreaker = CircuitBreakerConfig{Enabled: true, FailureThreshold: 10, OpenDuration: time.Minute}
+	assert.Equal(t, expected, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:    "no retry attempts",
+			modify:  func(cfg *Config) { cfg.Retry.MaxAttempts = 0 },
+			wantErr: "retry::max_attempts must be greater than zero",
+		},
+		{
+			name:    "retry and hedging",
+			modify:  func(cfg *Config) { cfg.Hedging.Enabled = true },
+			wantErr: "retry and hedging cannot be enabled together",
+		},
+		{
+			name: "invalid hedging",
+			modify: func(cfg *Config) {
+				cfg.Retry.Enabled = false
+				cfg.Hedging.Enabled = true
+				cfg.Hedging.Delay = -time.Second
+				cfg.Hedging.MaxAttempts = 1
+			},
+			wantErr: "hedging::delay must not be negative\nhedging::max_attempts must be at least 2",
+		},
+		{
+			name: "invalid circuit breaker",
+			modify: func(cfg *Config) {
+				cfg.CircuitBreaker.Enabled = true
+				cfg.CircuitBreaker.FailureThreshold = 0
+				cfg.CircuitBreaker.OpenDuration = 0
+			},
+			wantErr: "circuit_breaker::failure_threshold must be greater than zero\ncircuit_breaker::open_duration must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	schema := xconfmap.NewSchema(createDefaultConfig())
+	assert.InDelta(t, 1, *schema.Properties["retry"].Properties["max_attempts"].Minimum, 0)
+	assert.InDelta(t, 2, *schema.Properties["hedging"].Properties["max_attempts"].Minimum, 0)
+	assert.InDelta(t, 1, *schema.Properties["circuit_breaker"].Properties["failure_threshold"].Minimum, 0)
+}
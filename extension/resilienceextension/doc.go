@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package resilienceextension implements a client middleware extension that retries or hedges
+// the requests of the HTTP and gRPC clients, and stops sending requests to failing servers
+// with a circuit breaker.
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
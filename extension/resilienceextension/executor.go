@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+)
+
+// result is the result of an attempt.
+type result[T any] struct {
+	value   T
+	err     error
+	outcome outcome
+	// retryAfter is the delay requested by the server before retrying, or 0.
+	retryAfter time.Duration
+}
+
+// call is a request sent with several attempts.
+type call[T any] interface {
+	// attempt sends an attempt of the request, numbered from 0.
+	attempt(ctx context.Context, n int) result[T]
+	// discard releases the value of an attempt that is not returned.
+	discard(value T)
+	// keep returns the value of the hedged attempt that is returned, cancel must be called
+	// once the value is released.
+	keep(value T, cancel context.CancelFunc) T
+	// rejected returns the error returned when the circuit breaker rejects the request.
+	rejected() error
+}
+
+// executor sends the requests with the retries or the hedged requests of its configuration.
+type executor struct {
+	cfg     *Config
+	breaker *circuitBreaker
+	stopCh  chan struct{}
+}
+
+// execute sends the request, with a single attempt when retry is false, e.g. because its body cannot be sent again.
+func execute[T any](ctx context.Context, e *executor, c call[T], retry bool) (T, error) {
+	switch {
+	case retry && e.cfg.Hedging.Enabled:
+		return executeHedged(ctx, e, c)
+	case retry && e.cfg.Retry.Enabled:
+		return executeWithRetries(ctx, e, c)
+	default:
+		if err := e.breaker.allow(); err != nil {
+			var zero T
+			return zero, c.rejected()
+		}
+		r := c.attempt(ctx, 0)
+		e.breaker.done(r.outcome)
+		return r.value, r.err
+	}
+}
+
+// executeWithRetries sends the attempts one after the other, until an attempt does not fail with a retryable failure.
+func executeWithRetries[T any](ctx context.Context, e *executor, c call[T]) (T, error) {
+	// Do not use NewExponentialBackOff since it calls Reset and the code here must
+	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
+	expBackoff := backoff.ExponentialBackOff{
+		InitialInterval:     e.cfg.Retry.InitialInterval,
+		RandomizationFactor: e.cfg.Retry.RandomizationFactor,
+		Multiplier:          e.cfg.Retry.Multiplier,
+		MaxInterval:         e.cfg.Retry.MaxInterval,
+	}
+	expBackoff.Reset()
+	var maxElapsedTime time.Time
+	if e.cfg.Retry.MaxElapsedTime > 0 {
+		maxElapsedTime = time.Now().Add(e.cfg.Retry.MaxElapsedTime)
+	}
+
+	var last result[T]
+	for n := 0; ; n++ {
+		if err := e.breaker.allow(); err != nil {
+			if n > 0 {
+				// The result of the previous attempt is more useful than the rejection.
+				return last.value, last.err
+			}
+			return last.value, c.rejected()
+		}
+		if n > 0 {
+			// The previous attempt is released only once the next one is allowed, it is returned otherwise.
+			c.discard(last.value)
+		}
+		last = c.attempt(ctx, n)
+		e.breaker.done(last.outcome)
+		if last.outcome != outcomeFailure || n+1 >= e.cfg.Retry.MaxAttempts {
+			return last.value, last.err
+		}
+
+		delay := max(expBackoff.NextBackOff(), last.retryAfter)
+		nextRetryTime := time.Now().Add(delay)
+		if !maxElapsedTime.IsZero() && maxElapsedTime.Before(nextRetryTime) {
+			return last.value, last.err
+		}
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(nextRetryTime) {
+			return last.value, last.err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last.value, last.err
+		case <-e.stopCh:
+			timer.Stop()
+			return last.value, last.err
+		case <-timer.C:
+		}
+	}
+}
+
+type hedgedResult[T any] struct {
+	result[T]
+	n int
+}
+
+// executeHedged sends the attempts concurrently, the next attempt is sent when the previous ones did not get
+// a response after the hedging delay, or when an attempt fails with a retryable failure.
+func executeHedged[T any](ctx context.Context, e *executor, c call[T]) (T, error) {
+	results := make(chan hedgedResult[T], e.cfg.Hedging.MaxAttempts)
+	cancels := make([]context.CancelFunc, 0, e.cfg.Hedging.MaxAttempts)
+	pending := 0
+	launch := func() bool {
+		if len(cancels) >= e.cfg.Hedging.MaxAttempts || e.breaker.allow() != nil {
+			return false
+		}
+		n := len(cancels)
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		pending++
+		go func() {
+			results <- hedgedResult[T]{result: c.attempt(attemptCtx, n), n: n}
+		}()
+		return true
+	}
+
+	if !launch() {
+		var zero T
+		return zero, c.rejected()
+	}
+	timer := time.NewTimer(e.cfg.Hedging.Delay)
+	defer timer.Stop()
+
+	var last hedgedResult[T]
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if launch() {
+				timer.Reset(e.cfg.Hedging.Delay)
+			}
+			continue
+		case last = <-results:
+			pending--
+		}
+		e.breaker.done(last.outcome)
+		if last.outcome != outcomeFailure {
+			break
+		}
+		// A new attempt is sent at once to replace the failed one.
+		launched := launch()
+		if pending == 0 {
+			break
+		}
+		c.discard(last.value)
+		cancels[last.n]()
+		if launched {
+			timer.Reset(e.cfg.Hedging.Delay)
+		}
+	}
+
+	// Cancel the other attempts, and release their results once they return.
+	for n, cancel := range cancels {
+		if n != last.n {
+			cancel()
+		}
+	}
+	go func(pending int) {
+		for ; pending > 0; pending-- {
+			r := <-results
+			e.breaker.done(r.outcome)
+			c.discard(r.value)
+		}
+	}(pending)
+	return c.keep(last.value, cancels[last.n]), last.err
+}
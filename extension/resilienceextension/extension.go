@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"context"
+	"net/http"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionmiddleware"
+)
+
+var (
+	_ extension.Extension            = (*resilience)(nil)
+	_ extensionmiddleware.HTTPClient = (*resilience)(nil)
+	_ extensionmiddleware.GRPCClient = (*resilience)(nil)
+)
+
+// resilience shares its circuit breaker between all the clients using the extension.
+type resilience struct {
+	component.StartFunc
+
+	executor *executor
+}
+
+func newResilience(cfg *Config, logger *zap.Logger) *resilience {
+	return &resilience{
+		executor: &executor{
+			cfg:     cfg,
+			breaker: newCircuitBreaker(cfg.CircuitBreaker, logger),
+			stopCh:  make(chan struct{}),
+		},
+	}
+}
+
+// Shutdown stops waiting before the retries, the requests return the result of their last attempt.
+func (r *resilience) Shutdown(context.Context) error {
+	close(r.executor.stopCh)
+	return nil
+}
+
+// GetHTTPRoundTripper wraps the RoundTripper with the retries, hedged requests and circuit breaker of the configuration.
+func (r *resilience) GetHTTPRoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &roundTripper{executor: r.executor, base: base}, nil
+}
+
+// GetGRPCClientOptions returns the interceptor applying the retries, hedged requests and circuit breaker
+// of the configuration to the unary calls.
+func (r *resilience) GetGRPCClientOptions() ([]grpc.DialOption, error) {
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(r.executor.unaryClientInterceptor)}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/resilienceextension/internal/metadata"
+)
+
+// NewFactory creates a factory for the resilience extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	// The requests are retried quickly, the exporters retry the whole exports with longer intervals.
+	backOff := configretry.NewDefaultBackOffConfig()
+	backOff.InitialInterval = 100 * time.Millisecond
+	backOff.MaxInterval = time.Second
+	backOff.MaxElapsedTime = 5 * time.Second
+	return &Config{
+		Retry: RetryConfig{
+			BackOffConfig: backOff,
+			MaxAttempts:   3,
+		},
+		Hedging: HedgingConfig{
+			Delay:       500 * time.Millisecond,
+			MaxAttempts: 2,
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: 5,
+			OpenDuration:     30 * time.Second,
+		},
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newResilience(cfg.(*Config), set.Logger), nil
+}
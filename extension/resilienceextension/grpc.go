@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// unaryClientInterceptor sends the unary calls with the retries or the hedged requests of the configuration.
+// The streaming calls are not retried.
+func (e *executor) unaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c := &grpcCall{method: method, req: req, reply: reply, cc: cc, invoker: invoker, opts: opts, hedged: e.cfg.Hedging.Enabled}
+	// The hedged attempts are concurrent, each of them needs its own reply.
+	_, isProto := reply.(proto.Message)
+	retry := isProto || !c.hedged
+	_, err := execute[any](ctx, e, c, retry)
+	return err
+}
+
+type grpcCall struct {
+	method  string
+	req     any
+	reply   any
+	cc      *grpc.ClientConn
+	invoker grpc.UnaryInvoker
+	opts    []grpc.CallOption
+	hedged  bool
+}
+
+func (c *grpcCall) attempt(ctx context.Context, _ int) result[any] {
+	reply := c.reply
+	if msg, ok := reply.(proto.Message); ok && c.hedged {
+		reply = msg.ProtoReflect().New().Interface()
+	}
+	err := c.invoker(ctx, c.method, c.req, reply, c.cc, c.opts...)
+	if err == nil {
+		return result[any]{value: reply, outcome: outcomeSuccess}
+	}
+	if ctx.Err() != nil {
+		return result[any]{err: err, outcome: outcomeCanceled}
+	}
+
+	st := status.Convert(err)
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+			break
+		}
+	}
+	if !isRetryableCode(st.Code(), retryInfo) {
+		return result[any]{err: err, outcome: outcomeSuccess}
+	}
+	r := result[any]{err: err, outcome: outcomeFailure}
+	if retryInfo != nil {
+		r.retryAfter = retryInfo.GetRetryDelay().AsDuration()
+	}
+	return r
+}
+
+func (c *grpcCall) discard(any) {}
+
+func (c *grpcCall) keep(reply any, cancel context.CancelFunc) any {
+	// The unary calls are complete, the context of the attempt is not used anymore.
+	cancel()
+	if reply != nil && reply != c.reply {
+		proto.Reset(c.reply.(proto.Message))
+		proto.Merge(c.reply.(proto.Message), reply.(proto.Message))
+	}
+	return c.reply
+}
+
+func (c *grpcCall) rejected() error {
+	return status.Error(codes.Unavailable, errCircuitOpen.Error())
+}
+
+// isRetryableCode returns true for the codes indicating a transient failure, like the OTLP exporter.
+// The code RESOURCE_EXHAUSTED is retryable only when the server tells when to retry.
+func isRetryableCode(code codes.Code, retryInfo *errdetails.RetryInfo) bool {
+	switch code {
+	case codes.Aborted,
+		codes.OutOfRange,
+		codes.Unavailable,
+		codes.DataLoss:
+		return true
+	case codes.ResourceExhausted:
+		return retryInfo != nil
+	}
+	return false
+}
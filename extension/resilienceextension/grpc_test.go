@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// healthServer answers with the given errors, then with SERVING. The calls of the attempts
+// listed in slow wait for their cancellation.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	mu    sync.Mutex
+	calls int
+	errs  []error
+	slow  map[int]bool
+}
+
+func (s *healthServer) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.mu.Lock()
+	n := s.calls
+	s.calls++
+	s.mu.Unlock()
+	if s.slow[n] {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if n < len(s.errs) {
+		return nil, s.errs[n]
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *healthServer) numCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func newTestHealthClient(t *testing.T, cfg *Config, srv *healthServer) healthpb.HealthClient {
+	r := newResilience(cfg, zap.NewNop())
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, srv)
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	opts, err := r.GetGRPCClientOptions()
+	require.NoError(t, err)
+	conn, err := grpc.NewClient(lis.Addr().String(), append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	return healthpb.NewHealthClient(conn)
+}
+
+func resourceExhausted(t *testing.T, retryDelay time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+	require.NoError(t, err)
+	return st.Err()
+}
+
+func TestGRPCRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			wantCode:  codes.OK,
+			wantCalls: 1,
+		},
+		{
+			name:      "retried",
+			errs:      []error{status.Error(codes.Unavailable, "unavailable"), resourceExhausted(t, time.Millisecond)},
+			wantCode:  codes.OK,
+			wantCalls: 3,
+		},
+		{
+			name:      "resource exhausted without retry info",
+			errs:      []error{status.Error(codes.ResourceExhausted, "exhausted")},
+			wantCode:  codes.ResourceExhausted,
+			wantCalls: 1,
+		},
+		{
+			name:      "no more attempts",
+			errs:      []error{status.Error(codes.Unavailable, "1"), status.Error(codes.Unavailable, "2"), status.Error(codes.Aborted, "3")},
+			wantCode:  codes.Aborted,
+			wantCalls: 3,
+		},
+		{
+			name:      "not retryable",
+			errs:      []error{status.Error(codes.InvalidArgument, "invalid")},
+			wantCode:  codes.InvalidArgument,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &healthServer{errs: tt.errs}
+			resp, err := newTestHealthClient(t, testConfig(), srv).Check(context.Background(), &healthpb.HealthCheckRequest{})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+			}
+			assert.Equal(t, tt.wantCalls, srv.numCalls())
+		})
+	}
+}
+
+func TestGRPCHedging(t *testing.T) {
+	srv := &healthServer{slow: map[int]bool{0: true}}
+	cfg := testConfig()
+	cfg.Retry.Enabled = false
+	cfg.Hedging = HedgingConfig{Enabled: true, Delay: 10 * time.Millisecond, MaxAttempts: 3}
+
+	resp, err := newTestHealthClient(t, cfg, srv).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	assert.Equal(t, 2, srv.numCalls())
+}
+
+func TestGRPCCircuitBreaker(t *testing.T) {
+	srv := &healthServer{errs: []error{status.Error(codes.Unavailable, "1"), status.Error(codes.Unavailable, "2")}}
+	cfg := testConfig()
+	cfg.Retry.Enabled = false
+	cfg.CircuitBreaker = CircuitBreakerConfig{Enabled: true, FailureThreshold: 2, OpenDuration: time.Minute}
+	client := newTestHealthClient(t, cfg, srv)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Equal(t, status.Error(codes.Unavailable, "circuit breaker is open"), err)
+	assert.Equal(t, 2, srv.numCalls())
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension // import "go.opentelemetry.io/collector/extension/resilienceextension"
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type roundTripper struct {
+	executor *executor
+	base     http.RoundTripper
+}
+
+// RoundTrip sends the request with the retries or the hedged requests of the configuration. The requests
+// whose body cannot be sent again, without GetBody, are sent once.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	retry := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	return execute[*http.Response](req.Context(), rt.executor, &httpCall{base: rt.base, req: req}, retry)
+}
+
+type httpCall struct {
+	base http.RoundTripper
+	req  *http.Request
+}
+
+func (c *httpCall) attempt(ctx context.Context, n int) result[*http.Response] {
+	req := c.req.Clone(ctx)
+	if n > 0 && c.req.GetBody != nil {
+		body, err := c.req.GetBody()
+		if err != nil {
+			return result[*http.Response]{err: err, outcome: outcomeCanceled}
+		}
+		req.Body = body
+	}
+
+	resp, err := c.base.RoundTrip(req)
+	switch {
+	case err != nil:
+		if ctx.Err() != nil {
+			return result[*http.Response]{err: err, outcome: outcomeCanceled}
+		}
+		return result[*http.Response]{err: err, outcome: outcomeFailure}
+	case isRetryableStatus(resp.StatusCode):
+		return result[*http.Response]{value: resp, outcome: outcomeFailure, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	default:
+		return result[*http.Response]{value: resp, outcome: outcomeSuccess}
+	}
+}
+
+func (c *httpCall) discard(resp *http.Response) {
+	if resp != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+}
+
+func (c *httpCall) keep(resp *http.Response, cancel context.CancelFunc) *http.Response {
+	if resp == nil {
+		cancel()
+		return nil
+	}
+	// The body is read with the context of the attempt, it is canceled once the body is closed.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp
+}
+
+func (c *httpCall) rejected() error {
+	return errCircuitOpen
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// isRetryableStatus returns true for the statuses indicating that the server is unavailable or overloaded.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(0, seconds)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(date))
+	}
+	return 0
+}
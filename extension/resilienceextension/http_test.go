@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resilienceextension
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Retry.InitialInterval = time.Millisecond
+	cfg.Retry.MaxInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func newTestClient(t *testing.T, cfg *Config) *http.Client {
+	r := newResilience(cfg, zap.NewNop())
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+	rt, err := r.GetHTTPRoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	return &http.Client{Transport: rt}
+}
+
+// statusServer answers with the given statuses, then with 200, and records the bodies of the requests.
+func statusServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "payload", string(body))
+		n := int(requests.Add(1)) - 1
+		if n < len(statuses) {
+			w.WriteHeader(statuses[n])
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func post(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader([]byte("payload")))
+	require.NoError(t, err)
+	return client.Do(req)
+}
+
+func TestHTTPRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatus   int
+		wantRequests int32
+	}{
+		{
+			name:         "success",
+			wantStatus:   http.StatusOK,
+			wantRequests: 1,
+		},
+		{
+			name:         "retried",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantStatus:   http.StatusOK,
+			wantRequests: 3,
+		},
+		{
+			name:         "no more attempts",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusGatewayTimeout},
+			wantStatus:   http.StatusGatewayTimeout,
+			wantRequests: 3,
+		},
+		{
+			name:         "not retryable",
+			statuses:     []int{http.StatusBadRequest},
+			wantStatus:   http.StatusBadRequest,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := statusServer(t, tt.statuses...)
+			resp, err := post(t, newTestClient(t, testConfig()), srv.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantRequests, requests.Load())
+		})
+	}
+}
+
+func TestHTTPRetryAfter(t *testing.T) {
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		times = append(times, time.Now())
+		if len(times) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	resp, err := post(t, newTestClient(t, cfg), srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, times, 2)
+	assert.GreaterOrEqual(t, times[1].Sub(times[0]), time.Second)
+
+	// The delay requested by the server is not waited beyond the maximum elapsed time.
+	times = nil
+	cfg.Retry.MaxElapsedTime = 100 * time.Millisecond
+	resp, err = post(t, newTestClient(t, cfg), srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Len(t, times, 1)
+}
+
+func TestHTTPBodyNotReplayable(t *testing.T) {
+	srv, requests := statusServer(t, http.StatusServiceUnavailable)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("payload")))
+	require.NoError(t, err)
+	require.Nil(t, req.GetBody)
+
+	resp, err := newTestClient(t, testConfig()).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestHTTPHedging(t *testing.T) {
+	var requests atomic.Int32
+	canceled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if requests.Add(1) == 1 {
+			// The first attempt is slow, it is canceled once the hedged attempt succeeds.
+			<-r.Context().Done()
+			close(canceled)
+			return
+		}
+		_, _ = w.Write([]byte("hedged"))
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.Retry.Enabled = false
+	cfg.Hedging = HedgingConfig{Enabled: true, Delay: 10 * time.Millisecond, MaxAttempts: 2}
+	resp, err := post(t, newTestClient(t, cfg), srv.URL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "hedged", string(body))
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first attempt was not canceled")
+	}
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestHTTPHedgingFailures(t *testing.T) {
+	srv, requests := statusServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	cfg := testConfig()
+	cfg.Retry.Enabled = false
+	cfg.Hedging = HedgingConfig{Enabled: true, Delay: time.Minute, MaxAttempts: 3}
+	// The failed attempts are replaced at once, the last failure is returned.
+	resp, err := post(t, newTestClient(t, cfg), srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestHTTPCircuitBreaker(t *testing.T) {
+	srv, requests := statusServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	cfg := testConfig()
+	cfg.Retry.MaxAttempts = 5
+	cfg.CircuitBreaker = CircuitBreakerConfig{Enabled: true, FailureThreshold: 2, OpenDuration: time.Minute}
+	client := newTestClient(t, cfg)
+
+	// The retries stop once the circuit breaker opens.
+	resp, err := post(t, client, srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+
+	_, err = post(t, client, srv.URL)
+	require.ErrorIs(t, err, errCircuitOpen)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestHTTPCircuitBreakerOpenedDuringBackoff(t *testing.T) {
+	var requests atomic.Int32
+	firstFailed := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		n := requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("unavailable"))
+		if n == 1 {
+			close(firstFailed)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.Retry.InitialInterval = 200 * time.Millisecond
+	cfg.Retry.RandomizationFactor = 0
+	cfg.CircuitBreaker = CircuitBreakerConfig{Enabled: true, FailureThreshold: 2, OpenDuration: time.Minute}
+	client := newTestClient(t, cfg)
+
+	type response struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan response, 1)
+	go func() {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, bytes.NewReader([]byte("payload")))
+		if !assert.NoError(t, err) {
+			done <- response{err: err}
+			return
+		}
+		resp, err := client.Do(req)
+		done <- response{resp: resp, err: err}
+	}()
+
+	// Another request opens the circuit breaker while the first one waits before its retry.
+	<-firstFailed
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("payload")))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// The retry is rejected, the response of the previous attempt is returned with its body.
+	r := <-done
+	require.NoError(t, r.err)
+	body, err := io.ReadAll(r.resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, r.resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, r.resp.StatusCode)
+	assert.Equal(t, "unavailable", string(body))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+	assert.InDelta(t, time.Hour, parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)), float64(2*time.Second))
+}
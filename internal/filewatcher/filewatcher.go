@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filewatcher watches a file for changes, e.g. the credentials files of the auth extensions.
+package filewatcher // import "go.opentelemetry.io/collector/internal/filewatcher"
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// DebounceDelay is the time to wait after the last change before reading the file again.
+// It can be lowered in tests.
+var DebounceDelay = 100 * time.Millisecond
+
+// Watcher calls onChange with the new content of the file every time it changes.
+//
+// The parent directories of the file and of its symlink target are watched instead of the file itself,
+// so that files replaced by a rename or through a symlink swap, as Kubernetes does when a Secret is
+// updated, are detected.
+type Watcher struct {
+	path     string
+	content  []byte
+	onChange func(content []byte)
+	logger   *zap.Logger
+	delay    time.Duration
+
+	watcher *fsnotify.Watcher
+	dirs    map[string]bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New starts watching the file at the given path, whose current content is given.
+// The watcher must be closed with Close.
+func New(path string, content []byte, onChange func([]byte), logger *zap.Logger) (*Watcher, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	fw := &Watcher{
+		path:     path,
+		content:  content,
+		onChange: onChange,
+		logger:   logger,
+		delay:    DebounceDelay,
+		watcher:  watcher,
+		dirs:     map[string]bool{},
+		done:     make(chan struct{}),
+	}
+	if err = fw.watchDirs(); err != nil {
+		return nil, errors.Join(err, watcher.Close())
+	}
+
+	fw.wg.Add(1)
+	go fw.run()
+	return fw, nil
+}
+
+// watchDirs watches the parent directories of the file and of its symlink target.
+func (fw *Watcher) watchDirs() error {
+	dirs := []string{filepath.Dir(fw.path)}
+	if realPath, err := filepath.EvalSymlinks(fw.path); err == nil {
+		dirs = append(dirs, filepath.Dir(realPath))
+	}
+	for _, dir := range dirs {
+		if fw.dirs[dir] {
+			continue
+		}
+		if err := fw.watcher.Add(dir); err != nil {
+			return err
+		}
+		fw.dirs[dir] = true
+	}
+	return nil
+}
+
+func (fw *Watcher) run() {
+	defer fw.wg.Done()
+	debounce := time.NewTimer(fw.delay)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-fw.done:
+			return
+		case _, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+			// Any change in the directories may change the content of the file, e.g. a symlink swap.
+			debounce.Reset(fw.delay)
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+			fw.logger.Warn("Failed to watch the file", zap.String("filename", fw.path), zap.Error(err))
+			debounce.Reset(fw.delay)
+		case <-debounce.C:
+			if err := fw.watchDirs(); err != nil {
+				fw.logger.Warn("Failed to watch the file", zap.String("filename", fw.path), zap.Error(err))
+			}
+			content, err := os.ReadFile(fw.path)
+			if err != nil {
+				// The file may be missing while it is being replaced, the next event will read it again.
+				fw.logger.Warn("Failed to read the file, the previous content is kept", zap.String("filename", fw.path), zap.Error(err))
+				continue
+			}
+			if string(content) != string(fw.content) {
+				fw.content = content
+				fw.onChange(content)
+			}
+		}
+	}
+}
+
+// Close stops watching the file.
+func (fw *Watcher) Close() error {
+	close(fw.done)
+	err := fw.watcher.Close()
+	fw.wg.Wait()
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filewatcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func init() {
+	DebounceDelay = 10 * time.Millisecond
+}
+
+func startWatcher(t *testing.T, path string) <-chan string {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	changes := make(chan string, 10)
+	w, err := New(path, content, func(content []byte) {
+		changes <- string(content)
+	}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, w.Close())
+	})
+	return changes
+}
+
+func requireChange(t *testing.T, changes <-chan string, expected string) {
+	select {
+	case content := <-changes:
+		require.Equal(t, expected, content)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change")
+	}
+}
+
+func TestWatcherRename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+	changes := startWatcher(t, path)
+
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte("second"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+	requireChange(t, changes, "second")
+
+	// The watcher is not notified while the file is missing, nor when the content is unchanged.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, changes)
+}
+
+func TestWatcherSymlinkSwap(t *testing.T) {
+	// The layout of a Kubernetes Secret volume: the file is a symlink to a directory that is swapped.
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "v1"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v1", "file"), []byte("first"), 0o600))
+	require.NoError(t, os.Symlink("v1", filepath.Join(dir, "data")))
+	require.NoError(t, os.Symlink(filepath.Join("data", "file"), filepath.Join(dir, "file")))
+	changes := startWatcher(t, filepath.Join(dir, "file"))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "v2"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v2", "file"), []byte("second"), 0o600))
+	require.NoError(t, os.Symlink("v2", filepath.Join(dir, "data.tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "data.tmp"), filepath.Join(dir, "data")))
+	requireChange(t, changes, "second")
+}
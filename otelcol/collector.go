@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync/atomic"
 	"syscall"
 
@@ -22,10 +23,19 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
 	"go.opentelemetry.io/collector/service"
 )
 
+var hotReloadPipelinesFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"otelcol.hotReloadPipelines",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.126.0"),
+	featuregate.WithRegisterDescription("if set to true, only the pipeline components that changed are restarted when "+
+		"the configuration is updated, as long as the telemetry and the extensions are unchanged"),
+)
+
 // State defines Collector's state.
 type State int
 
@@ -99,6 +109,8 @@ type Collector struct {
 
 	configProvider *ConfigProvider
 
+	// config is the configuration of the running service.
+	config        *Config
 	serviceConfig *service.Config
 	service       *service.Service
 	state         *atomic.Int64
@@ -173,28 +185,80 @@ func buildModuleInfo(m map[component.Type]string) map[component.Type]service.Mod
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	col.setCollectorState(StateStarting)
 
+	cfg, factories, err := col.loadConfiguration(ctx)
+	if err != nil {
+		return err
+	}
+	return col.setupService(ctx, cfg, factories)
+}
+
+// setupService creates the service for the loaded configuration and starts it. If all the steps succeeds it
+// sets the col.service with the service currently running.
+func (col *Collector) setupService(ctx context.Context, cfg *Config, factories Factories) error {
+	col.setCollectorState(StateStarting)
+
+	col.serviceConfig = &cfg.Service
+	col.config = cfg
+
+	set, err := col.serviceSettings(cfg, factories)
+	if err != nil {
+		return err
+	}
+
+	col.service, err = service.New(ctx, set, cfg.Service)
+	if err != nil {
+		return err
+	}
+	if col.updateConfigProviderLogger != nil {
+		col.updateConfigProviderLogger(col.service.Logger().Core())
+	}
+	if col.bc != nil {
+		x := col.bc.TakeLogs()
+		for _, log := range x {
+			ce := col.service.Logger().Core().Check(log.Entry, nil)
+			if ce != nil {
+				ce.Write(log.Context...)
+			}
+		}
+	}
+
+	if !col.set.SkipSettingGRPCLogger {
+		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
+	}
+
+	if err = col.service.Start(ctx); err != nil {
+		return multierr.Combine(err, col.service.Shutdown(ctx))
+	}
+	col.setCollectorState(StateRunning)
+
+	return nil
+}
+
+// loadConfiguration retrieves and validates the current configuration.
+func (col *Collector) loadConfiguration(ctx context.Context) (*Config, Factories, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return nil, Factories{}, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 	cfg, err := col.configProvider.Get(ctx, factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return nil, Factories{}, fmt.Errorf("failed to get config: %w", err)
 	}
 
 	if err = xconfmap.Validate(cfg); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return nil, Factories{}, fmt.Errorf("invalid configuration: %w", err)
 	}
+	return cfg, factories, nil
+}
 
-	col.serviceConfig = &cfg.Service
-
+// serviceSettings returns the settings to create the service for the given configuration.
+func (col *Collector) serviceSettings(cfg *Config, factories Factories) (service.Settings, error) {
 	conf := confmap.New()
-
-	if err = conf.Marshal(cfg); err != nil {
-		return fmt.Errorf("could not marshal configuration: %w", err)
+	if err := conf.Marshal(cfg); err != nil {
+		return service.Settings{}, fmt.Errorf("could not marshal configuration: %w", err)
 	}
 
-	col.service, err = service.New(ctx, service.Settings{
+	return service.Settings{
 		BuildInfo:     col.set.BuildInfo,
 		CollectorConf: conf,
 
@@ -218,36 +282,16 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		},
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
-	}, cfg.Service)
-	if err != nil {
-		return err
-	}
-	if col.updateConfigProviderLogger != nil {
-		col.updateConfigProviderLogger(col.service.Logger().Core())
-	}
-	if col.bc != nil {
-		x := col.bc.TakeLogs()
-		for _, log := range x {
-			ce := col.service.Logger().Core().Check(log.Entry, nil)
-			if ce != nil {
-				ce.Write(log.Context...)
-			}
-		}
-	}
-
-	if !col.set.SkipSettingGRPCLogger {
-		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
-	}
-
-	if err = col.service.Start(ctx); err != nil {
-		return multierr.Combine(err, col.service.Shutdown(ctx))
-	}
-	col.setCollectorState(StateRunning)
-
-	return nil
+	}, nil
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
+	// The configuration is resolved once, and reused to restart the service if the pipelines cannot be reloaded.
+	cfg, factories, loadErr := col.loadConfiguration(ctx)
+	if loadErr == nil && hotReloadPipelinesFeatureGate.IsEnabled() && col.reloadPipelines(ctx, cfg, factories) {
+		return nil
+	}
+
 	col.service.Logger().Warn("Config updated, restart service")
 	col.setCollectorState(StateClosing)
 
@@ -255,13 +299,46 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
 	}
 
-	if err := col.setupConfigurationComponents(ctx); err != nil {
+	if loadErr != nil {
+		col.setCollectorState(StateStarting)
+		return fmt.Errorf("failed to setup configuration components: %w", loadErr)
+	}
+	if err := col.setupService(ctx, cfg, factories); err != nil {
 		return fmt.Errorf("failed to setup configuration components: %w", err)
 	}
 
 	return nil
 }
 
+// reloadPipelines reloads only the pipeline components that changed in the new configuration, and reports
+// whether it succeeded. The service must be restarted if it returns false.
+func (col *Collector) reloadPipelines(ctx context.Context, cfg *Config, factories Factories) bool {
+	logger := col.service.Logger()
+	if col.config == nil {
+		return false
+	}
+	if !reflect.DeepEqual(col.config.Service.Telemetry, cfg.Service.Telemetry) ||
+		!reflect.DeepEqual(col.config.Service.Extensions, cfg.Service.Extensions) ||
+		!reflect.DeepEqual(col.config.Extensions, cfg.Extensions) {
+		logger.Info("Config updated, telemetry or extensions changed")
+		return false
+	}
+
+	set, err := col.serviceSettings(cfg, factories)
+	if err != nil {
+		return false
+	}
+	logger.Info("Config updated, reload pipelines")
+	if err = col.service.ReloadPipelines(ctx, set, cfg.Service); err != nil {
+		logger.Warn("Failed to reload pipelines", zap.Error(err))
+		return false
+	}
+
+	col.serviceConfig = &cfg.Service
+	col.config = cfg
+	return true
+}
+
 func (col *Collector) DryRun(ctx context.Context) error {
 	factories, err := col.set.Factories()
 	if err != nil {
@@ -17,12 +17,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	yaml "sigs.k8s.io/yaml/goyaml.v3"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -116,6 +118,95 @@ func TestCollectorStateAfterConfigChange(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+func TestCollectorHotReloadPipelines(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(hotReloadPipelinesFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(hotReloadPipelinesFeatureGate.ID(), false))
+	}()
+
+	var mu sync.Mutex
+	var watcher confmap.WatcherFunc
+	update := func(map[string]any) {}
+	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		watcher = w
+		conf := newConfFromFile(t, uri[5:])
+		update(conf)
+		return confmap.NewRetrieved(conf)
+	})
+
+	var messages []string
+	hasMessage := func(msg string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, m := range messages {
+			if m == msg {
+				return true
+			}
+		}
+		return false
+	}
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: nopFactories,
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{filepath.Join("testdata", "otelcol-nop.yaml")},
+				ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			},
+		},
+		LoggingOptions: []zap.Option{zap.Hooks(func(entry zapcore.Entry) error {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, entry.Message)
+			return nil
+		})},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// Removing a pipeline only reloads the pipelines.
+	mu.Lock()
+	update = func(conf map[string]any) {
+		delete(conf["service"].(map[string]any)["pipelines"].(map[string]any), "metrics")
+	}
+	w := watcher
+	mu.Unlock()
+	w(&confmap.ChangeEvent{})
+
+	assert.Eventually(t, func() bool {
+		return hasMessage("Pipelines reloaded.")
+	}, 2*time.Second, 200*time.Millisecond)
+	assert.False(t, hasMessage("Config updated, restart service"))
+
+	// Changing the extensions restarts the service.
+	mu.Lock()
+	update = func(conf map[string]any) {
+		conf["service"].(map[string]any)["extensions"] = []any{}
+	}
+	w = watcher
+	mu.Unlock()
+	w(&confmap.ChangeEvent{})
+
+	assert.Eventually(t, func() bool {
+		return hasMessage("Config updated, restart service")
+	}, 2*time.Second, 200*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	col.Shutdown()
+
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
 func TestCollectorReportError(t *testing.T) {
 	col, err := NewCollector(CollectorSettings{
 		BuildInfo:              component.NewDefaultBuildInfo(),
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "sigs.k8s.io/yaml/goyaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeyRegexp matches the keys whose values are redacted by the explain command.
+var sensitiveKeyRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|credential|authorization|bearer|cookie|private_?key|key_pem|^headers$)`)
+
+// newConfigSubCommand constructs a new config sub command using the given CollectorSettings.
+func newConfigSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspects the Collector's configuration",
+		Args:  cobra.ExactArgs(0),
+	}
+	cmd.AddCommand(newConfigExplainSubCommand(set, flagSet))
+	cmd.AddCommand(newConfigSchemaSubCommand(set))
+	return cmd
+}
+
+// newConfigExplainSubCommand constructs a new config explain sub command using the given CollectorSettings.
+func newConfigExplainSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Prints the Collector's configuration in YAML format annotated with the origin of every value",
+		Long: `Prints the Collector's configuration in YAML format after all config sources are resolved and merged.
+Every value is annotated with the config source and line that set it, the references expanded in it, and the values it overrides from previous config sources.
+The values of sensitive keys and the values set by an expanded reference are redacted.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := updateSettingsUsingFlags(&set, flagSet)
+			if err != nil {
+				return err
+			}
+			resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+			if err != nil {
+				return fmt.Errorf("failed to create new resolver: %w", err)
+			}
+			defer func() {
+				_ = resolver.Shutdown(cmd.Context())
+			}()
+			conf, err := resolver.Resolve(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("error while resolving config: %w", err)
+			}
+			b, err := explainConfig(conf, resolver.Origins())
+			if err != nil {
+				return fmt.Errorf("error while marshaling to YAML: %w", err)
+			}
+			_, err = cmd.OutOrStdout().Write(b)
+			return err
+		},
+	}
+	cmd.Flags().AddGoFlagSet(flagSet)
+	return cmd
+}
+
+// newConfigSchemaSubCommand constructs a new config schema sub command using the given CollectorSettings.
+func newConfigSchemaSubCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the Collector's configuration",
+		Long: `Prints the JSON Schema of the Collector's configuration, with the configuration of every component of the distribution.
+The schema is generated from the config structs of the components, with their default configuration as default values.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+			b, err := json.MarshalIndent(configSchema(set.BuildInfo, factories), "", "  ")
+			if err != nil {
+				return fmt.Errorf("error while marshaling to JSON: %w", err)
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", b)
+			return err
+		},
+	}
+}
+
+// configSchema returns the JSON Schema of the configuration of a distribution with the given factories.
+// The schema of every component is defined once in "$defs", e.g. "#/$defs/receivers.otlp".
+func configSchema(info component.BuildInfo, factories Factories) *xconfmap.Schema {
+	schema := &xconfmap.Schema{
+		Schema:               xconfmap.SchemaDraft,
+		Type:                 xconfmap.SchemaType{"object"},
+		Properties:           map[string]*xconfmap.Schema{},
+		AdditionalProperties: xconfmap.FalseSchema(),
+		Defs:                 map[string]*xconfmap.Schema{},
+	}
+	if info.Description != "" {
+		schema.Title = info.Description
+	}
+	addComponentSchemas(schema, "receivers", factories.Receivers)
+	addComponentSchemas(schema, "processors", factories.Processors)
+	addComponentSchemas(schema, "exporters", factories.Exporters)
+	addComponentSchemas(schema, "connectors", factories.Connectors)
+	addComponentSchemas(schema, "extensions", factories.Extensions)
+
+	telConfig := telemetry.NewFactory().CreateDefaultConfig().(*telemetry.Config)
+	schema.Properties["service"] = xconfmap.NewSchema(&service.Config{Telemetry: *telConfig})
+	return schema
+}
+
+// addComponentSchemas adds the property of the given kind of components, e.g. "receivers", accepting the ID of
+// every component of that kind, with the schema of its configuration.
+func addComponentSchemas[F component.Factory](schema *xconfmap.Schema, kind string, factories map[component.Type]F) {
+	property := &xconfmap.Schema{
+		Type:                 xconfmap.SchemaType{"object", "null"},
+		PatternProperties:    map[string]*xconfmap.Schema{},
+		AdditionalProperties: xconfmap.FalseSchema(),
+	}
+	types := make([]component.Type, 0, len(factories))
+	for typ := range factories {
+		types = append(types, typ)
+	}
+	slices.SortFunc(types, func(a, b component.Type) int { return strings.Compare(a.String(), b.String()) })
+	for _, typ := range types {
+		def := kind + "." + typ.String()
+		schema.Defs[def] = xconfmap.NewSchema(factories[typ].CreateDefaultConfig())
+		// The ID of a component is its type, optionally followed by a name, e.g. "otlp/2".
+		property.PatternProperties["^"+regexp.QuoteMeta(typ.String())+"(/.+)?$"] = &xconfmap.Schema{Ref: "#/$defs/" + def}
+	}
+	schema.Properties[kind] = property
+}
+
+// explainConfig marshals the configuration to YAML, with a comment describing the origin of every value.
+func explainConfig(conf *confmap.Conf, origins map[string]confmap.Origin) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(conf.ToStringMap()); err != nil {
+		return nil, err
+	}
+	annotateNode(&root, "", origins, nil, false)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// annotateNode adds the origin of the values of the given mapping node as comments, and redacts the sensitive values.
+// The keys without origin, e.g. the keys of a map set by an expanded reference, inherit the origin of their parent.
+// The maps in sequences are annotated with the prefix and the origin of the sequence.
+func annotateNode(node *yaml.Node, prefix string, origins map[string]confmap.Origin, parent *confmap.Origin, redact bool) {
+	if node.Kind == yaml.DocumentNode || node.Kind == yaml.SequenceNode {
+		for _, n := range node.Content {
+			annotateNode(n, prefix, origins, parent, redact)
+		}
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + confmap.KeyDelimiter + key
+		}
+		origin := parent
+		if o, ok := origins[key]; ok {
+			origin = &o
+		}
+		sensitive := redact || sensitiveKeyRegexp.MatchString(keyNode.Value) || (origin != nil && len(origin.Expanded) > 0)
+
+		if valueNode.Kind == yaml.MappingNode && len(valueNode.Content) > 0 {
+			annotateNode(valueNode, key, origins, origin, sensitive)
+			if origin != parent && origin != nil {
+				keyNode.LineComment = formatOrigin(*origin)
+			}
+			continue
+		}
+
+		if valueNode.Kind == yaml.SequenceNode && !sensitive {
+			annotateNode(valueNode, key, origins, origin, sensitive)
+		}
+		if sensitive && valueNode.Tag != "!!null" {
+			*valueNode = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: redacted}
+		}
+		if origin == nil {
+			continue
+		}
+		if valueNode.Kind == yaml.ScalarNode {
+			valueNode.LineComment = formatOrigin(*origin)
+		} else {
+			keyNode.LineComment = formatOrigin(*origin)
+		}
+	}
+}
+
+func formatOrigin(origin confmap.Origin) string {
+	parts := []string{formatLocation(origin)}
+	if len(origin.Expanded) > 0 {
+		expanded := make([]string, 0, len(origin.Expanded))
+		for _, uri := range origin.Expanded {
+			expanded = append(expanded, formatSource(uri))
+		}
+		parts = append(parts, "expanded from "+strings.Join(expanded, ", "))
+	}
+	if len(origin.Overrides) > 0 {
+		overrides := make([]string, 0, len(origin.Overrides))
+		for _, o := range origin.Overrides {
+			overrides = append(overrides, formatLocation(o))
+		}
+		parts = append(parts, "overrides "+strings.Join(overrides, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatLocation(origin confmap.Origin) string {
+	source := formatSource(origin.URI)
+	if origin.Line == 0 {
+		return source
+	}
+	return source + " line " + strconv.Itoa(origin.Line)
+}
+
+// formatSource returns the name of the source of a value, without the parts of its URI that may hold secrets:
+// the URIs of the "yaml" scheme, e.g. from the --set flag, hold the value itself and are replaced by the scheme,
+// and the credentials and the query are removed from the http and https URIs.
+func formatSource(uri string) string {
+	scheme, _, _ := strings.Cut(uri, ":")
+	switch scheme {
+	case "yaml":
+		return scheme
+	case "http", "https":
+		u, err := url.Parse(uri)
+		if err != nil {
+			return scheme
+		}
+		u.User = nil
+		u.RawQuery = ""
+		u.Fragment = ""
+		return u.String()
+	default:
+		return uri
+	}
+}
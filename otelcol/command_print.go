@@ -4,9 +4,12 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	yaml "sigs.k8s.io/yaml/goyaml.v3"
@@ -19,40 +22,129 @@ var printCommandFeatureFlag = featuregate.GlobalRegistry().MustRegister(
 	"otelcol.printInitialConfig",
 	featuregate.StageAlpha,
 	featuregate.WithRegisterFromVersion("v0.120.0"),
-	featuregate.WithRegisterDescription("if set to true, turns on the print-initial-config command"),
+	featuregate.WithRegisterDescription("if set to true, turns on the print-config command"),
+)
+
+const (
+	printModeRaw      = "raw"
+	printModeRedacted = "redacted"
+
+	printFormatYAML = "yaml"
+	printFormatJSON = "json"
 )
 
 // newConfigPrintSubCommand constructs a new config print sub command using the given CollectorSettings.
 func newConfigPrintSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var mode, format string
 	cmd := &cobra.Command{
-		Use:   "print-initial-config",
-		Short: "Prints the Collector's configuration in YAML format after all config sources are resolved and merged",
-		Long:  `Note: This command prints the final yaml configuration before it is unmarshaled into config structs, which may contain sensitive values.`,
-		Args:  cobra.ExactArgs(0),
+		Use:     "print-config",
+		Aliases: []string{"print-initial-config"},
+		Short:   "Prints the Collector's configuration after all config sources are resolved and merged",
+		Long: `Prints the Collector's configuration after all config sources are resolved and merged.
+
+In the raw mode, the default, the configuration is printed before it is unmarshaled into config structs, which may contain sensitive values.
+In the redacted mode, the configuration is unmarshaled into the config structs of the components, with their default values applied,
+and every configopaque.String value is printed as [REDACTED].`,
+		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !printCommandFeatureFlag.IsEnabled() {
-				return errors.New("print-initial-config is currently experimental, use the otelcol.printInitialConfig feature gate to enable this command")
+				return errors.New("print-config is currently experimental, use the otelcol.printInitialConfig feature gate to enable this command")
+			}
+			if format != printFormatYAML && format != printFormatJSON {
+				return fmt.Errorf("invalid format %q, must be %q or %q", format, printFormatYAML, printFormatJSON)
 			}
 			err := updateSettingsUsingFlags(&set, flagSet)
 			if err != nil {
 				return err
 			}
-			resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
-			if err != nil {
-				return fmt.Errorf("failed to create new resolver: %w", err)
+
+			var conf *confmap.Conf
+			switch mode {
+			case printModeRaw:
+				conf, err = rawConfig(cmd.Context(), set)
+			case printModeRedacted:
+				conf, err = redactedConfig(cmd.Context(), set)
+			default:
+				return fmt.Errorf("invalid mode %q, must be %q or %q", mode, printModeRaw, printModeRedacted)
 			}
-			conf, err := resolver.Resolve(cmd.Context())
 			if err != nil {
-				return fmt.Errorf("error while resolving config: %w", err)
+				return err
+			}
+
+			var b []byte
+			if format == printFormatJSON {
+				b, err = json.MarshalIndent(durationsToStrings(conf.ToStringMap()), "", "  ")
+			} else {
+				b, err = yaml.Marshal(conf.ToStringMap())
 			}
-			b, err := yaml.Marshal(conf.ToStringMap())
 			if err != nil {
-				return fmt.Errorf("error while marshaling to YAML: %w", err)
+				return fmt.Errorf("error while marshaling to %s: %w", format, err)
 			}
-			fmt.Printf("%s\n", b)
-			return nil
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", b)
+			return err
 		},
 	}
+	cmd.Flags().StringVar(&mode, "mode", printModeRaw, fmt.Sprintf("Which configuration to print: %q or %q", printModeRaw, printModeRedacted))
+	cmd.Flags().StringVar(&format, "format", printFormatYAML, fmt.Sprintf("Output format: %q or %q", printFormatYAML, printFormatJSON))
 	cmd.Flags().AddGoFlagSet(flagSet)
 	return cmd
 }
+
+// rawConfig returns the configuration after all config sources are resolved and merged.
+func rawConfig(ctx context.Context, set CollectorSettings) (*confmap.Conf, error) {
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new resolver: %w", err)
+	}
+	defer func() {
+		_ = resolver.Shutdown(ctx)
+	}()
+	conf, err := resolver.Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while resolving config: %w", err)
+	}
+	return conf, nil
+}
+
+// redactedConfig returns the configuration unmarshaled into the config structs of the components, and marshaled back.
+// The sensitive values, stored in configopaque.String, are marshaled as [REDACTED].
+func redactedConfig(ctx context.Context, set CollectorSettings) (*confmap.Conf, error) {
+	factories, err := set.Factories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	configProvider, err := NewConfigProvider(set.ConfigProviderSettings)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = configProvider.Shutdown(ctx)
+	}()
+	cfg, err := configProvider.Get(ctx, factories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	conf := confmap.New()
+	if err = conf.Marshal(cfg); err != nil {
+		return nil, fmt.Errorf("could not marshal configuration: %w", err)
+	}
+	return conf, nil
+}
+
+// durationsToStrings formats the durations like the YAML encoder does, e.g. "5s", since encoding/json formats them
+// as a number of nanoseconds.
+func durationsToStrings(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, e := range val {
+			val[k] = durationsToStrings(e)
+		}
+	case []any:
+		for i, e := range val {
+			val[i] = durationsToStrings(e)
+		}
+	case time.Duration:
+		return val.String()
+	}
+	return v
+}
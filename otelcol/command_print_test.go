@@ -7,13 +7,18 @@ import (
 	"bytes"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	yaml "sigs.k8s.io/yaml/goyaml.v3"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -102,7 +107,7 @@ func TestPrintCommandFeaturegateDisabled(t *testing.T) {
 		},
 	}}, flags(featuregate.GlobalRegistry()))
 	err := cmd.Execute()
-	require.ErrorContains(t, err, "print-initial-config is currently experimental, use the otelcol.printInitialConfig feature gate to enable this command")
+	require.ErrorContains(t, err, "print-config is currently experimental, use the otelcol.printInitialConfig feature gate to enable this command")
 }
 
 func TestConfig(t *testing.T) {
@@ -177,3 +182,97 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+type secretConfig struct {
+	Endpoint string              `mapstructure:"endpoint"`
+	Token    configopaque.String `mapstructure:"token"`
+	Timeout  time.Duration       `mapstructure:"timeout"`
+}
+
+func secretFactories() (Factories, error) {
+	factories, err := nopFactories()
+	if err != nil {
+		return Factories{}, err
+	}
+	secretFactory := exporter.NewFactory(component.MustNewType("secret"), func() component.Config {
+		return &secretConfig{Endpoint: "localhost:4317", Timeout: 5 * time.Second}
+	})
+	factories.Exporters[secretFactory.Type()] = secretFactory
+	return factories, nil
+}
+
+func TestPrintCommandRedacted(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), false))
+	}()
+
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			set := CollectorSettings{
+				Factories: secretFactories,
+				ConfigProviderSettings: ConfigProviderSettings{
+					ResolverSettings: confmap.ResolverSettings{
+						URIs:              []string{"file:testdata/configs/print-config.yaml"},
+						ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+						DefaultScheme:     "file",
+					},
+				},
+			}
+			cmd := newConfigPrintSubCommand(set, flags(featuregate.GlobalRegistry()))
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{"--mode=redacted", "--format=" + format})
+			require.NoError(t, cmd.Execute())
+
+			// JSON is valid YAML.
+			var actual map[string]any
+			require.NoError(t, yaml.Unmarshal(out.Bytes(), &actual))
+			conf := confmap.NewFromStringMap(actual)
+			assert.Equal(t, "[REDACTED]", conf.Get("exporters::secret::token"))
+			assert.Equal(t, "localhost:4317", conf.Get("exporters::secret::endpoint"))
+			assert.Equal(t, "5s", conf.Get("exporters::secret::timeout"))
+			assert.Equal(t, []any{"secret"}, conf.Get("service::pipelines::traces::exporters"))
+			assert.True(t, conf.IsSet("service::telemetry::logs::level"))
+			assert.NotContains(t, out.String(), "my-token")
+		})
+	}
+}
+
+func TestPrintCommandInvalidFlags(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(printCommandFeatureFlag.ID(), false))
+	}()
+
+	tests := []struct {
+		args      []string
+		errString string
+	}{
+		{
+			args:      []string{"--mode=other"},
+			errString: `invalid mode "other", must be "raw" or "redacted"`,
+		},
+		{
+			args:      []string{"--format=toml"},
+			errString: `invalid format "toml", must be "yaml" or "json"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.args[0], func(t *testing.T) {
+			set := CollectorSettings{
+				Factories: nopFactories,
+				ConfigProviderSettings: ConfigProviderSettings{
+					ResolverSettings: confmap.ResolverSettings{
+						URIs:              []string{"file:testdata/configs/print-config.yaml"},
+						ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+						DefaultScheme:     "file",
+					},
+				},
+			}
+			cmd := newConfigPrintSubCommand(set, flags(featuregate.GlobalRegistry()))
+			cmd.SetArgs(test.args)
+			require.EqualError(t, cmd.Execute(), test.errString)
+		})
+	}
+}
@@ -32,6 +32,9 @@ func LoadConfig(fileName string, factories otelcol.Factories) (*otelcol.Config,
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = provider.Shutdown(context.Background())
+	}()
 	return provider.Get(context.Background(), factories)
 }
 
@@ -0,0 +1,394 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pdatagen/internal"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectorprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1development"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1development"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// jsonPackage is a protogen package of messages marshaled by the generated OTLP/JSON marshalers.
+type jsonPackage struct {
+	alias string
+	path  string
+	// file is the name of the generated file the marshalers of the package's messages are written to.
+	file string
+}
+
+const protogenPath = "go.opentelemetry.io/collector/pdata/internal/data/protogen/"
+
+var jsonPackages = []jsonPackage{
+	{alias: "otlpcommon", path: protogenPath + "common/v1", file: "common"},
+	{alias: "otlpresource", path: protogenPath + "resource/v1", file: "common"},
+	{alias: "otlptrace", path: protogenPath + "trace/v1", file: "traces"},
+	{alias: "otlpcollectortrace", path: protogenPath + "collector/trace/v1", file: "traces"},
+	{alias: "otlpmetrics", path: protogenPath + "metrics/v1", file: "metrics"},
+	{alias: "otlpcollectormetrics", path: protogenPath + "collector/metrics/v1", file: "metrics"},
+	{alias: "otlplogs", path: protogenPath + "logs/v1", file: "logs"},
+	{alias: "otlpcollectorlogs", path: protogenPath + "collector/logs/v1", file: "logs"},
+	{alias: "otlpprofiles", path: protogenPath + "profiles/v1development", file: "profiles"},
+	{alias: "otlpcollectorprofiles", path: protogenPath + "collector/profiles/v1development", file: "profiles"},
+}
+
+// jsonRoots are the messages with an exported Marshal function. The marshalers of all the messages they
+// contain are generated as well.
+var jsonRoots = []any{
+	otlptrace.TracesData{},
+	otlpcollectortrace.ExportTraceServiceRequest{},
+	otlpcollectortrace.ExportTraceServiceResponse{},
+	otlpmetrics.MetricsData{},
+	otlpcollectormetrics.ExportMetricsServiceRequest{},
+	otlpcollectormetrics.ExportMetricsServiceResponse{},
+	otlplogs.LogsData{},
+	otlpcollectorlogs.ExportLogsServiceRequest{},
+	otlpcollectorlogs.ExportLogsServiceResponse{},
+	otlpprofiles.ProfilesData{},
+	otlpcollectorprofiles.ExportProfilesServiceRequest{},
+	otlpcollectorprofiles.ExportProfilesServiceResponse{},
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// GenerateJSONMarshalers generates the OTLP/JSON marshalers of the jsonRoots messages in the internal/json package.
+// They write the same output as the jsonpb marshaler with EnumsAsInts set, without going through reflection.
+func GenerateJSONMarshalers() error {
+	g := &jsonGenerator{files: map[string]*jsonFile{}, writers: map[string]reflect.Type{}}
+	for _, root := range jsonRoots {
+		g.addRoot(reflect.TypeOf(root))
+	}
+	names := make([]string, 0, len(g.files))
+	for name := range g.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		src, err := g.files[name].generate()
+		if err != nil {
+			return err
+		}
+		path := filepath.Join("internal", "json", "generated_marshal_"+name+".go")
+		if err := os.WriteFile(path, src, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonFile struct {
+	hasRoots bool
+	imports  []jsonPackage
+	body     bytes.Buffer
+}
+
+func (f *jsonFile) generate() ([]byte, error) {
+	var sb bytes.Buffer
+	sb.WriteString(header + "\n\npackage json\n\nimport (\n")
+	if f.hasRoots {
+		sb.WriteString("\t\"io\"\n\n")
+	}
+	sb.WriteString("\tjsoniter \"github.com/json-iterator/go\"\n\n")
+	slices.SortFunc(f.imports, func(a, b jsonPackage) int { return strings.Compare(a.path, b.path) })
+	for _, p := range f.imports {
+		fmt.Fprintf(&sb, "\t%s %q\n", p.alias, p.path)
+	}
+	sb.WriteString(")\n")
+	sb.Write(f.body.Bytes())
+	return format.Source(sb.Bytes())
+}
+
+type jsonGenerator struct {
+	files map[string]*jsonFile
+	// writers are the message types of the generated write functions, by function name.
+	writers map[string]reflect.Type
+}
+
+func (g *jsonGenerator) pkg(t reflect.Type) jsonPackage {
+	for _, p := range jsonPackages {
+		if p.path == t.PkgPath() {
+			return p
+		}
+	}
+	panic(fmt.Sprintf("no JSON package for %v", t))
+}
+
+func (g *jsonGenerator) file(t reflect.Type) *jsonFile {
+	p := g.pkg(t)
+	f, ok := g.files[p.file]
+	if !ok {
+		f = &jsonFile{}
+		g.files[p.file] = f
+	}
+	if !slices.Contains(f.imports, p) {
+		f.imports = append(f.imports, p)
+	}
+	return f
+}
+
+func (g *jsonGenerator) typeName(t reflect.Type) string {
+	return g.pkg(t).alias + "." + t.Name()
+}
+
+func writerName(t reflect.Type) string {
+	return "write" + strings.ReplaceAll(t.Name(), "_", "")
+}
+
+func (g *jsonGenerator) addRoot(t reflect.Type) {
+	f := g.file(t)
+	f.hasRoots = true
+	fmt.Fprintf(&f.body, "\n// Marshal%[1]s writes the OTLP/JSON encoding of orig to out.\n"+
+		"func Marshal%[1]s(out io.Writer, orig *%[2]s) error {\n"+
+		"\treturn marshal(out, orig, %[3]s)\n}\n", t.Name(), g.typeName(t), writerName(t))
+	g.addMessage(t)
+}
+
+// addMessage generates the write function of the message type t, and of the messages it contains.
+func (g *jsonGenerator) addMessage(t reflect.Type) {
+	name := writerName(t)
+	if prev, ok := g.writers[name]; ok {
+		if prev != t {
+			panic(fmt.Sprintf("%s is the write function of both %v and %v", name, prev, t))
+		}
+		return
+	}
+	g.writers[name] = t
+
+	w := &jsonObjectWriter{g: g, state: noFields}
+	for i := 0; i < t.NumField(); i++ {
+		w.addField(t, t.Field(i))
+	}
+	f := g.file(t)
+	fmt.Fprintf(&f.body, "\nfunc %s(dest *jsoniter.Stream, orig *%s) {\n\tdest.WriteObjectStart()\n", name, g.typeName(t))
+	// The more variable is only set by the fields before the last one reading it.
+	body := w.body.String()
+	lastRead := max(strings.LastIndex(body, readMoreCode), 0)
+	body = strings.ReplaceAll(body[:lastRead], setMoreMarker, "more = true\n") + strings.ReplaceAll(body[lastRead:], setMoreMarker, "")
+	if lastRead != 0 {
+		f.body.WriteString("\tmore := false\n")
+	}
+	f.body.WriteString(body)
+	f.body.WriteString("\tdest.WriteObjectEnd()\n}\n")
+
+	for _, nested := range w.nested {
+		g.addMessage(nested)
+	}
+}
+
+// The states of a jsonObjectWriter, depending on the fields already written to the object.
+const (
+	noFields = iota
+	someFields
+	// unknownFields is the state after optional fields, where the more variable tells whether they were written.
+	unknownFields
+)
+
+// jsonObjectWriter generates the code writing the fields of a message, separated by commas.
+type jsonObjectWriter struct {
+	g      *jsonGenerator
+	body   strings.Builder
+	state  int
+	nested []reflect.Type
+}
+
+func (w *jsonObjectWriter) addField(t reflect.Type, f reflect.StructField) {
+	if strings.HasPrefix(f.Name, "XXX_") {
+		return
+	}
+	if f.Tag.Get("protobuf_oneof") != "" {
+		w.addOneOf(t, f)
+		return
+	}
+	tag := f.Tag.Get("protobuf")
+	if tag == "" {
+		return
+	}
+	expr := "orig." + f.Name
+	value := w.value(f.Type, expr, 1, true)
+	switch f.Type.Kind() {
+	case reflect.Bool:
+		w.optionalField(expr, jsonName(tag), value)
+	case reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Float64:
+		w.optionalField(expr+" != 0", jsonName(tag), value)
+	case reflect.String:
+		w.optionalField(expr+` != ""`, jsonName(tag), value)
+	case reflect.Ptr, reflect.Slice:
+		w.optionalField(expr+" != nil", jsonName(tag), value)
+	case reflect.Struct, reflect.Array:
+		w.separator(1)
+		w.fieldName(1, jsonName(tag))
+		w.body.WriteString(value)
+		w.state = someFields
+	default:
+		panic(fmt.Sprintf("unsupported kind of field %v.%s", t, f.Name))
+	}
+}
+
+// addOneOf writes the field of the value set in a oneof, if any. Like for the repeated fields, a zero value is
+// written as well.
+func (w *jsonObjectWriter) addOneOf(t reflect.Type, f reflect.StructField) {
+	m, ok := reflect.New(t).Interface().(interface{ XXX_OneofWrappers() []any })
+	if !ok {
+		panic(fmt.Sprintf("no oneof wrappers for %v", t))
+	}
+	fmt.Fprintf(&w.body, "\tswitch ov := orig.%s.(type) {\n", f.Name)
+	for _, wrapper := range m.XXX_OneofWrappers() {
+		wt := reflect.TypeOf(wrapper)
+		if !wt.Implements(f.Type) {
+			continue
+		}
+		vf := wt.Elem().Field(0)
+		fmt.Fprintf(&w.body, "\tcase *%s:\n", w.g.typeName(wt.Elem()))
+		w.separator(2)
+		w.fieldName(2, jsonName(vf.Tag.Get("protobuf")))
+		w.body.WriteString(w.value(vf.Type, "ov."+vf.Name, 2, false))
+		w.setMore()
+	}
+	w.body.WriteString("\t}\n")
+	w.endOptional()
+}
+
+func (w *jsonObjectWriter) optionalField(cond, name, value string) {
+	fmt.Fprintf(&w.body, "\tif %s {\n", cond)
+	w.separator(2)
+	w.fieldName(2, name)
+	w.body.WriteString(indent(value, 1))
+	w.setMore()
+	w.body.WriteString("\t}\n")
+	w.endOptional()
+}
+
+func (w *jsonObjectWriter) separator(depth int) {
+	switch w.state {
+	case someFields:
+		fmt.Fprintf(&w.body, "%sdest.WriteMore()\n", tabs(depth))
+	case unknownFields:
+		fmt.Fprintf(&w.body, "%s%s\n%[1]s\tdest.WriteMore()\n%[1]s}\n", tabs(depth), readMoreCode)
+	}
+}
+
+func (w *jsonObjectWriter) fieldName(depth int, name string) {
+	fmt.Fprintf(&w.body, "%sdest.WriteObjectField(%q)\n", tabs(depth), name)
+}
+
+const readMoreCode = "if more {"
+
+// setMoreMarker is replaced by the assignment of the more variable, or removed after the last field reading it.
+// It is indented by the formatting of the generated file.
+const setMoreMarker = "<set more>\n"
+
+func (w *jsonObjectWriter) setMore() {
+	if w.state != someFields {
+		w.body.WriteString(setMoreMarker)
+	}
+}
+
+func (w *jsonObjectWriter) endOptional() {
+	if w.state != someFields {
+		w.state = unknownFields
+	}
+}
+
+// value returns the code writing the value expr of type t, at the given indentation depth. Unless nonNil is set,
+// a nil message pointer is written as null.
+func (w *jsonObjectWriter) value(t reflect.Type, expr string, depth int, nonNil bool) string {
+	in := tabs(depth)
+	switch t.Kind() {
+	case reflect.Bool:
+		return fmt.Sprintf("%sdest.WriteBool(%s)\n", in, expr)
+	case reflect.Int32:
+		if t.PkgPath() != "" {
+			// Enums are written as numbers.
+			expr = "int32(" + expr + ")"
+		}
+		return fmt.Sprintf("%sdest.WriteInt32(%s)\n", in, expr)
+	case reflect.Uint32:
+		return fmt.Sprintf("%sdest.WriteUint32(%s)\n", in, expr)
+	case reflect.Int64:
+		return fmt.Sprintf("%swriteInt64(dest, %s)\n", in, expr)
+	case reflect.Uint64:
+		return fmt.Sprintf("%swriteUint64(dest, %s)\n", in, expr)
+	case reflect.Float64:
+		return fmt.Sprintf("%swriteFloat64(dest, %s)\n", in, expr)
+	case reflect.String:
+		return fmt.Sprintf("%swriteString(dest, %s)\n", in, expr)
+	case reflect.Array:
+		if t.Elem().Kind() != reflect.Uint8 || !t.Implements(jsonMarshalerType) {
+			panic(fmt.Sprintf("unsupported array type %v", t))
+		}
+		return fmt.Sprintf("%swriteID(dest, %s[:])\n", in, expr)
+	case reflect.Struct:
+		w.nested = append(w.nested, t)
+		return fmt.Sprintf("%s%s(dest, &%s)\n", in, writerName(t), expr)
+	case reflect.Ptr:
+		if t.Elem().Kind() != reflect.Struct {
+			panic(fmt.Sprintf("unsupported pointer type %v", t))
+		}
+		w.nested = append(w.nested, t.Elem())
+		if nonNil {
+			return fmt.Sprintf("%s%s(dest, %s)\n", in, writerName(t.Elem()), expr)
+		}
+		return fmt.Sprintf("%[1]sif %[2]s == nil {\n%[1]s\tdest.WriteNil()\n%[1]s} else {\n%[1]s\t%[3]s(dest, %[2]s)\n%[1]s}\n",
+			in, expr, writerName(t.Elem()))
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%swriteBytes(dest, %s)\n", in, expr)
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%sdest.WriteArrayStart()\n%[1]sfor i := range %s {\n%[1]s\tif i != 0 {\n%[1]s\t\tdest.WriteMore()\n%[1]s\t}\n", in, expr)
+		sb.WriteString(w.value(t.Elem(), expr+"[i]", depth+1, false))
+		if k := t.Elem().Kind(); k == reflect.Struct || k == reflect.Ptr {
+			fmt.Fprintf(&sb, "%s\tflushIfFull(dest)\n", in)
+		}
+		fmt.Fprintf(&sb, "%s}\n%[1]sdest.WriteArrayEnd()\n", in)
+		return sb.String()
+	default:
+		panic(fmt.Sprintf("unsupported type %v", t))
+	}
+}
+
+// jsonName returns the JSON name of a field from its protobuf struct tag.
+func jsonName(tag string) string {
+	name := ""
+	for _, part := range strings.Split(tag, ",") {
+		if v, ok := strings.CutPrefix(part, "json="); ok {
+			return v
+		}
+		if v, ok := strings.CutPrefix(part, "name="); ok {
+			name = v
+		}
+	}
+	if name == "" {
+		panic(fmt.Sprintf("no name in protobuf tag %q", tag))
+	}
+	return name
+}
+
+func tabs(depth int) string {
+	return strings.Repeat("\t", depth)
+}
+
+func indent(code string, depth int) string {
+	lines := strings.SplitAfter(code, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = tabs(depth) + line
+		}
+	}
+	return strings.Join(lines, "")
+}
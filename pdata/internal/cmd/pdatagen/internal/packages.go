@@ -33,6 +33,8 @@ type Package struct {
 	info *PackageInfo
 	// Can be any of sliceOfPtrs, sliceOfValues, messageValueStruct.
 	structs []baseStruct
+	// signal is set for the packages of a signal, to generate the functions splitting and merging its data.
+	signal *signal
 }
 
 type PackageInfo struct {
@@ -50,7 +52,7 @@ func (p *Package) GenerateFiles() error {
 			return err
 		}
 	}
-	return nil
+	return p.generateSignalFiles(splitTemplate, mergeTemplate)
 }
 
 // GenerateTestFiles generates files with tests for the configured data structures for this Package.
@@ -61,7 +63,7 @@ func (p *Package) GenerateTestFiles() error {
 			return err
 		}
 	}
-	return nil
+	return p.generateSignalFiles(splitTestTemplate, mergeTestTemplate)
 }
 
 // GenerateInternalFiles generates files with internal pdata structures for this Package.
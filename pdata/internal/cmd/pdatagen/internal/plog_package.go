@@ -35,6 +35,21 @@ var plog = &Package{
 		logSlice,
 		logRecord,
 	},
+	signal: &signal{
+		name:        "Logs",
+		varName:     "ld",
+		resource:    "ResourceLogs",
+		resourceVar: "rl",
+		scope:       "ScopeLogs",
+		scopeVar:    "sl",
+		item:        "LogRecord",
+		itemVar:     "lr",
+		items:       "LogRecords",
+		itemsDoc:    "log records",
+		countFunc:   "LogRecordCount",
+		setItemName: "Body().SetStr",
+		itemName:    "Body().Str",
+	},
 }
 
 var resourceLogsSlice = &sliceOfPtrs{
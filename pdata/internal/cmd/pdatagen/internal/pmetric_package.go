@@ -53,6 +53,22 @@ var pmetric = &Package{
 		exemplarSlice,
 		exemplar,
 	},
+	signal: &signal{
+		name:        "Metrics",
+		varName:     "md",
+		resource:    "ResourceMetrics",
+		resourceVar: "rm",
+		scope:       "ScopeMetrics",
+		scopeVar:    "sm",
+		item:        "Metric",
+		itemVar:     "m",
+		items:       "Metrics",
+		itemsDoc:    "metrics",
+		countFunc:   "MetricCount",
+		setItemName: "SetName",
+		itemName:    "Name",
+		mergeNote:   "Metrics with the same name are not merged.",
+	},
 }
 
 var resourceMetricsSlice = &sliceOfPtrs{
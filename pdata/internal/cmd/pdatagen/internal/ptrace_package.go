@@ -40,6 +40,21 @@ var ptrace = &Package{
 		spanLink,
 		spanStatus,
 	},
+	signal: &signal{
+		name:        "Traces",
+		varName:     "td",
+		resource:    "ResourceSpans",
+		resourceVar: "rs",
+		scope:       "ScopeSpans",
+		scopeVar:    "ss",
+		item:        "Span",
+		itemVar:     "span",
+		items:       "Spans",
+		itemsDoc:    "spans",
+		countFunc:   "SpanCount",
+		setItemName: "SetName",
+		itemName:    "Name",
+	},
 }
 
 var resourceSpansSlice = &sliceOfPtrs{
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal/cmd/pdatagen/internal"
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// signal describes the data of a signal package, e.g. ptrace, to generate the functions splitting and merging it.
+type signal struct {
+	// name is the name of the data, e.g. "Traces", and varName the name of its variables, e.g. "td".
+	name    string
+	varName string
+	// resource and scope are the names of the resource and scope items, e.g. "ResourceSpans" and "ScopeSpans",
+	// which are also the names of their slice accessors.
+	resource    string
+	resourceVar string
+	scope       string
+	scopeVar    string
+	// item is the name of the items of the scopes, e.g. "Span", and items the name of their slice accessor.
+	item    string
+	itemVar string
+	items   string
+	// itemsDoc is the name of the items in doc comments, e.g. "spans".
+	itemsDoc string
+	// countFunc is the method returning the number of items of the data, e.g. "SpanCount".
+	countFunc string
+	// setItemName and itemName are the methods setting and returning a string of an item in the tests.
+	setItemName string
+	itemName    string
+	// mergeNote is appended to the doc comment of Merge.
+	mergeNote string
+}
+
+func (s *signal) templateFields(packageInfo *PackageInfo) map[string]any {
+	return map[string]any{
+		"packageName":      packageInfo.name,
+		"name":             s.name,
+		"lowerName":        strings.ToLower(s.name),
+		"varName":          s.varName,
+		"resource":         s.resource,
+		"resourceVar":      s.resourceVar,
+		"resourceFn":       strings.ToLower(s.resource[:1]) + s.resource[1:],
+		"upperResourceVar": strings.ToUpper(s.resourceVar),
+		"scope":            s.scope,
+		"scopeVar":         s.scopeVar,
+		"scopeFn":          strings.ToLower(s.scope[:1]) + s.scope[1:],
+		"upperScopeVar":    strings.ToUpper(s.scopeVar),
+		"item":             s.item,
+		"itemVar":          s.itemVar,
+		"items":            s.items,
+		"itemsDoc":         s.itemsDoc,
+		"countFunc":        s.countFunc,
+		"setItemName":      s.setItemName,
+		"itemName":         s.itemName,
+		"mergeNote":        s.mergeNote,
+	}
+}
+
+// generateSignalFiles generates the files splitting and merging the data of the signal of this Package, if any.
+func (p *Package) generateSignalFiles(templates ...*template.Template) error {
+	if p.signal == nil {
+		return nil
+	}
+	for _, t := range templates {
+		var sb bytes.Buffer
+		if err := t.Execute(&sb, p.signal.templateFields(p.info)); err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(t.Name(), ".go")
+		path := filepath.Join(p.info.path, "generated_"+name+".go")
+		if err := os.WriteFile(path, sb.Bytes(), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -9,6 +9,14 @@ import (
 )
 
 var (
+	//go:embed templates/merge.go.tmpl
+	mergeTemplateBytes []byte
+	mergeTemplate      = parseTemplate("merge.go", mergeTemplateBytes)
+
+	//go:embed templates/merge_test.go.tmpl
+	mergeTestTemplateBytes []byte
+	mergeTestTemplate      = parseTemplate("merge_test.go", mergeTestTemplateBytes)
+
 	//go:embed templates/message.go.tmpl
 	messageTemplateBytes []byte
 	messageTemplate      = parseTemplate("message.go", messageTemplateBytes)
@@ -44,6 +52,14 @@ var (
 	//go:embed templates/slice_test.go.tmpl
 	sliceTestTemplateBytes []byte
 	sliceTestTemplate      = parseTemplate("slice_test.go", sliceTestTemplateBytes)
+
+	//go:embed templates/split.go.tmpl
+	splitTemplateBytes []byte
+	splitTemplate      = parseTemplate("split.go", splitTemplateBytes)
+
+	//go:embed templates/split_test.go.tmpl
+	splitTestTemplateBytes []byte
+	splitTestTemplate      = parseTemplate("split_test.go", splitTestTemplateBytes)
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
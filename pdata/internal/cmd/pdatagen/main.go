@@ -19,4 +19,5 @@ func main() {
 		check(fp.GenerateTestFiles())
 		check(fp.GenerateInternalFiles())
 	}
+	check(internal.GenerateJSONMarshalers())
 }
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package identity encodes the identity of pdata values as bytes, to be used as map keys. Two values have the
+// same encoding if and only if they are equal, independently of the order of the keys of their maps.
+package identity // import "go.opentelemetry.io/collector/pdata/internal/identity"
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// AppendResource appends the identity of a Resource with the given schema URL to b: its attributes and dropped
+// attributes count.
+func AppendResource(b []byte, schemaURL string, res pcommon.Resource) []byte {
+	b = AppendString(b, schemaURL)
+	b = binary.AppendUvarint(b, uint64(res.DroppedAttributesCount()))
+	return AppendMap(b, res.Attributes())
+}
+
+// AppendScope appends the identity of a Scope with the given schema URL to b: its name, version, attributes and
+// dropped attributes count.
+func AppendScope(b []byte, schemaURL string, scope pcommon.InstrumentationScope) []byte {
+	b = AppendString(b, schemaURL)
+	b = AppendString(b, scope.Name())
+	b = AppendString(b, scope.Version())
+	b = binary.AppendUvarint(b, uint64(scope.DroppedAttributesCount()))
+	return AppendMap(b, scope.Attributes())
+}
+
+// AppendString appends s to b, prefixed by its length.
+func AppendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// AppendMap appends m to b, independently of the order of its keys.
+func AppendMap(b []byte, m pcommon.Map) []byte {
+	b = binary.AppendUvarint(b, uint64(m.Len()))
+	keys := make([]string, 0, m.Len())
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		b = AppendString(b, k)
+		b = AppendValue(b, v)
+	}
+	return b
+}
+
+// AppendValue appends v to b, prefixed by its type.
+func AppendValue(b []byte, v pcommon.Value) []byte {
+	b = append(b, byte(v.Type()))
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		b = AppendString(b, v.Str())
+	case pcommon.ValueTypeInt:
+		b = binary.AppendVarint(b, v.Int())
+	case pcommon.ValueTypeDouble:
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Double()))
+	case pcommon.ValueTypeBool:
+		if v.Bool() {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+	case pcommon.ValueTypeBytes:
+		b = binary.AppendUvarint(b, uint64(v.Bytes().Len()))
+		b = append(b, v.Bytes().AsRaw()...)
+	case pcommon.ValueTypeSlice:
+		b = binary.AppendUvarint(b, uint64(v.Slice().Len()))
+		for _, e := range v.Slice().All() {
+			b = AppendValue(b, e)
+		}
+	case pcommon.ValueTypeMap:
+		b = AppendMap(b, v.Map())
+	}
+	return b
+}
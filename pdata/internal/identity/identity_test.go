@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestAppendMap(t *testing.T) {
+	newMap := func(fill func(m pcommon.Map)) pcommon.Map {
+		m := pcommon.NewMap()
+		fill(m)
+		return m
+	}
+	key := func(m pcommon.Map) string {
+		return string(AppendMap(nil, m))
+	}
+
+	all := newMap(func(m pcommon.Map) {
+		m.PutStr("str", "value")
+		m.PutInt("int", 1)
+		m.PutDouble("double", 1.5)
+		m.PutBool("bool", true)
+		m.PutEmptyBytes("bytes").FromRaw([]byte{1, 2})
+		m.PutEmptySlice("slice").AppendEmpty().SetStr("value")
+		m.PutEmptyMap("map").PutStr("key", "value")
+		m.PutEmpty("empty")
+	})
+	reversed := newMap(func(m pcommon.Map) {
+		m.PutEmpty("empty")
+		m.PutEmptyMap("map").PutStr("key", "value")
+		m.PutEmptySlice("slice").AppendEmpty().SetStr("value")
+		m.PutEmptyBytes("bytes").FromRaw([]byte{1, 2})
+		m.PutBool("bool", true)
+		m.PutDouble("double", 1.5)
+		m.PutInt("int", 1)
+		m.PutStr("str", "value")
+	})
+	assert.Equal(t, key(all), key(reversed))
+
+	for name, other := range map[string]pcommon.Map{
+		"empty":      pcommon.NewMap(),
+		"value_type": newMap(func(m pcommon.Map) { all.CopyTo(m); m.PutStr("int", "1") }),
+		"bool":       newMap(func(m pcommon.Map) { all.CopyTo(m); m.PutBool("bool", false) }),
+		"nested":     newMap(func(m pcommon.Map) { all.CopyTo(m); m.PutEmptyMap("map").PutStr("key", "other") }),
+		"slice":      newMap(func(m pcommon.Map) { all.CopyTo(m); m.PutEmptySlice("slice") }),
+		"key":        newMap(func(m pcommon.Map) { all.CopyTo(m); m.Remove("str"); m.PutStr("str2", "value") }),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.NotEqual(t, key(all), key(other))
+		})
+	}
+
+	// Length prefixes prevent ambiguous concatenations.
+	assert.NotEqual(t,
+		key(newMap(func(m pcommon.Map) { m.PutStr("a", "bc") })),
+		key(newMap(func(m pcommon.Map) { m.PutStr("ab", "c") })))
+}
+
+func TestAppendResource(t *testing.T) {
+	key := func(schemaURL string, fill func(res pcommon.Resource)) string {
+		res := pcommon.NewResource()
+		res.Attributes().PutStr("key", "value")
+		fill(res)
+		return string(AppendResource(nil, schemaURL, res))
+	}
+
+	base := key("schema", func(pcommon.Resource) {})
+	assert.Equal(t, base, key("schema", func(pcommon.Resource) {}))
+	assert.NotEqual(t, base, key("other", func(pcommon.Resource) {}))
+	assert.NotEqual(t, base, key("schema", func(res pcommon.Resource) { res.Attributes().PutStr("key", "other") }))
+	assert.NotEqual(t, base, key("schema", func(res pcommon.Resource) { res.SetDroppedAttributesCount(1) }))
+}
+
+func TestAppendScope(t *testing.T) {
+	key := func(schemaURL string, fill func(scope pcommon.InstrumentationScope)) string {
+		scope := pcommon.NewInstrumentationScope()
+		scope.SetName("name")
+		scope.SetVersion("v1")
+		scope.Attributes().PutStr("key", "value")
+		fill(scope)
+		return string(AppendScope(nil, schemaURL, scope))
+	}
+
+	base := key("schema", func(pcommon.InstrumentationScope) {})
+	assert.Equal(t, base, key("schema", func(pcommon.InstrumentationScope) {}))
+	assert.NotEqual(t, base, key("other", func(pcommon.InstrumentationScope) {}))
+	assert.NotEqual(t, base, key("schema", func(scope pcommon.InstrumentationScope) { scope.SetName("other") }))
+	assert.NotEqual(t, base, key("schema", func(scope pcommon.InstrumentationScope) { scope.SetVersion("v2") }))
+	assert.NotEqual(t, base, key("schema", func(scope pcommon.InstrumentationScope) { scope.Attributes().Clear() }))
+	assert.NotEqual(t, base, key("schema", func(scope pcommon.InstrumentationScope) { scope.SetDroppedAttributesCount(1) }))
+	// The name and version are length prefixed.
+	assert.NotEqual(t, base, key("schema", func(scope pcommon.InstrumentationScope) {
+		scope.SetName("namev")
+		scope.SetVersion("1")
+	}))
+}
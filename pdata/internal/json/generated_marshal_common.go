@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package json
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+)
+
+func writeInstrumentationScope(dest *jsoniter.Stream, orig *otlpcommon.InstrumentationScope) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Name != "" {
+		dest.WriteObjectField("name")
+		writeString(dest, orig.Name)
+		more = true
+	}
+	if orig.Version != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("version")
+		writeString(dest, orig.Version)
+		more = true
+	}
+	if orig.Attributes != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.DroppedAttributesCount != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeKeyValue(dest *jsoniter.Stream, orig *otlpcommon.KeyValue) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Key != "" {
+		dest.WriteObjectField("key")
+		writeString(dest, orig.Key)
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("value")
+	writeAnyValue(dest, &orig.Value)
+	dest.WriteObjectEnd()
+}
+
+func writeAnyValue(dest *jsoniter.Stream, orig *otlpcommon.AnyValue) {
+	dest.WriteObjectStart()
+	switch ov := orig.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		dest.WriteObjectField("stringValue")
+		writeString(dest, ov.StringValue)
+	case *otlpcommon.AnyValue_BoolValue:
+		dest.WriteObjectField("boolValue")
+		dest.WriteBool(ov.BoolValue)
+	case *otlpcommon.AnyValue_IntValue:
+		dest.WriteObjectField("intValue")
+		writeInt64(dest, ov.IntValue)
+	case *otlpcommon.AnyValue_DoubleValue:
+		dest.WriteObjectField("doubleValue")
+		writeFloat64(dest, ov.DoubleValue)
+	case *otlpcommon.AnyValue_ArrayValue:
+		dest.WriteObjectField("arrayValue")
+		if ov.ArrayValue == nil {
+			dest.WriteNil()
+		} else {
+			writeArrayValue(dest, ov.ArrayValue)
+		}
+	case *otlpcommon.AnyValue_KvlistValue:
+		dest.WriteObjectField("kvlistValue")
+		if ov.KvlistValue == nil {
+			dest.WriteNil()
+		} else {
+			writeKeyValueList(dest, ov.KvlistValue)
+		}
+	case *otlpcommon.AnyValue_BytesValue:
+		dest.WriteObjectField("bytesValue")
+		writeBytes(dest, ov.BytesValue)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeArrayValue(dest *jsoniter.Stream, orig *otlpcommon.ArrayValue) {
+	dest.WriteObjectStart()
+	if orig.Values != nil {
+		dest.WriteObjectField("values")
+		dest.WriteArrayStart()
+		for i := range orig.Values {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeAnyValue(dest, &orig.Values[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeKeyValueList(dest *jsoniter.Stream, orig *otlpcommon.KeyValueList) {
+	dest.WriteObjectStart()
+	if orig.Values != nil {
+		dest.WriteObjectField("values")
+		dest.WriteArrayStart()
+		for i := range orig.Values {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Values[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeResource(dest *jsoniter.Stream, orig *otlpresource.Resource) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Attributes != nil {
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.DroppedAttributesCount != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	dest.WriteObjectEnd()
+}
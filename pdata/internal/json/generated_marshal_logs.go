@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package json
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+)
+
+// MarshalLogsData writes the OTLP/JSON encoding of orig to out.
+func MarshalLogsData(out io.Writer, orig *otlplogs.LogsData) error {
+	return marshal(out, orig, writeLogsData)
+}
+
+func writeLogsData(dest *jsoniter.Stream, orig *otlplogs.LogsData) {
+	dest.WriteObjectStart()
+	if orig.ResourceLogs != nil {
+		dest.WriteObjectField("resourceLogs")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceLogs {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceLogs[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceLogs(dest, orig.ResourceLogs[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeResourceLogs(dest *jsoniter.Stream, orig *otlplogs.ResourceLogs) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.DeprecatedScopeLogs != nil {
+		dest.WriteObjectField("deprecatedScopeLogs")
+		dest.WriteArrayStart()
+		for i := range orig.DeprecatedScopeLogs {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DeprecatedScopeLogs[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeLogs(dest, orig.DeprecatedScopeLogs[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("resource")
+	writeResource(dest, &orig.Resource)
+	if orig.ScopeLogs != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("scopeLogs")
+		dest.WriteArrayStart()
+		for i := range orig.ScopeLogs {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ScopeLogs[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeLogs(dest, orig.ScopeLogs[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeScopeLogs(dest *jsoniter.Stream, orig *otlplogs.ScopeLogs) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("scope")
+	writeInstrumentationScope(dest, &orig.Scope)
+	if orig.LogRecords != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("logRecords")
+		dest.WriteArrayStart()
+		for i := range orig.LogRecords {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.LogRecords[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeLogRecord(dest, orig.LogRecords[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeLogRecord(dest *jsoniter.Stream, orig *otlplogs.LogRecord) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.TimeUnixNano != 0 {
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	if orig.ObservedTimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("observedTimeUnixNano")
+		writeUint64(dest, orig.ObservedTimeUnixNano)
+		more = true
+	}
+	if orig.SeverityNumber != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("severityNumber")
+		dest.WriteInt32(int32(orig.SeverityNumber))
+		more = true
+	}
+	if orig.SeverityText != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("severityText")
+		writeString(dest, orig.SeverityText)
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("body")
+	writeAnyValue(dest, &orig.Body)
+	if orig.Attributes != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.DroppedAttributesCount != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	if orig.Flags != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+	}
+	dest.WriteMore()
+	dest.WriteObjectField("traceId")
+	writeID(dest, orig.TraceId[:])
+	dest.WriteMore()
+	dest.WriteObjectField("spanId")
+	writeID(dest, orig.SpanId[:])
+	if orig.EventName != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("eventName")
+		writeString(dest, orig.EventName)
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportLogsServiceRequest writes the OTLP/JSON encoding of orig to out.
+func MarshalExportLogsServiceRequest(out io.Writer, orig *otlpcollectorlogs.ExportLogsServiceRequest) error {
+	return marshal(out, orig, writeExportLogsServiceRequest)
+}
+
+func writeExportLogsServiceRequest(dest *jsoniter.Stream, orig *otlpcollectorlogs.ExportLogsServiceRequest) {
+	dest.WriteObjectStart()
+	if orig.ResourceLogs != nil {
+		dest.WriteObjectField("resourceLogs")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceLogs {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceLogs[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceLogs(dest, orig.ResourceLogs[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportLogsServiceResponse writes the OTLP/JSON encoding of orig to out.
+func MarshalExportLogsServiceResponse(out io.Writer, orig *otlpcollectorlogs.ExportLogsServiceResponse) error {
+	return marshal(out, orig, writeExportLogsServiceResponse)
+}
+
+func writeExportLogsServiceResponse(dest *jsoniter.Stream, orig *otlpcollectorlogs.ExportLogsServiceResponse) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("partialSuccess")
+	writeExportLogsPartialSuccess(dest, &orig.PartialSuccess)
+	dest.WriteObjectEnd()
+}
+
+func writeExportLogsPartialSuccess(dest *jsoniter.Stream, orig *otlpcollectorlogs.ExportLogsPartialSuccess) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.RejectedLogRecords != 0 {
+		dest.WriteObjectField("rejectedLogRecords")
+		writeInt64(dest, orig.RejectedLogRecords)
+		more = true
+	}
+	if orig.ErrorMessage != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("errorMessage")
+		writeString(dest, orig.ErrorMessage)
+	}
+	dest.WriteObjectEnd()
+}
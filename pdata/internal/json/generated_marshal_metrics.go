@@ -0,0 +1,896 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package json
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+// MarshalMetricsData writes the OTLP/JSON encoding of orig to out.
+func MarshalMetricsData(out io.Writer, orig *otlpmetrics.MetricsData) error {
+	return marshal(out, orig, writeMetricsData)
+}
+
+func writeMetricsData(dest *jsoniter.Stream, orig *otlpmetrics.MetricsData) {
+	dest.WriteObjectStart()
+	if orig.ResourceMetrics != nil {
+		dest.WriteObjectField("resourceMetrics")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceMetrics {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceMetrics[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceMetrics(dest, orig.ResourceMetrics[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeResourceMetrics(dest *jsoniter.Stream, orig *otlpmetrics.ResourceMetrics) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.DeprecatedScopeMetrics != nil {
+		dest.WriteObjectField("deprecatedScopeMetrics")
+		dest.WriteArrayStart()
+		for i := range orig.DeprecatedScopeMetrics {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DeprecatedScopeMetrics[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeMetrics(dest, orig.DeprecatedScopeMetrics[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("resource")
+	writeResource(dest, &orig.Resource)
+	if orig.ScopeMetrics != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("scopeMetrics")
+		dest.WriteArrayStart()
+		for i := range orig.ScopeMetrics {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ScopeMetrics[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeMetrics(dest, orig.ScopeMetrics[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeScopeMetrics(dest *jsoniter.Stream, orig *otlpmetrics.ScopeMetrics) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("scope")
+	writeInstrumentationScope(dest, &orig.Scope)
+	if orig.Metrics != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("metrics")
+		dest.WriteArrayStart()
+		for i := range orig.Metrics {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Metrics[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeMetric(dest, orig.Metrics[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeMetric(dest *jsoniter.Stream, orig *otlpmetrics.Metric) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Name != "" {
+		dest.WriteObjectField("name")
+		writeString(dest, orig.Name)
+		more = true
+	}
+	if orig.Description != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("description")
+		writeString(dest, orig.Description)
+		more = true
+	}
+	if orig.Unit != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("unit")
+		writeString(dest, orig.Unit)
+		more = true
+	}
+	switch ov := orig.Data.(type) {
+	case *otlpmetrics.Metric_Gauge:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("gauge")
+		if ov.Gauge == nil {
+			dest.WriteNil()
+		} else {
+			writeGauge(dest, ov.Gauge)
+		}
+		more = true
+	case *otlpmetrics.Metric_Sum:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("sum")
+		if ov.Sum == nil {
+			dest.WriteNil()
+		} else {
+			writeSum(dest, ov.Sum)
+		}
+		more = true
+	case *otlpmetrics.Metric_Histogram:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("histogram")
+		if ov.Histogram == nil {
+			dest.WriteNil()
+		} else {
+			writeHistogram(dest, ov.Histogram)
+		}
+		more = true
+	case *otlpmetrics.Metric_ExponentialHistogram:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("exponentialHistogram")
+		if ov.ExponentialHistogram == nil {
+			dest.WriteNil()
+		} else {
+			writeExponentialHistogram(dest, ov.ExponentialHistogram)
+		}
+		more = true
+	case *otlpmetrics.Metric_Summary:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("summary")
+		if ov.Summary == nil {
+			dest.WriteNil()
+		} else {
+			writeSummary(dest, ov.Summary)
+		}
+		more = true
+	}
+	if orig.Metadata != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("metadata")
+		dest.WriteArrayStart()
+		for i := range orig.Metadata {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Metadata[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeGauge(dest *jsoniter.Stream, orig *otlpmetrics.Gauge) {
+	dest.WriteObjectStart()
+	if orig.DataPoints != nil {
+		dest.WriteObjectField("dataPoints")
+		dest.WriteArrayStart()
+		for i := range orig.DataPoints {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DataPoints[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeNumberDataPoint(dest, orig.DataPoints[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeNumberDataPoint(dest *jsoniter.Stream, orig *otlpmetrics.NumberDataPoint) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Attributes != nil {
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.StartTimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("startTimeUnixNano")
+		writeUint64(dest, orig.StartTimeUnixNano)
+		more = true
+	}
+	if orig.TimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	switch ov := orig.Value.(type) {
+	case *otlpmetrics.NumberDataPoint_AsDouble:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("asDouble")
+		writeFloat64(dest, ov.AsDouble)
+		more = true
+	case *otlpmetrics.NumberDataPoint_AsInt:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("asInt")
+		writeInt64(dest, ov.AsInt)
+		more = true
+	}
+	if orig.Exemplars != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("exemplars")
+		dest.WriteArrayStart()
+		for i := range orig.Exemplars {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeExemplar(dest, &orig.Exemplars[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.Flags != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeExemplar(dest *jsoniter.Stream, orig *otlpmetrics.Exemplar) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.FilteredAttributes != nil {
+		dest.WriteObjectField("filteredAttributes")
+		dest.WriteArrayStart()
+		for i := range orig.FilteredAttributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.FilteredAttributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.TimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	switch ov := orig.Value.(type) {
+	case *otlpmetrics.Exemplar_AsDouble:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("asDouble")
+		writeFloat64(dest, ov.AsDouble)
+		more = true
+	case *otlpmetrics.Exemplar_AsInt:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("asInt")
+		writeInt64(dest, ov.AsInt)
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("spanId")
+	writeID(dest, orig.SpanId[:])
+	dest.WriteMore()
+	dest.WriteObjectField("traceId")
+	writeID(dest, orig.TraceId[:])
+	dest.WriteObjectEnd()
+}
+
+func writeSum(dest *jsoniter.Stream, orig *otlpmetrics.Sum) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.DataPoints != nil {
+		dest.WriteObjectField("dataPoints")
+		dest.WriteArrayStart()
+		for i := range orig.DataPoints {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DataPoints[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeNumberDataPoint(dest, orig.DataPoints[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.AggregationTemporality != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("aggregationTemporality")
+		dest.WriteInt32(int32(orig.AggregationTemporality))
+		more = true
+	}
+	if orig.IsMonotonic {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("isMonotonic")
+		dest.WriteBool(orig.IsMonotonic)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeHistogram(dest *jsoniter.Stream, orig *otlpmetrics.Histogram) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.DataPoints != nil {
+		dest.WriteObjectField("dataPoints")
+		dest.WriteArrayStart()
+		for i := range orig.DataPoints {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DataPoints[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeHistogramDataPoint(dest, orig.DataPoints[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.AggregationTemporality != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("aggregationTemporality")
+		dest.WriteInt32(int32(orig.AggregationTemporality))
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeHistogramDataPoint(dest *jsoniter.Stream, orig *otlpmetrics.HistogramDataPoint) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Attributes != nil {
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.StartTimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("startTimeUnixNano")
+		writeUint64(dest, orig.StartTimeUnixNano)
+		more = true
+	}
+	if orig.TimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	if orig.Count != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("count")
+		writeUint64(dest, orig.Count)
+		more = true
+	}
+	switch ov := orig.Sum_.(type) {
+	case *otlpmetrics.HistogramDataPoint_Sum:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("sum")
+		writeFloat64(dest, ov.Sum)
+		more = true
+	}
+	if orig.BucketCounts != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("bucketCounts")
+		dest.WriteArrayStart()
+		for i := range orig.BucketCounts {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeUint64(dest, orig.BucketCounts[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.ExplicitBounds != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("explicitBounds")
+		dest.WriteArrayStart()
+		for i := range orig.ExplicitBounds {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeFloat64(dest, orig.ExplicitBounds[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.Exemplars != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("exemplars")
+		dest.WriteArrayStart()
+		for i := range orig.Exemplars {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeExemplar(dest, &orig.Exemplars[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.Flags != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+		more = true
+	}
+	switch ov := orig.Min_.(type) {
+	case *otlpmetrics.HistogramDataPoint_Min:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("min")
+		writeFloat64(dest, ov.Min)
+		more = true
+	}
+	switch ov := orig.Max_.(type) {
+	case *otlpmetrics.HistogramDataPoint_Max:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("max")
+		writeFloat64(dest, ov.Max)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeExponentialHistogram(dest *jsoniter.Stream, orig *otlpmetrics.ExponentialHistogram) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.DataPoints != nil {
+		dest.WriteObjectField("dataPoints")
+		dest.WriteArrayStart()
+		for i := range orig.DataPoints {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DataPoints[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeExponentialHistogramDataPoint(dest, orig.DataPoints[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.AggregationTemporality != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("aggregationTemporality")
+		dest.WriteInt32(int32(orig.AggregationTemporality))
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeExponentialHistogramDataPoint(dest *jsoniter.Stream, orig *otlpmetrics.ExponentialHistogramDataPoint) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Attributes != nil {
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.StartTimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("startTimeUnixNano")
+		writeUint64(dest, orig.StartTimeUnixNano)
+		more = true
+	}
+	if orig.TimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	if orig.Count != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("count")
+		writeUint64(dest, orig.Count)
+		more = true
+	}
+	switch ov := orig.Sum_.(type) {
+	case *otlpmetrics.ExponentialHistogramDataPoint_Sum:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("sum")
+		writeFloat64(dest, ov.Sum)
+		more = true
+	}
+	if orig.Scale != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("scale")
+		dest.WriteInt32(orig.Scale)
+		more = true
+	}
+	if orig.ZeroCount != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("zeroCount")
+		writeUint64(dest, orig.ZeroCount)
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("positive")
+	writeExponentialHistogramDataPointBuckets(dest, &orig.Positive)
+	dest.WriteMore()
+	dest.WriteObjectField("negative")
+	writeExponentialHistogramDataPointBuckets(dest, &orig.Negative)
+	if orig.Flags != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+	}
+	if orig.Exemplars != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("exemplars")
+		dest.WriteArrayStart()
+		for i := range orig.Exemplars {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeExemplar(dest, &orig.Exemplars[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	switch ov := orig.Min_.(type) {
+	case *otlpmetrics.ExponentialHistogramDataPoint_Min:
+		dest.WriteMore()
+		dest.WriteObjectField("min")
+		writeFloat64(dest, ov.Min)
+	}
+	switch ov := orig.Max_.(type) {
+	case *otlpmetrics.ExponentialHistogramDataPoint_Max:
+		dest.WriteMore()
+		dest.WriteObjectField("max")
+		writeFloat64(dest, ov.Max)
+	}
+	if orig.ZeroThreshold != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("zeroThreshold")
+		writeFloat64(dest, orig.ZeroThreshold)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeExponentialHistogramDataPointBuckets(dest *jsoniter.Stream, orig *otlpmetrics.ExponentialHistogramDataPoint_Buckets) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Offset != 0 {
+		dest.WriteObjectField("offset")
+		dest.WriteInt32(orig.Offset)
+		more = true
+	}
+	if orig.BucketCounts != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("bucketCounts")
+		dest.WriteArrayStart()
+		for i := range orig.BucketCounts {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeUint64(dest, orig.BucketCounts[i])
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeSummary(dest *jsoniter.Stream, orig *otlpmetrics.Summary) {
+	dest.WriteObjectStart()
+	if orig.DataPoints != nil {
+		dest.WriteObjectField("dataPoints")
+		dest.WriteArrayStart()
+		for i := range orig.DataPoints {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DataPoints[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeSummaryDataPoint(dest, orig.DataPoints[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeSummaryDataPoint(dest *jsoniter.Stream, orig *otlpmetrics.SummaryDataPoint) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Attributes != nil {
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.StartTimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("startTimeUnixNano")
+		writeUint64(dest, orig.StartTimeUnixNano)
+		more = true
+	}
+	if orig.TimeUnixNano != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	if orig.Count != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("count")
+		writeUint64(dest, orig.Count)
+		more = true
+	}
+	if orig.Sum != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("sum")
+		writeFloat64(dest, orig.Sum)
+		more = true
+	}
+	if orig.QuantileValues != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("quantileValues")
+		dest.WriteArrayStart()
+		for i := range orig.QuantileValues {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.QuantileValues[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeSummaryDataPointValueAtQuantile(dest, orig.QuantileValues[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.Flags != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeSummaryDataPointValueAtQuantile(dest *jsoniter.Stream, orig *otlpmetrics.SummaryDataPoint_ValueAtQuantile) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Quantile != 0 {
+		dest.WriteObjectField("quantile")
+		writeFloat64(dest, orig.Quantile)
+		more = true
+	}
+	if orig.Value != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("value")
+		writeFloat64(dest, orig.Value)
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportMetricsServiceRequest writes the OTLP/JSON encoding of orig to out.
+func MarshalExportMetricsServiceRequest(out io.Writer, orig *otlpcollectormetrics.ExportMetricsServiceRequest) error {
+	return marshal(out, orig, writeExportMetricsServiceRequest)
+}
+
+func writeExportMetricsServiceRequest(dest *jsoniter.Stream, orig *otlpcollectormetrics.ExportMetricsServiceRequest) {
+	dest.WriteObjectStart()
+	if orig.ResourceMetrics != nil {
+		dest.WriteObjectField("resourceMetrics")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceMetrics {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceMetrics[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceMetrics(dest, orig.ResourceMetrics[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportMetricsServiceResponse writes the OTLP/JSON encoding of orig to out.
+func MarshalExportMetricsServiceResponse(out io.Writer, orig *otlpcollectormetrics.ExportMetricsServiceResponse) error {
+	return marshal(out, orig, writeExportMetricsServiceResponse)
+}
+
+func writeExportMetricsServiceResponse(dest *jsoniter.Stream, orig *otlpcollectormetrics.ExportMetricsServiceResponse) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("partialSuccess")
+	writeExportMetricsPartialSuccess(dest, &orig.PartialSuccess)
+	dest.WriteObjectEnd()
+}
+
+func writeExportMetricsPartialSuccess(dest *jsoniter.Stream, orig *otlpcollectormetrics.ExportMetricsPartialSuccess) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.RejectedDataPoints != 0 {
+		dest.WriteObjectField("rejectedDataPoints")
+		writeInt64(dest, orig.RejectedDataPoints)
+		more = true
+	}
+	if orig.ErrorMessage != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("errorMessage")
+		writeString(dest, orig.ErrorMessage)
+	}
+	dest.WriteObjectEnd()
+}
@@ -0,0 +1,747 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package json
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+
+	otlpcollectorprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1development"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1development"
+)
+
+// MarshalProfilesData writes the OTLP/JSON encoding of orig to out.
+func MarshalProfilesData(out io.Writer, orig *otlpprofiles.ProfilesData) error {
+	return marshal(out, orig, writeProfilesData)
+}
+
+func writeProfilesData(dest *jsoniter.Stream, orig *otlpprofiles.ProfilesData) {
+	dest.WriteObjectStart()
+	if orig.ResourceProfiles != nil {
+		dest.WriteObjectField("resourceProfiles")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceProfiles {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceProfiles[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceProfiles(dest, orig.ResourceProfiles[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeResourceProfiles(dest *jsoniter.Stream, orig *otlpprofiles.ResourceProfiles) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("resource")
+	writeResource(dest, &orig.Resource)
+	if orig.ScopeProfiles != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("scopeProfiles")
+		dest.WriteArrayStart()
+		for i := range orig.ScopeProfiles {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ScopeProfiles[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeProfiles(dest, orig.ScopeProfiles[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeScopeProfiles(dest *jsoniter.Stream, orig *otlpprofiles.ScopeProfiles) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("scope")
+	writeInstrumentationScope(dest, &orig.Scope)
+	if orig.Profiles != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("profiles")
+		dest.WriteArrayStart()
+		for i := range orig.Profiles {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Profiles[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeProfile(dest, orig.Profiles[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeProfile(dest *jsoniter.Stream, orig *otlpprofiles.Profile) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.SampleType != nil {
+		dest.WriteObjectField("sampleType")
+		dest.WriteArrayStart()
+		for i := range orig.SampleType {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.SampleType[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeValueType(dest, orig.SampleType[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.Sample != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("sample")
+		dest.WriteArrayStart()
+		for i := range orig.Sample {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Sample[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeSample(dest, orig.Sample[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.MappingTable != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("mappingTable")
+		dest.WriteArrayStart()
+		for i := range orig.MappingTable {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.MappingTable[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeMapping(dest, orig.MappingTable[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.LocationTable != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("locationTable")
+		dest.WriteArrayStart()
+		for i := range orig.LocationTable {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.LocationTable[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeLocation(dest, orig.LocationTable[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.LocationIndices != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("locationIndices")
+		dest.WriteArrayStart()
+		for i := range orig.LocationIndices {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			dest.WriteInt32(orig.LocationIndices[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.FunctionTable != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("functionTable")
+		dest.WriteArrayStart()
+		for i := range orig.FunctionTable {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.FunctionTable[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeFunction(dest, orig.FunctionTable[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.AttributeTable != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributeTable")
+		dest.WriteArrayStart()
+		for i := range orig.AttributeTable {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.AttributeTable[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.AttributeUnits != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributeUnits")
+		dest.WriteArrayStart()
+		for i := range orig.AttributeUnits {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.AttributeUnits[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeAttributeUnit(dest, orig.AttributeUnits[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.LinkTable != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("linkTable")
+		dest.WriteArrayStart()
+		for i := range orig.LinkTable {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.LinkTable[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeLink(dest, orig.LinkTable[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.StringTable != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("stringTable")
+		dest.WriteArrayStart()
+		for i := range orig.StringTable {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeString(dest, orig.StringTable[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.TimeNanos != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timeNanos")
+		writeInt64(dest, orig.TimeNanos)
+		more = true
+	}
+	if orig.DurationNanos != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("durationNanos")
+		writeInt64(dest, orig.DurationNanos)
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("periodType")
+	writeValueType(dest, &orig.PeriodType)
+	if orig.Period != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("period")
+		writeInt64(dest, orig.Period)
+	}
+	if orig.CommentStrindices != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("commentStrindices")
+		dest.WriteArrayStart()
+		for i := range orig.CommentStrindices {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			dest.WriteInt32(orig.CommentStrindices[i])
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.DefaultSampleTypeStrindex != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("defaultSampleTypeStrindex")
+		dest.WriteInt32(orig.DefaultSampleTypeStrindex)
+	}
+	dest.WriteMore()
+	dest.WriteObjectField("profileId")
+	writeID(dest, orig.ProfileId[:])
+	if orig.DroppedAttributesCount != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	if orig.OriginalPayloadFormat != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("originalPayloadFormat")
+		writeString(dest, orig.OriginalPayloadFormat)
+	}
+	if orig.OriginalPayload != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("originalPayload")
+		writeBytes(dest, orig.OriginalPayload)
+	}
+	if orig.AttributeIndices != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("attributeIndices")
+		dest.WriteArrayStart()
+		for i := range orig.AttributeIndices {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			dest.WriteInt32(orig.AttributeIndices[i])
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeValueType(dest *jsoniter.Stream, orig *otlpprofiles.ValueType) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.TypeStrindex != 0 {
+		dest.WriteObjectField("typeStrindex")
+		dest.WriteInt32(orig.TypeStrindex)
+		more = true
+	}
+	if orig.UnitStrindex != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("unitStrindex")
+		dest.WriteInt32(orig.UnitStrindex)
+		more = true
+	}
+	if orig.AggregationTemporality != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("aggregationTemporality")
+		dest.WriteInt32(int32(orig.AggregationTemporality))
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeSample(dest *jsoniter.Stream, orig *otlpprofiles.Sample) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.LocationsStartIndex != 0 {
+		dest.WriteObjectField("locationsStartIndex")
+		dest.WriteInt32(orig.LocationsStartIndex)
+		more = true
+	}
+	if orig.LocationsLength != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("locationsLength")
+		dest.WriteInt32(orig.LocationsLength)
+		more = true
+	}
+	if orig.Value != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("value")
+		dest.WriteArrayStart()
+		for i := range orig.Value {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeInt64(dest, orig.Value[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.AttributeIndices != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributeIndices")
+		dest.WriteArrayStart()
+		for i := range orig.AttributeIndices {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			dest.WriteInt32(orig.AttributeIndices[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	switch ov := orig.LinkIndex_.(type) {
+	case *otlpprofiles.Sample_LinkIndex:
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("linkIndex")
+		dest.WriteInt32(ov.LinkIndex)
+		more = true
+	}
+	if orig.TimestampsUnixNano != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("timestampsUnixNano")
+		dest.WriteArrayStart()
+		for i := range orig.TimestampsUnixNano {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeUint64(dest, orig.TimestampsUnixNano[i])
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeMapping(dest *jsoniter.Stream, orig *otlpprofiles.Mapping) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.MemoryStart != 0 {
+		dest.WriteObjectField("memoryStart")
+		writeUint64(dest, orig.MemoryStart)
+		more = true
+	}
+	if orig.MemoryLimit != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("memoryLimit")
+		writeUint64(dest, orig.MemoryLimit)
+		more = true
+	}
+	if orig.FileOffset != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("fileOffset")
+		writeUint64(dest, orig.FileOffset)
+		more = true
+	}
+	if orig.FilenameStrindex != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("filenameStrindex")
+		dest.WriteInt32(orig.FilenameStrindex)
+		more = true
+	}
+	if orig.AttributeIndices != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributeIndices")
+		dest.WriteArrayStart()
+		for i := range orig.AttributeIndices {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			dest.WriteInt32(orig.AttributeIndices[i])
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.HasFunctions {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("hasFunctions")
+		dest.WriteBool(orig.HasFunctions)
+		more = true
+	}
+	if orig.HasFilenames {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("hasFilenames")
+		dest.WriteBool(orig.HasFilenames)
+		more = true
+	}
+	if orig.HasLineNumbers {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("hasLineNumbers")
+		dest.WriteBool(orig.HasLineNumbers)
+		more = true
+	}
+	if orig.HasInlineFrames {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("hasInlineFrames")
+		dest.WriteBool(orig.HasInlineFrames)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeLocation(dest *jsoniter.Stream, orig *otlpprofiles.Location) {
+	dest.WriteObjectStart()
+	more := false
+	switch ov := orig.MappingIndex_.(type) {
+	case *otlpprofiles.Location_MappingIndex:
+		dest.WriteObjectField("mappingIndex")
+		dest.WriteInt32(ov.MappingIndex)
+		more = true
+	}
+	if orig.Address != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("address")
+		writeUint64(dest, orig.Address)
+		more = true
+	}
+	if orig.Line != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("line")
+		dest.WriteArrayStart()
+		for i := range orig.Line {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Line[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeLine(dest, orig.Line[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.IsFolded {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("isFolded")
+		dest.WriteBool(orig.IsFolded)
+		more = true
+	}
+	if orig.AttributeIndices != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributeIndices")
+		dest.WriteArrayStart()
+		for i := range orig.AttributeIndices {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			dest.WriteInt32(orig.AttributeIndices[i])
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeLine(dest *jsoniter.Stream, orig *otlpprofiles.Line) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.FunctionIndex != 0 {
+		dest.WriteObjectField("functionIndex")
+		dest.WriteInt32(orig.FunctionIndex)
+		more = true
+	}
+	if orig.Line != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("line")
+		writeInt64(dest, orig.Line)
+		more = true
+	}
+	if orig.Column != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("column")
+		writeInt64(dest, orig.Column)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeFunction(dest *jsoniter.Stream, orig *otlpprofiles.Function) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.NameStrindex != 0 {
+		dest.WriteObjectField("nameStrindex")
+		dest.WriteInt32(orig.NameStrindex)
+		more = true
+	}
+	if orig.SystemNameStrindex != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("systemNameStrindex")
+		dest.WriteInt32(orig.SystemNameStrindex)
+		more = true
+	}
+	if orig.FilenameStrindex != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("filenameStrindex")
+		dest.WriteInt32(orig.FilenameStrindex)
+		more = true
+	}
+	if orig.StartLine != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("startLine")
+		writeInt64(dest, orig.StartLine)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeAttributeUnit(dest *jsoniter.Stream, orig *otlpprofiles.AttributeUnit) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.AttributeKeyStrindex != 0 {
+		dest.WriteObjectField("attributeKeyStrindex")
+		dest.WriteInt32(orig.AttributeKeyStrindex)
+		more = true
+	}
+	if orig.UnitStrindex != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("unitStrindex")
+		dest.WriteInt32(orig.UnitStrindex)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeLink(dest *jsoniter.Stream, orig *otlpprofiles.Link) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("traceId")
+	writeID(dest, orig.TraceId[:])
+	dest.WriteMore()
+	dest.WriteObjectField("spanId")
+	writeID(dest, orig.SpanId[:])
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportProfilesServiceRequest writes the OTLP/JSON encoding of orig to out.
+func MarshalExportProfilesServiceRequest(out io.Writer, orig *otlpcollectorprofiles.ExportProfilesServiceRequest) error {
+	return marshal(out, orig, writeExportProfilesServiceRequest)
+}
+
+func writeExportProfilesServiceRequest(dest *jsoniter.Stream, orig *otlpcollectorprofiles.ExportProfilesServiceRequest) {
+	dest.WriteObjectStart()
+	if orig.ResourceProfiles != nil {
+		dest.WriteObjectField("resourceProfiles")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceProfiles {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceProfiles[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceProfiles(dest, orig.ResourceProfiles[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportProfilesServiceResponse writes the OTLP/JSON encoding of orig to out.
+func MarshalExportProfilesServiceResponse(out io.Writer, orig *otlpcollectorprofiles.ExportProfilesServiceResponse) error {
+	return marshal(out, orig, writeExportProfilesServiceResponse)
+}
+
+func writeExportProfilesServiceResponse(dest *jsoniter.Stream, orig *otlpcollectorprofiles.ExportProfilesServiceResponse) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("partialSuccess")
+	writeExportProfilesPartialSuccess(dest, &orig.PartialSuccess)
+	dest.WriteObjectEnd()
+}
+
+func writeExportProfilesPartialSuccess(dest *jsoniter.Stream, orig *otlpcollectorprofiles.ExportProfilesPartialSuccess) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.RejectedProfiles != 0 {
+		dest.WriteObjectField("rejectedProfiles")
+		writeInt64(dest, orig.RejectedProfiles)
+		more = true
+	}
+	if orig.ErrorMessage != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("errorMessage")
+		writeString(dest, orig.ErrorMessage)
+	}
+	dest.WriteObjectEnd()
+}
@@ -0,0 +1,383 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package json
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// MarshalTracesData writes the OTLP/JSON encoding of orig to out.
+func MarshalTracesData(out io.Writer, orig *otlptrace.TracesData) error {
+	return marshal(out, orig, writeTracesData)
+}
+
+func writeTracesData(dest *jsoniter.Stream, orig *otlptrace.TracesData) {
+	dest.WriteObjectStart()
+	if orig.ResourceSpans != nil {
+		dest.WriteObjectField("resourceSpans")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceSpans {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceSpans[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceSpans(dest, orig.ResourceSpans[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeResourceSpans(dest *jsoniter.Stream, orig *otlptrace.ResourceSpans) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.DeprecatedScopeSpans != nil {
+		dest.WriteObjectField("deprecatedScopeSpans")
+		dest.WriteArrayStart()
+		for i := range orig.DeprecatedScopeSpans {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.DeprecatedScopeSpans[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeSpans(dest, orig.DeprecatedScopeSpans[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if more {
+		dest.WriteMore()
+	}
+	dest.WriteObjectField("resource")
+	writeResource(dest, &orig.Resource)
+	if orig.ScopeSpans != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("scopeSpans")
+		dest.WriteArrayStart()
+		for i := range orig.ScopeSpans {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ScopeSpans[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeScopeSpans(dest, orig.ScopeSpans[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeScopeSpans(dest *jsoniter.Stream, orig *otlptrace.ScopeSpans) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("scope")
+	writeInstrumentationScope(dest, &orig.Scope)
+	if orig.Spans != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("spans")
+		dest.WriteArrayStart()
+		for i := range orig.Spans {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Spans[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeSpan(dest, orig.Spans[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.SchemaUrl != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("schemaUrl")
+		writeString(dest, orig.SchemaUrl)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeSpan(dest *jsoniter.Stream, orig *otlptrace.Span) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("traceId")
+	writeID(dest, orig.TraceId[:])
+	dest.WriteMore()
+	dest.WriteObjectField("spanId")
+	writeID(dest, orig.SpanId[:])
+	if orig.TraceState != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("traceState")
+		writeString(dest, orig.TraceState)
+	}
+	dest.WriteMore()
+	dest.WriteObjectField("parentSpanId")
+	writeID(dest, orig.ParentSpanId[:])
+	if orig.Flags != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+	}
+	if orig.Name != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("name")
+		writeString(dest, orig.Name)
+	}
+	if orig.Kind != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("kind")
+		dest.WriteInt32(int32(orig.Kind))
+	}
+	if orig.StartTimeUnixNano != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("startTimeUnixNano")
+		writeUint64(dest, orig.StartTimeUnixNano)
+	}
+	if orig.EndTimeUnixNano != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("endTimeUnixNano")
+		writeUint64(dest, orig.EndTimeUnixNano)
+	}
+	if orig.Attributes != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.DroppedAttributesCount != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	if orig.Events != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("events")
+		dest.WriteArrayStart()
+		for i := range orig.Events {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Events[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeSpanEvent(dest, orig.Events[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.DroppedEventsCount != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("droppedEventsCount")
+		dest.WriteUint32(orig.DroppedEventsCount)
+	}
+	if orig.Links != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("links")
+		dest.WriteArrayStart()
+		for i := range orig.Links {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.Links[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeSpanLink(dest, orig.Links[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.DroppedLinksCount != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("droppedLinksCount")
+		dest.WriteUint32(orig.DroppedLinksCount)
+	}
+	dest.WriteMore()
+	dest.WriteObjectField("status")
+	writeStatus(dest, &orig.Status)
+	dest.WriteObjectEnd()
+}
+
+func writeSpanEvent(dest *jsoniter.Stream, orig *otlptrace.Span_Event) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.TimeUnixNano != 0 {
+		dest.WriteObjectField("timeUnixNano")
+		writeUint64(dest, orig.TimeUnixNano)
+		more = true
+	}
+	if orig.Name != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("name")
+		writeString(dest, orig.Name)
+		more = true
+	}
+	if orig.Attributes != nil {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+		more = true
+	}
+	if orig.DroppedAttributesCount != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeSpanLink(dest *jsoniter.Stream, orig *otlptrace.Span_Link) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("traceId")
+	writeID(dest, orig.TraceId[:])
+	dest.WriteMore()
+	dest.WriteObjectField("spanId")
+	writeID(dest, orig.SpanId[:])
+	if orig.TraceState != "" {
+		dest.WriteMore()
+		dest.WriteObjectField("traceState")
+		writeString(dest, orig.TraceState)
+	}
+	if orig.Attributes != nil {
+		dest.WriteMore()
+		dest.WriteObjectField("attributes")
+		dest.WriteArrayStart()
+		for i := range orig.Attributes {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			writeKeyValue(dest, &orig.Attributes[i])
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	if orig.DroppedAttributesCount != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("droppedAttributesCount")
+		dest.WriteUint32(orig.DroppedAttributesCount)
+	}
+	if orig.Flags != 0 {
+		dest.WriteMore()
+		dest.WriteObjectField("flags")
+		dest.WriteUint32(orig.Flags)
+	}
+	dest.WriteObjectEnd()
+}
+
+func writeStatus(dest *jsoniter.Stream, orig *otlptrace.Status) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.Message != "" {
+		dest.WriteObjectField("message")
+		writeString(dest, orig.Message)
+		more = true
+	}
+	if orig.Code != 0 {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("code")
+		dest.WriteInt32(int32(orig.Code))
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportTraceServiceRequest writes the OTLP/JSON encoding of orig to out.
+func MarshalExportTraceServiceRequest(out io.Writer, orig *otlpcollectortrace.ExportTraceServiceRequest) error {
+	return marshal(out, orig, writeExportTraceServiceRequest)
+}
+
+func writeExportTraceServiceRequest(dest *jsoniter.Stream, orig *otlpcollectortrace.ExportTraceServiceRequest) {
+	dest.WriteObjectStart()
+	if orig.ResourceSpans != nil {
+		dest.WriteObjectField("resourceSpans")
+		dest.WriteArrayStart()
+		for i := range orig.ResourceSpans {
+			if i != 0 {
+				dest.WriteMore()
+			}
+			if orig.ResourceSpans[i] == nil {
+				dest.WriteNil()
+			} else {
+				writeResourceSpans(dest, orig.ResourceSpans[i])
+			}
+			flushIfFull(dest)
+		}
+		dest.WriteArrayEnd()
+	}
+	dest.WriteObjectEnd()
+}
+
+// MarshalExportTraceServiceResponse writes the OTLP/JSON encoding of orig to out.
+func MarshalExportTraceServiceResponse(out io.Writer, orig *otlpcollectortrace.ExportTraceServiceResponse) error {
+	return marshal(out, orig, writeExportTraceServiceResponse)
+}
+
+func writeExportTraceServiceResponse(dest *jsoniter.Stream, orig *otlpcollectortrace.ExportTraceServiceResponse) {
+	dest.WriteObjectStart()
+	dest.WriteObjectField("partialSuccess")
+	writeExportTracePartialSuccess(dest, &orig.PartialSuccess)
+	dest.WriteObjectEnd()
+}
+
+func writeExportTracePartialSuccess(dest *jsoniter.Stream, orig *otlpcollectortrace.ExportTracePartialSuccess) {
+	dest.WriteObjectStart()
+	more := false
+	if orig.RejectedSpans != 0 {
+		dest.WriteObjectField("rejectedSpans")
+		writeInt64(dest, orig.RejectedSpans)
+		more = true
+	}
+	if orig.ErrorMessage != "" {
+		if more {
+			dest.WriteMore()
+		}
+		dest.WriteObjectField("errorMessage")
+		writeString(dest, orig.ErrorMessage)
+	}
+	dest.WriteObjectEnd()
+}
@@ -4,19 +4,156 @@
 package json // import "go.opentelemetry.io/collector/pdata/internal/json"
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
 
-	"github.com/gogo/protobuf/jsonpb"
-	"github.com/gogo/protobuf/proto"
+	jsoniter "github.com/json-iterator/go"
 )
 
-var marshaler = &jsonpb.Marshaler{
-	// https://github.com/open-telemetry/opentelemetry-specification/pull/2758
-	EnumsAsInts: true,
-	// https://github.com/open-telemetry/opentelemetry-specification/pull/2829
-	OrigName: false,
+// flushSize is the size of the buffered output above which it is flushed to the writer, between the elements of
+// the repeated messages.
+const flushSize = 64 << 10
+
+// marshal writes the OTLP/JSON encoding of orig to out, using the generated write function of its message.
+// The output is the same as the one of the jsonpb marshaler with EnumsAsInts set:
+//   - https://github.com/open-telemetry/opentelemetry-specification/pull/2758
+//   - https://github.com/open-telemetry/opentelemetry-specification/pull/2829
+func marshal[T any](out io.Writer, orig *T, write func(*jsoniter.Stream, *T)) error {
+	dest := jsoniter.ConfigFastest.BorrowStream(out)
+	defer jsoniter.ConfigFastest.ReturnStream(dest)
+	write(dest, orig)
+	return dest.Flush()
+}
+
+// flushIfFull flushes the buffered output once it exceeds flushSize. An error is recorded by the stream, and
+// returned by the final flush.
+func flushIfFull(dest *jsoniter.Stream) {
+	if len(dest.Buffer()) >= flushSize {
+		_ = dest.Flush()
+	}
+}
+
+// writeInt64 writes an int64 as a decimal string.
+// See https://developers.google.com/protocol-buffers/docs/proto3#json.
+func writeInt64(dest *jsoniter.Stream, v int64) {
+	buf := append(dest.Buffer(), '"')
+	buf = strconv.AppendInt(buf, v, 10)
+	dest.SetBuffer(append(buf, '"'))
+}
+
+// writeUint64 writes an uint64 as a decimal string.
+// See https://developers.google.com/protocol-buffers/docs/proto3#json.
+func writeUint64(dest *jsoniter.Stream, v uint64) {
+	buf := append(dest.Buffer(), '"')
+	buf = strconv.AppendUint(buf, v, 10)
+	dest.SetBuffer(append(buf, '"'))
 }
 
-func Marshal(out io.Writer, pb proto.Message) error {
-	return marshaler.Marshal(out, pb)
+// writeFloat64 writes a float64 like encoding/json does, and the special values as strings.
+// See https://developers.google.com/protocol-buffers/docs/proto3#json.
+func writeFloat64(dest *jsoniter.Stream, v float64) {
+	switch {
+	case math.IsNaN(v):
+		dest.WriteRaw(`"NaN"`)
+		return
+	case math.IsInf(v, 1):
+		dest.WriteRaw(`"Infinity"`)
+		return
+	case math.IsInf(v, -1):
+		dest.WriteRaw(`"-Infinity"`)
+		return
+	}
+	format := byte('f')
+	if abs := math.Abs(v); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	buf := strconv.AppendFloat(dest.Buffer(), v, format, -1, 64)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(buf)
+		if n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	dest.SetBuffer(buf)
+}
+
+// writeBytes writes bytes as a base64 string.
+func writeBytes(dest *jsoniter.Stream, v []byte) {
+	buf := append(dest.Buffer(), '"')
+	buf = base64.StdEncoding.AppendEncode(buf, v)
+	dest.SetBuffer(append(buf, '"'))
+}
+
+// writeID writes a trace, span or profile ID as a hex string, empty if the ID is empty.
+func writeID(dest *jsoniter.Stream, id []byte) {
+	buf := append(dest.Buffer(), '"')
+	for _, b := range id {
+		if b != 0 {
+			buf = hex.AppendEncode(buf, id)
+			break
+		}
+	}
+	dest.SetBuffer(append(buf, '"'))
+}
+
+const hexDigits = "0123456789abcdef"
+
+// writeString writes a string escaped like encoding/json does, HTML characters included. Invalid UTF-8 is
+// replaced by an escaped U+FFFD, as done by encoding/json before its v2 implementation.
+func writeString(dest *jsoniter.Stream, v string) {
+	buf := append(dest.Buffer(), '"')
+	start := 0
+	for i := 0; i < len(v); {
+		if b := v[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			buf = append(buf, v[start:i]...)
+			switch b {
+			case '\\', '"':
+				buf = append(buf, '\\', b)
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(v[i:])
+		if c == utf8.RuneError && size == 1 {
+			buf = append(buf, v[start:i]...)
+			buf = append(buf, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are escaped for JSONP.
+		if c == '\u2028' || c == '\u2029' {
+			buf = append(buf, v[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hexDigits[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, v[start:]...)
+	dest.SetBuffer(append(buf, '"'))
 }
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal/data"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// write returns the output written by fn.
+func write(fn func(*jsoniter.Stream)) string {
+	dest := jsoniter.ConfigFastest.BorrowStream(nil)
+	defer jsoniter.ConfigFastest.ReturnStream(dest)
+	fn(dest)
+	return string(dest.Buffer())
+}
+
+func TestWriteString(t *testing.T) {
+	for _, v := range []string{
+		"",
+		"value",
+		`"quoted" \ back\slash`,
+		"<html> & </html>",
+		"\b\f\n\r\t\x00\x1f\x7f",
+		"ünïcødé 世界 🙂",
+		"separators \u2028 \u2029",
+	} {
+		want, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), write(func(dest *jsoniter.Stream) { writeString(dest, v) }), "string %q", v)
+	}
+	assert.Equal(t, `"invalid \ufffd\ufffd utf-8 \ufffd\ufffd"`, write(func(dest *jsoniter.Stream) { writeString(dest, "invalid \xff\xfe utf-8 \xe2\x82") }))
+}
+
+func TestWriteFloat64(t *testing.T) {
+	for _, v := range []float64{0, math.Copysign(0, -1), 1, -1.5, math.Pi, 1e-6, 1e-7, -1e-7, 1e20, 1e21, 123456789e15, math.MaxFloat64, math.SmallestNonzeroFloat64} {
+		want, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), write(func(dest *jsoniter.Stream) { writeFloat64(dest, v) }), "float %v", v)
+	}
+	assert.JSONEq(t, `"NaN"`, write(func(dest *jsoniter.Stream) { writeFloat64(dest, math.NaN()) }))
+	assert.JSONEq(t, `"Infinity"`, write(func(dest *jsoniter.Stream) { writeFloat64(dest, math.Inf(1)) }))
+	assert.JSONEq(t, `"-Infinity"`, write(func(dest *jsoniter.Stream) { writeFloat64(dest, math.Inf(-1)) }))
+}
+
+func TestWriteInt64(t *testing.T) {
+	assert.Equal(t, `"-9223372036854775808"`, write(func(dest *jsoniter.Stream) { writeInt64(dest, math.MinInt64) }))
+	assert.Equal(t, `"18446744073709551615"`, write(func(dest *jsoniter.Stream) { writeUint64(dest, math.MaxUint64) }))
+}
+
+func TestWriteBytes(t *testing.T) {
+	assert.Equal(t, `""`, write(func(dest *jsoniter.Stream) { writeBytes(dest, []byte{}) }))
+	assert.Equal(t, `"AQID/w=="`, write(func(dest *jsoniter.Stream) { writeBytes(dest, []byte{1, 2, 3, 0xff}) }))
+}
+
+func TestWriteID(t *testing.T) {
+	assert.Equal(t, `""`, write(func(dest *jsoniter.Stream) { writeID(dest, make([]byte, 16)) }))
+	id := data.SpanID([8]byte{0, 1, 2, 3, 0xa, 0xb, 0xc, 0xff})
+	want, err := id.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(want), write(func(dest *jsoniter.Stream) { writeID(dest, id[:]) }))
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestMarshalFlush(t *testing.T) {
+	spans := make([]*otlptrace.Span, 1000)
+	for i := range spans {
+		spans[i] = &otlptrace.Span{Name: strings.Repeat("x", 100)}
+	}
+	orig := &otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: []*otlptrace.ResourceSpans{
+		{ScopeSpans: []*otlptrace.ScopeSpans{{Scope: otlpcommon.InstrumentationScope{Name: "scope"}, Spans: spans}}},
+	}}
+
+	var want bytes.Buffer
+	require.NoError(t, jsonpbMarshaler.Marshal(&want, orig))
+	require.Greater(t, want.Len(), flushSize)
+	var got bytes.Buffer
+	require.NoError(t, MarshalExportTraceServiceRequest(&got, orig))
+	assert.Equal(t, want.String(), got.String())
+
+	require.EqualError(t, MarshalExportTraceServiceRequest(errWriter{}, orig), "write failed")
+}
@@ -0,0 +1,448 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal/data"
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectorprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1development"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1development"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// jsonpbMarshaler is the marshaler the generated ones must write the same output as.
+var jsonpbMarshaler = &jsonpb.Marshaler{EnumsAsInts: true}
+
+// testMarshal checks that marshal writes the same output as the jsonpb marshaler.
+func testMarshal[T any, PT interface {
+	*T
+	proto.Message
+}](t *testing.T, orig PT, marshal func(io.Writer, PT) error,
+) {
+	var want bytes.Buffer
+	require.NoError(t, jsonpbMarshaler.Marshal(&want, orig))
+	var got bytes.Buffer
+	require.NoError(t, marshal(&got, orig))
+	assert.Equal(t, want.String(), got.String())
+}
+
+func testTraceID() data.TraceID {
+	return data.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+}
+
+func testSpanID() data.SpanID {
+	return data.SpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1})
+}
+
+func testAttributes() []otlpcommon.KeyValue {
+	return []otlpcommon.KeyValue{
+		{Key: "string", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "<value> & \"quoted\"\n"}}},
+		{Key: "bool", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: true}}},
+		{Key: "int", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: -42}}},
+		{Key: "double", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: math.Pi}}},
+		{Key: "nan", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: math.NaN()}}},
+		{Key: "bytes", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: []byte{1, 2, 3}}}},
+		{Key: "array", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{
+			Values: []otlpcommon.AnyValue{
+				{Value: &otlpcommon.AnyValue_StringValue{StringValue: ""}},
+				{Value: &otlpcommon.AnyValue_IntValue{IntValue: 0}},
+				{},
+			},
+		}}}},
+		{Key: "kvlist", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{
+			Values: []otlpcommon.KeyValue{
+				{Key: "nested", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: 1e-9}}},
+			},
+		}}}},
+		{Key: "empty_array", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{Values: []otlpcommon.AnyValue{}}}}},
+		{Key: "nil_kvlist", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{}}},
+		{Key: "empty"},
+		{},
+	}
+}
+
+func testResource() otlpresource.Resource {
+	return otlpresource.Resource{
+		Attributes:             testAttributes(),
+		DroppedAttributesCount: 1,
+	}
+}
+
+func testScope() otlpcommon.InstrumentationScope {
+	return otlpcommon.InstrumentationScope{
+		Name:                   "scope",
+		Version:                "v1.0.0",
+		Attributes:             testAttributes()[:2],
+		DroppedAttributesCount: 2,
+	}
+}
+
+func testResourceSpans() []*otlptrace.ResourceSpans {
+	return []*otlptrace.ResourceSpans{
+		{
+			Resource: testResource(),
+			ScopeSpans: []*otlptrace.ScopeSpans{
+				{
+					Scope: testScope(),
+					Spans: []*otlptrace.Span{
+						{
+							TraceId:                testTraceID(),
+							SpanId:                 testSpanID(),
+							TraceState:             "key=value",
+							ParentSpanId:           testSpanID(),
+							Flags:                  1,
+							Name:                   "span",
+							Kind:                   otlptrace.Span_SPAN_KIND_SERVER,
+							StartTimeUnixNano:      1,
+							EndTimeUnixNano:        math.MaxUint64,
+							Attributes:             testAttributes(),
+							DroppedAttributesCount: 3,
+							Events: []*otlptrace.Span_Event{
+								{TimeUnixNano: 3, Name: "event", Attributes: testAttributes()[:1], DroppedAttributesCount: 4},
+								nil,
+							},
+							DroppedEventsCount: 5,
+							Links: []*otlptrace.Span_Link{
+								{TraceId: testTraceID(), SpanId: testSpanID(), TraceState: "link", Attributes: testAttributes()[1:2], DroppedAttributesCount: 6, Flags: 2},
+							},
+							DroppedLinksCount: 7,
+							Status:            otlptrace.Status{Message: "error", Code: otlptrace.Status_STATUS_CODE_ERROR},
+						},
+						{Name: "empty", Attributes: []otlpcommon.KeyValue{}},
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			DeprecatedScopeSpans: []*otlptrace.ScopeSpans{
+				{Spans: []*otlptrace.Span{{Name: "deprecated"}}},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestMarshalTraces(t *testing.T) {
+	testMarshal(t, &otlptrace.TracesData{}, MarshalTracesData)
+	testMarshal(t, &otlptrace.TracesData{ResourceSpans: testResourceSpans()}, MarshalTracesData)
+	testMarshal(t, &otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: testResourceSpans()}, MarshalExportTraceServiceRequest)
+	testMarshal(t, &otlpcollectortrace.ExportTraceServiceResponse{}, MarshalExportTraceServiceResponse)
+	testMarshal(t, &otlpcollectortrace.ExportTraceServiceResponse{
+		PartialSuccess: otlpcollectortrace.ExportTracePartialSuccess{RejectedSpans: 1, ErrorMessage: "error"},
+	}, MarshalExportTraceServiceResponse)
+}
+
+func testExemplars() []otlpmetrics.Exemplar {
+	return []otlpmetrics.Exemplar{
+		{
+			FilteredAttributes: testAttributes()[:1],
+			TimeUnixNano:       1,
+			Value:              &otlpmetrics.Exemplar_AsDouble{AsDouble: 1.5},
+			SpanId:             testSpanID(),
+			TraceId:            testTraceID(),
+		},
+		{Value: &otlpmetrics.Exemplar_AsInt{AsInt: -3}},
+		{},
+	}
+}
+
+func testResourceMetrics() []*otlpmetrics.ResourceMetrics {
+	return []*otlpmetrics.ResourceMetrics{
+		{
+			Resource: testResource(),
+			ScopeMetrics: []*otlpmetrics.ScopeMetrics{
+				{
+					Scope: testScope(),
+					Metrics: []*otlpmetrics.Metric{
+						{
+							Name:        "gauge",
+							Description: "description",
+							Unit:        "1",
+							Metadata:    testAttributes()[:1],
+							Data: &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{
+								DataPoints: []*otlpmetrics.NumberDataPoint{
+									{
+										Attributes:        testAttributes(),
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Value:             &otlpmetrics.NumberDataPoint_AsDouble{AsDouble: math.Inf(-1)},
+										Exemplars:         testExemplars(),
+										Flags:             1,
+									},
+									{Value: &otlpmetrics.NumberDataPoint_AsInt{AsInt: -4}},
+									{Value: &otlpmetrics.NumberDataPoint_AsDouble{AsDouble: 0}},
+								},
+							}},
+						},
+						{
+							Name: "sum",
+							Data: &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{
+								DataPoints: []*otlpmetrics.NumberDataPoint{
+									{Value: &otlpmetrics.NumberDataPoint_AsInt{AsInt: 5}},
+								},
+								AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+								IsMonotonic:            true,
+							}},
+						},
+						{
+							Name: "histogram",
+							Data: &otlpmetrics.Metric_Histogram{Histogram: &otlpmetrics.Histogram{
+								DataPoints: []*otlpmetrics.HistogramDataPoint{
+									{
+										Attributes:        testAttributes()[1:3],
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Count:             6,
+										Sum_:              &otlpmetrics.HistogramDataPoint_Sum{Sum: 7.5},
+										BucketCounts:      []uint64{1, 2, 3},
+										ExplicitBounds:    []float64{0.5, math.Inf(1), 1e21},
+										Exemplars:         testExemplars(),
+										Flags:             1,
+										Min_:              &otlpmetrics.HistogramDataPoint_Min{Min: 0.25},
+										Max_:              &otlpmetrics.HistogramDataPoint_Max{Max: 2.5},
+									},
+									{BucketCounts: []uint64{}, ExplicitBounds: []float64{}},
+								},
+								AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+							}},
+						},
+						{
+							Name: "exponential_histogram",
+							Data: &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{
+								DataPoints: []*otlpmetrics.ExponentialHistogramDataPoint{
+									{
+										Attributes:        testAttributes()[3:4],
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Count:             6,
+										Sum_:              &otlpmetrics.ExponentialHistogramDataPoint_Sum{Sum: 7.5},
+										Scale:             -3,
+										ZeroCount:         1,
+										Positive:          otlpmetrics.ExponentialHistogramDataPoint_Buckets{Offset: -2, BucketCounts: []uint64{1, 300, 70000}},
+										Negative:          otlpmetrics.ExponentialHistogramDataPoint_Buckets{Offset: 5, BucketCounts: []uint64{4}},
+										Flags:             1,
+										Exemplars:         testExemplars(),
+										Min_:              &otlpmetrics.ExponentialHistogramDataPoint_Min{Min: -1},
+										Max_:              &otlpmetrics.ExponentialHistogramDataPoint_Max{Max: 3},
+										ZeroThreshold:     0.001,
+									},
+									{},
+								},
+								AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+							}},
+						},
+						{
+							Name: "summary",
+							Data: &otlpmetrics.Metric_Summary{Summary: &otlpmetrics.Summary{
+								DataPoints: []*otlpmetrics.SummaryDataPoint{
+									{
+										Attributes:        testAttributes()[4:5],
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Count:             3,
+										Sum:               4.5,
+										QuantileValues: []*otlpmetrics.SummaryDataPoint_ValueAtQuantile{
+											{Quantile: 0.5, Value: 1},
+											{Quantile: 0.99, Value: 2},
+											{},
+										},
+										Flags: 1,
+									},
+								},
+							}},
+						},
+						{Name: "nil_gauge", Data: &otlpmetrics.Metric_Gauge{}},
+						{Name: "empty"},
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			DeprecatedScopeMetrics: []*otlpmetrics.ScopeMetrics{
+				{Metrics: []*otlpmetrics.Metric{{Name: "deprecated"}}},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestMarshalMetrics(t *testing.T) {
+	testMarshal(t, &otlpmetrics.MetricsData{}, MarshalMetricsData)
+	testMarshal(t, &otlpmetrics.MetricsData{ResourceMetrics: testResourceMetrics()}, MarshalMetricsData)
+	testMarshal(t, &otlpcollectormetrics.ExportMetricsServiceRequest{ResourceMetrics: testResourceMetrics()}, MarshalExportMetricsServiceRequest)
+	testMarshal(t, &otlpcollectormetrics.ExportMetricsServiceResponse{}, MarshalExportMetricsServiceResponse)
+	testMarshal(t, &otlpcollectormetrics.ExportMetricsServiceResponse{
+		PartialSuccess: otlpcollectormetrics.ExportMetricsPartialSuccess{RejectedDataPoints: 1, ErrorMessage: "error"},
+	}, MarshalExportMetricsServiceResponse)
+}
+
+func testResourceLogs() []*otlplogs.ResourceLogs {
+	return []*otlplogs.ResourceLogs{
+		{
+			Resource: testResource(),
+			ScopeLogs: []*otlplogs.ScopeLogs{
+				{
+					Scope: testScope(),
+					LogRecords: []*otlplogs.LogRecord{
+						{
+							TimeUnixNano:           1,
+							ObservedTimeUnixNano:   2,
+							SeverityNumber:         otlplogs.SeverityNumber_SEVERITY_NUMBER_WARN,
+							SeverityText:           "warn",
+							Body:                   otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "body"}},
+							Attributes:             testAttributes(),
+							DroppedAttributesCount: 3,
+							Flags:                  1,
+							TraceId:                testTraceID(),
+							SpanId:                 testSpanID(),
+							EventName:              "event",
+						},
+						{Body: testAttributes()[6].Value},
+						nil,
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			DeprecatedScopeLogs: []*otlplogs.ScopeLogs{
+				{LogRecords: []*otlplogs.LogRecord{{SeverityText: "deprecated"}}},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestMarshalLogs(t *testing.T) {
+	testMarshal(t, &otlplogs.LogsData{}, MarshalLogsData)
+	testMarshal(t, &otlplogs.LogsData{ResourceLogs: testResourceLogs()}, MarshalLogsData)
+	testMarshal(t, &otlpcollectorlogs.ExportLogsServiceRequest{ResourceLogs: testResourceLogs()}, MarshalExportLogsServiceRequest)
+	testMarshal(t, &otlpcollectorlogs.ExportLogsServiceResponse{}, MarshalExportLogsServiceResponse)
+	testMarshal(t, &otlpcollectorlogs.ExportLogsServiceResponse{
+		PartialSuccess: otlpcollectorlogs.ExportLogsPartialSuccess{RejectedLogRecords: 1, ErrorMessage: "error"},
+	}, MarshalExportLogsServiceResponse)
+}
+
+func testResourceProfiles() []*otlpprofiles.ResourceProfiles {
+	return []*otlpprofiles.ResourceProfiles{
+		{
+			Resource: testResource(),
+			ScopeProfiles: []*otlpprofiles.ScopeProfiles{
+				{
+					Scope: testScope(),
+					Profiles: []*otlpprofiles.Profile{
+						{
+							SampleType: []*otlpprofiles.ValueType{
+								{TypeStrindex: 1, UnitStrindex: 2, AggregationTemporality: otlpprofiles.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA},
+							},
+							Sample: []*otlpprofiles.Sample{
+								{
+									LocationsStartIndex: 1,
+									LocationsLength:     2,
+									Value:               []int64{-1, 2},
+									AttributeIndices:    []int32{0, 1},
+									LinkIndex_:          &otlpprofiles.Sample_LinkIndex{LinkIndex: 0},
+									TimestampsUnixNano:  []uint64{3},
+								},
+								{Value: []int64{}},
+							},
+							MappingTable: []*otlpprofiles.Mapping{
+								{
+									MemoryStart:      1,
+									MemoryLimit:      2,
+									FileOffset:       3,
+									FilenameStrindex: 4,
+									AttributeIndices: []int32{5},
+									HasFunctions:     true,
+									HasFilenames:     true,
+									HasLineNumbers:   true,
+									HasInlineFrames:  true,
+								},
+							},
+							LocationTable: []*otlpprofiles.Location{
+								{
+									MappingIndex_:    &otlpprofiles.Location_MappingIndex{MappingIndex: 1},
+									Address:          2,
+									Line:             []*otlpprofiles.Line{{FunctionIndex: 1, Line: 2, Column: 3}, {}},
+									IsFolded:         true,
+									AttributeIndices: []int32{4},
+								},
+							},
+							LocationIndices: []int32{1, 2},
+							FunctionTable: []*otlpprofiles.Function{
+								{NameStrindex: 1, SystemNameStrindex: 2, FilenameStrindex: 3, StartLine: 4},
+							},
+							AttributeTable:            testAttributes(),
+							AttributeUnits:            []*otlpprofiles.AttributeUnit{{AttributeKeyStrindex: 1, UnitStrindex: 2}},
+							LinkTable:                 []*otlpprofiles.Link{{TraceId: testTraceID(), SpanId: testSpanID()}, {}},
+							StringTable:               []string{"", "string", "<html>"},
+							TimeNanos:                 1,
+							DurationNanos:             2,
+							PeriodType:                otlpprofiles.ValueType{TypeStrindex: 1},
+							Period:                    3,
+							CommentStrindices:         []int32{1},
+							DefaultSampleTypeStrindex: 1,
+							ProfileId:                 data.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}),
+							DroppedAttributesCount:    1,
+							OriginalPayloadFormat:     "pprof",
+							OriginalPayload:           []byte{1, 2, 3},
+							AttributeIndices:          []int32{1},
+						},
+						{OriginalPayload: []byte{}},
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestMarshalProfiles(t *testing.T) {
+	testMarshal(t, &otlpprofiles.ProfilesData{}, MarshalProfilesData)
+	testMarshal(t, &otlpprofiles.ProfilesData{ResourceProfiles: testResourceProfiles()}, MarshalProfilesData)
+	testMarshal(t, &otlpcollectorprofiles.ExportProfilesServiceRequest{ResourceProfiles: testResourceProfiles()}, MarshalExportProfilesServiceRequest)
+	testMarshal(t, &otlpcollectorprofiles.ExportProfilesServiceResponse{}, MarshalExportProfilesServiceResponse)
+	testMarshal(t, &otlpcollectorprofiles.ExportProfilesServiceResponse{
+		PartialSuccess: otlpcollectorprofiles.ExportProfilesPartialSuccess{RejectedProfiles: 1, ErrorMessage: "error"},
+	}, MarshalExportProfilesServiceResponse)
+}
+
+func BenchmarkMarshalTracesData(b *testing.B) {
+	orig := &otlptrace.TracesData{ResourceSpans: testResourceSpans()}
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		require.NoError(b, MarshalTracesData(&buf, orig))
+	}
+}
+
+func BenchmarkMarshalTracesDataJSONPB(b *testing.B) {
+	orig := &otlptrace.TracesData{ResourceSpans: testResourceSpans()}
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		require.NoError(b, jsonpbMarshaler.Marshal(&buf, orig))
+	}
+}
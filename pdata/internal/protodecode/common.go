@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode // import "go.opentelemetry.io/collector/pdata/internal/protodecode"
+
+import (
+	"math"
+	"slices"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+)
+
+func (d *decoder) decodeAnyValue(buf []byte, av *otlpcommon.AnyValue) error {
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "AnyValue")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "StringValue")
+			if err != nil {
+				return err
+			}
+			v := d.stringValues.next(slabSize)
+			v.StringValue = string(buf[start:end])
+			av.Value = v
+			i = end
+		case 2:
+			b, end, err := varintField(buf, next, wireType, "BoolValue")
+			if err != nil {
+				return err
+			}
+			v := d.boolValues.next(slabSize)
+			v.BoolValue = b != 0
+			av.Value = v
+			i = end
+		case 3:
+			n, end, err := varintField(buf, next, wireType, "IntValue")
+			if err != nil {
+				return err
+			}
+			v := d.intValues.next(slabSize)
+			v.IntValue = int64(n)
+			av.Value = v
+			i = end
+		case 4:
+			f, end, err := fixed64Field(buf, next, wireType, "DoubleValue")
+			if err != nil {
+				return err
+			}
+			v := d.doubleValues.next(slabSize)
+			v.DoubleValue = math.Float64frombits(f)
+			av.Value = v
+			i = end
+		case 5:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ArrayValue")
+			if err != nil {
+				return err
+			}
+			v := &otlpcommon.ArrayValue{}
+			if err = d.decodeArrayValue(buf[start:end], v); err != nil {
+				return err
+			}
+			av.Value = &otlpcommon.AnyValue_ArrayValue{ArrayValue: v}
+			i = end
+		case 6:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "KvlistValue")
+			if err != nil {
+				return err
+			}
+			v := &otlpcommon.KeyValueList{}
+			if err = d.decodeKeyValueList(buf[start:end], v); err != nil {
+				return err
+			}
+			av.Value = &otlpcommon.AnyValue_KvlistValue{KvlistValue: v}
+			i = end
+		case 7:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "BytesValue")
+			if err != nil {
+				return err
+			}
+			v := make([]byte, end-start)
+			copy(v, buf[start:end])
+			av.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: v}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeArrayValue(buf []byte, av *otlpcommon.ArrayValue) error {
+	counts := countFields(buf, 1)
+	av.Values = slices.Grow(av.Values, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ArrayValue")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Values")
+			if err != nil {
+				return err
+			}
+			av.Values = append(av.Values, otlpcommon.AnyValue{})
+			if err = d.decodeAnyValue(buf[start:end], &av.Values[len(av.Values)-1]); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeKeyValueList(buf []byte, kvl *otlpcommon.KeyValueList) error {
+	counts := countFields(buf, 1)
+	kvl.Values = slices.Grow(kvl.Values, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "KeyValueList")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Values")
+			if err != nil {
+				return err
+			}
+			if kvl.Values, err = d.appendKeyValue(buf[start:end], kvl.Values); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendKeyValue decodes a key value, and appends it to the given attributes.
+func (d *decoder) appendKeyValue(buf []byte, attrs []otlpcommon.KeyValue) ([]otlpcommon.KeyValue, error) {
+	attrs = append(attrs, otlpcommon.KeyValue{})
+	return attrs, d.decodeKeyValue(buf, &attrs[len(attrs)-1])
+}
+
+func (d *decoder) decodeKeyValue(buf []byte, kv *otlpcommon.KeyValue) error {
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "KeyValue")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Key")
+			if err != nil {
+				return err
+			}
+			kv.Key = string(buf[start:end])
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Value")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeAnyValue(buf[start:end], &kv.Value); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeInstrumentationScope(buf []byte, scope *otlpcommon.InstrumentationScope) error {
+	counts := countFields(buf, 3)
+	scope.Attributes = slices.Grow(scope.Attributes, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "InstrumentationScope")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Name")
+			if err != nil {
+				return err
+			}
+			scope.Name = string(buf[start:end])
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Version")
+			if err != nil {
+				return err
+			}
+			scope.Version = string(buf[start:end])
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if scope.Attributes, err = d.appendKeyValue(buf[start:end], scope.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 4:
+			v, end, err := varintField(buf, next, wireType, "DroppedAttributesCount")
+			if err != nil {
+				return err
+			}
+			scope.DroppedAttributesCount = uint32(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeResource(buf []byte, resource *otlpresource.Resource) error {
+	counts := countFields(buf, 1)
+	resource.Attributes = slices.Grow(resource.Attributes, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Resource")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if resource.Attributes, err = d.appendKeyValue(buf[start:end], resource.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			v, end, err := varintField(buf, next, wireType, "DroppedAttributesCount")
+			if err != nil {
+				return err
+			}
+			resource.DroppedAttributesCount = uint32(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package protodecode implements a streaming decoder of the OTLP protobuf messages into the generated structs
+// backing pdata. It decodes the same messages as the generated code, with the same errors, but allocates less:
+// the repeated fields are counted before being decoded so that their values are allocated at once, and the
+// oneof wrappers of the attribute values are allocated in slabs reused across the decoded messages.
+package protodecode // import "go.opentelemetry.io/collector/pdata/internal/protodecode"
+
+import (
+	"sync"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+var decoderPool = sync.Pool{
+	New: func() any {
+		return &decoder{}
+	},
+}
+
+// decoder holds the slabs of the most frequent oneof values. The values left in the slabs once a message is
+// decoded are used by the next messages, the decoders are pooled for that purpose.
+type decoder struct {
+	stringValues slab[otlpcommon.AnyValue_StringValue]
+	intValues    slab[otlpcommon.AnyValue_IntValue]
+	doubleValues slab[otlpcommon.AnyValue_DoubleValue]
+	boolValues   slab[otlpcommon.AnyValue_BoolValue]
+
+	numberDoubleValues slab[otlpmetrics.NumberDataPoint_AsDouble]
+	numberIntValues    slab[otlpmetrics.NumberDataPoint_AsInt]
+}
+
+func getDecoder() *decoder {
+	return decoderPool.Get().(*decoder)
+}
+
+func putDecoder(d *decoder) {
+	decoderPool.Put(d)
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal/data"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+)
+
+type message interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// testEquivalence checks that unmarshal decodes the marshaled orig, and all the truncated and corrupted
+// variants of it, the same way as the generated code.
+func testEquivalence[T any, PT interface {
+	*T
+	message
+}](t *testing.T, orig PT, unmarshal func([]byte, PT) error,
+) {
+	buf, err := orig.Marshal()
+	require.NoError(t, err)
+
+	got := PT(new(T))
+	require.NoError(t, unmarshal(buf, got))
+	gotBuf, err := got.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, buf, gotBuf)
+
+	for _, variant := range variants(buf) {
+		want := PT(new(T))
+		wantErr := want.Unmarshal(variant)
+		got := PT(new(T))
+		gotErr := unmarshal(variant, got)
+		if wantErr != nil {
+			require.EqualError(t, gotErr, wantErr.Error(), "input %x", variant)
+			continue
+		}
+		require.NoError(t, gotErr, "input %x", variant)
+		wantBuf, err := want.Marshal()
+		require.NoError(t, err)
+		gotBuf, err := got.Marshal()
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(wantBuf, gotBuf), "input %x", variant)
+	}
+}
+
+// variants returns the truncations of buf, buf with unknown fields, and buf with each of its bytes replaced.
+func variants(buf []byte) [][]byte {
+	var res [][]byte
+	for i := range buf {
+		res = append(res, buf[:i])
+	}
+	unknown := []byte{
+		0xf8, 0x07, 0x01, // field 127, varint
+		0xf9, 0x07, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, // field 127, fixed64
+		0xfa, 0x07, 0x02, 0x01, 0x02, // field 127, length-delimited
+		0xfb, 0x07, 0x08, 0x01, 0xfc, 0x07, // field 127, group
+		0xfd, 0x07, 0x01, 0x02, 0x03, 0x04, // field 127, fixed32
+	}
+	res = append(res, append(bytes.Clone(buf), unknown...), append(bytes.Clone(unknown), buf...))
+	for i := range buf {
+		for _, b := range []byte{0x00, 0x01, 0x07, 0x7f, 0x80, 0xff, buf[i] ^ 0x07} {
+			variant := bytes.Clone(buf)
+			variant[i] = b
+			res = append(res, variant)
+		}
+	}
+	return res
+}
+
+func testTraceID() data.TraceID {
+	return data.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+}
+
+func testSpanID() data.SpanID {
+	return data.SpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1})
+}
+
+func testAttributes() []otlpcommon.KeyValue {
+	return []otlpcommon.KeyValue{
+		{Key: "string", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "value"}}},
+		{Key: "bool", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: true}}},
+		{Key: "int", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: -42}}},
+		{Key: "double", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: math.Pi}}},
+		{Key: "bytes", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: []byte{1, 2, 3}}}},
+		{Key: "array", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{
+			Values: []otlpcommon.AnyValue{
+				{Value: &otlpcommon.AnyValue_StringValue{StringValue: "element"}},
+				{Value: &otlpcommon.AnyValue_IntValue{IntValue: 7}},
+				{},
+			},
+		}}}},
+		{Key: "kvlist", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{
+			Values: []otlpcommon.KeyValue{
+				{Key: "nested", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: 1.5}}},
+			},
+		}}}},
+		{Key: "empty"},
+	}
+}
+
+func testResource() otlpresource.Resource {
+	return otlpresource.Resource{
+		Attributes:             testAttributes(),
+		DroppedAttributesCount: 1,
+	}
+}
+
+func testScope() otlpcommon.InstrumentationScope {
+	return otlpcommon.InstrumentationScope{
+		Name:                   "scope",
+		Version:                "v1.0.0",
+		Attributes:             testAttributes()[:2],
+		DroppedAttributesCount: 2,
+	}
+}
+
+func TestDecoderReuse(t *testing.T) {
+	kv := &otlpcommon.KeyValue{Key: "key", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "value"}}}
+	buf, err := kv.Marshal()
+	require.NoError(t, err)
+
+	d := getDecoder()
+	first := otlpcommon.KeyValue{}
+	require.NoError(t, d.decodeKeyValue(buf, &first))
+	putDecoder(d)
+
+	d = getDecoder()
+	second := otlpcommon.KeyValue{}
+	require.NoError(t, d.decodeKeyValue(buf, &second))
+	putDecoder(d)
+
+	require.Equal(t, *kv, first)
+	require.Equal(t, *kv, second)
+	require.NotSame(t, first.Value.Value, second.Value.Value)
+}
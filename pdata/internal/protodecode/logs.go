@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode // import "go.opentelemetry.io/collector/pdata/internal/protodecode"
+
+import (
+	"slices"
+
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+)
+
+// UnmarshalLogsData unmarshals the protobuf bytes of a LogsData into ld, like ld.Unmarshal does.
+func UnmarshalLogsData(buf []byte, ld *otlplogs.LogsData) error {
+	d := getDecoder()
+	defer putDecoder(d)
+	return d.decodeLogs(buf, &ld.ResourceLogs, "LogsData")
+}
+
+// UnmarshalExportLogsServiceRequest unmarshals the protobuf bytes of an ExportLogsServiceRequest into req,
+// like req.Unmarshal does.
+func UnmarshalExportLogsServiceRequest(buf []byte, req *otlpcollectorlogs.ExportLogsServiceRequest) error {
+	d := getDecoder()
+	defer putDecoder(d)
+	return d.decodeLogs(buf, &req.ResourceLogs, "ExportLogsServiceRequest")
+}
+
+// decodeLogs decodes the LogsData and ExportLogsServiceRequest messages, which have the same fields.
+func (d *decoder) decodeLogs(buf []byte, resourceLogs *[]*otlplogs.ResourceLogs, name string) error {
+	counts := countFields(buf, 1)
+	*resourceLogs = slices.Grow(*resourceLogs, counts[0])
+	values := make(slab[otlplogs.ResourceLogs], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, name)
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ResourceLogs")
+			if err != nil {
+				return err
+			}
+			rl := values.next(0)
+			*resourceLogs = append(*resourceLogs, rl)
+			if err = d.decodeResourceLogs(buf[start:end], rl); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeResourceLogs(buf []byte, rl *otlplogs.ResourceLogs) error {
+	counts := countFields(buf, 2, 1000)
+	rl.ScopeLogs = slices.Grow(rl.ScopeLogs, counts[0])
+	rl.DeprecatedScopeLogs = slices.Grow(rl.DeprecatedScopeLogs, counts[1])
+	values := make(slab[otlplogs.ScopeLogs], counts[0]+counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ResourceLogs")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Resource")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeResource(buf[start:end], &rl.Resource); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ScopeLogs")
+			if err != nil {
+				return err
+			}
+			sl := values.next(0)
+			rl.ScopeLogs = append(rl.ScopeLogs, sl)
+			if err = d.decodeScopeLogs(buf[start:end], sl); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SchemaUrl")
+			if err != nil {
+				return err
+			}
+			rl.SchemaUrl = string(buf[start:end])
+			i = end
+		case 1000:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DeprecatedScopeLogs")
+			if err != nil {
+				return err
+			}
+			sl := values.next(0)
+			rl.DeprecatedScopeLogs = append(rl.DeprecatedScopeLogs, sl)
+			if err = d.decodeScopeLogs(buf[start:end], sl); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeScopeLogs(buf []byte, sl *otlplogs.ScopeLogs) error {
+	counts := countFields(buf, 2)
+	sl.LogRecords = slices.Grow(sl.LogRecords, counts[0])
+	values := make(slab[otlplogs.LogRecord], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ScopeLogs")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Scope")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeInstrumentationScope(buf[start:end], &sl.Scope); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "LogRecords")
+			if err != nil {
+				return err
+			}
+			lr := values.next(0)
+			sl.LogRecords = append(sl.LogRecords, lr)
+			if err = d.decodeLogRecord(buf[start:end], lr); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SchemaUrl")
+			if err != nil {
+				return err
+			}
+			sl.SchemaUrl = string(buf[start:end])
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeLogRecord(buf []byte, lr *otlplogs.LogRecord) error {
+	counts := countFields(buf, 6)
+	lr.Attributes = slices.Grow(lr.Attributes, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "LogRecord")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			lr.TimeUnixNano = v
+			i = end
+		case 2:
+			v, end, err := varintField(buf, next, wireType, "SeverityNumber")
+			if err != nil {
+				return err
+			}
+			lr.SeverityNumber = otlplogs.SeverityNumber(v)
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SeverityText")
+			if err != nil {
+				return err
+			}
+			lr.SeverityText = string(buf[start:end])
+			i = end
+		case 5:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Body")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeAnyValue(buf[start:end], &lr.Body); err != nil {
+				return err
+			}
+			i = end
+		case 6:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if lr.Attributes, err = d.appendKeyValue(buf[start:end], lr.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 7:
+			v, end, err := varintField(buf, next, wireType, "DroppedAttributesCount")
+			if err != nil {
+				return err
+			}
+			lr.DroppedAttributesCount = uint32(v)
+			i = end
+		case 8:
+			v, end, err := fixed32Field(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			lr.Flags = v
+			i = end
+		case 9:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "TraceId")
+			if err != nil {
+				return err
+			}
+			if err = lr.TraceId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 10:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SpanId")
+			if err != nil {
+				return err
+			}
+			if err = lr.SpanId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 11:
+			v, end, err := fixed64Field(buf, next, wireType, "ObservedTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			lr.ObservedTimeUnixNano = v
+			i = end
+		case 12:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "EventName")
+			if err != nil {
+				return err
+			}
+			lr.EventName = string(buf[start:end])
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
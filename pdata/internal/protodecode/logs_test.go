@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode
+
+import (
+	"testing"
+
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+)
+
+func testResourceLogs() []*otlplogs.ResourceLogs {
+	return []*otlplogs.ResourceLogs{
+		{
+			Resource: testResource(),
+			ScopeLogs: []*otlplogs.ScopeLogs{
+				{
+					Scope: testScope(),
+					LogRecords: []*otlplogs.LogRecord{
+						{
+							TimeUnixNano:           1,
+							ObservedTimeUnixNano:   2,
+							SeverityNumber:         otlplogs.SeverityNumber_SEVERITY_NUMBER_WARN,
+							SeverityText:           "warn",
+							Body:                   otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "body"}},
+							Attributes:             testAttributes(),
+							DroppedAttributesCount: 3,
+							Flags:                  1,
+							TraceId:                testTraceID(),
+							SpanId:                 testSpanID(),
+							EventName:              "event",
+						},
+						{Body: testAttributes()[6].Value},
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			DeprecatedScopeLogs: []*otlplogs.ScopeLogs{
+				{LogRecords: []*otlplogs.LogRecord{{SeverityText: "deprecated"}}},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestUnmarshalLogsData(t *testing.T) {
+	testEquivalence(t, &otlplogs.LogsData{ResourceLogs: testResourceLogs()}, UnmarshalLogsData)
+}
+
+func TestUnmarshalExportLogsServiceRequest(t *testing.T) {
+	testEquivalence(t, &otlpcollectorlogs.ExportLogsServiceRequest{ResourceLogs: testResourceLogs()}, UnmarshalExportLogsServiceRequest)
+}
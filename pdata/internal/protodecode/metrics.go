@@ -0,0 +1,952 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode // import "go.opentelemetry.io/collector/pdata/internal/protodecode"
+
+import (
+	"math"
+	"slices"
+
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+// UnmarshalMetricsData unmarshals the protobuf bytes of a MetricsData into md, like md.Unmarshal does.
+func UnmarshalMetricsData(buf []byte, md *otlpmetrics.MetricsData) error {
+	d := getDecoder()
+	defer putDecoder(d)
+	return d.decodeMetrics(buf, &md.ResourceMetrics, "MetricsData")
+}
+
+// UnmarshalExportMetricsServiceRequest unmarshals the protobuf bytes of an ExportMetricsServiceRequest into req,
+// like req.Unmarshal does.
+func UnmarshalExportMetricsServiceRequest(buf []byte, req *otlpcollectormetrics.ExportMetricsServiceRequest) error {
+	d := getDecoder()
+	defer putDecoder(d)
+	return d.decodeMetrics(buf, &req.ResourceMetrics, "ExportMetricsServiceRequest")
+}
+
+// decodeMetrics decodes the MetricsData and ExportMetricsServiceRequest messages, which have the same fields.
+func (d *decoder) decodeMetrics(buf []byte, resourceMetrics *[]*otlpmetrics.ResourceMetrics, name string) error {
+	counts := countFields(buf, 1)
+	*resourceMetrics = slices.Grow(*resourceMetrics, counts[0])
+	values := make(slab[otlpmetrics.ResourceMetrics], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, name)
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ResourceMetrics")
+			if err != nil {
+				return err
+			}
+			rm := values.next(0)
+			*resourceMetrics = append(*resourceMetrics, rm)
+			if err = d.decodeResourceMetrics(buf[start:end], rm); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeResourceMetrics(buf []byte, rm *otlpmetrics.ResourceMetrics) error {
+	counts := countFields(buf, 2, 1000)
+	rm.ScopeMetrics = slices.Grow(rm.ScopeMetrics, counts[0])
+	rm.DeprecatedScopeMetrics = slices.Grow(rm.DeprecatedScopeMetrics, counts[1])
+	values := make(slab[otlpmetrics.ScopeMetrics], counts[0]+counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ResourceMetrics")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Resource")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeResource(buf[start:end], &rm.Resource); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ScopeMetrics")
+			if err != nil {
+				return err
+			}
+			sm := values.next(0)
+			rm.ScopeMetrics = append(rm.ScopeMetrics, sm)
+			if err = d.decodeScopeMetrics(buf[start:end], sm); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SchemaUrl")
+			if err != nil {
+				return err
+			}
+			rm.SchemaUrl = string(buf[start:end])
+			i = end
+		case 1000:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DeprecatedScopeMetrics")
+			if err != nil {
+				return err
+			}
+			sm := values.next(0)
+			rm.DeprecatedScopeMetrics = append(rm.DeprecatedScopeMetrics, sm)
+			if err = d.decodeScopeMetrics(buf[start:end], sm); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeScopeMetrics(buf []byte, sm *otlpmetrics.ScopeMetrics) error {
+	counts := countFields(buf, 2)
+	sm.Metrics = slices.Grow(sm.Metrics, counts[0])
+	values := make(slab[otlpmetrics.Metric], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ScopeMetrics")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Scope")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeInstrumentationScope(buf[start:end], &sm.Scope); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Metrics")
+			if err != nil {
+				return err
+			}
+			metric := values.next(0)
+			sm.Metrics = append(sm.Metrics, metric)
+			if err = d.decodeMetric(buf[start:end], metric); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SchemaUrl")
+			if err != nil {
+				return err
+			}
+			sm.SchemaUrl = string(buf[start:end])
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeMetric(buf []byte, metric *otlpmetrics.Metric) error {
+	counts := countFields(buf, 12)
+	metric.Metadata = slices.Grow(metric.Metadata, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Metric")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Name")
+			if err != nil {
+				return err
+			}
+			metric.Name = string(buf[start:end])
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Description")
+			if err != nil {
+				return err
+			}
+			metric.Description = string(buf[start:end])
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Unit")
+			if err != nil {
+				return err
+			}
+			metric.Unit = string(buf[start:end])
+			i = end
+		case 5:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Gauge")
+			if err != nil {
+				return err
+			}
+			v := &otlpmetrics.Gauge{}
+			if err = d.decodeGauge(buf[start:end], v); err != nil {
+				return err
+			}
+			metric.Data = &otlpmetrics.Metric_Gauge{Gauge: v}
+			i = end
+		case 7:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Sum")
+			if err != nil {
+				return err
+			}
+			v := &otlpmetrics.Sum{}
+			if err = d.decodeSum(buf[start:end], v); err != nil {
+				return err
+			}
+			metric.Data = &otlpmetrics.Metric_Sum{Sum: v}
+			i = end
+		case 9:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Histogram")
+			if err != nil {
+				return err
+			}
+			v := &otlpmetrics.Histogram{}
+			if err = d.decodeHistogram(buf[start:end], v); err != nil {
+				return err
+			}
+			metric.Data = &otlpmetrics.Metric_Histogram{Histogram: v}
+			i = end
+		case 10:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ExponentialHistogram")
+			if err != nil {
+				return err
+			}
+			v := &otlpmetrics.ExponentialHistogram{}
+			if err = d.decodeExponentialHistogram(buf[start:end], v); err != nil {
+				return err
+			}
+			metric.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: v}
+			i = end
+		case 11:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Summary")
+			if err != nil {
+				return err
+			}
+			v := &otlpmetrics.Summary{}
+			if err = d.decodeSummary(buf[start:end], v); err != nil {
+				return err
+			}
+			metric.Data = &otlpmetrics.Metric_Summary{Summary: v}
+			i = end
+		case 12:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Metadata")
+			if err != nil {
+				return err
+			}
+			if metric.Metadata, err = d.appendKeyValue(buf[start:end], metric.Metadata); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeGauge(buf []byte, gauge *otlpmetrics.Gauge) error {
+	counts := countFields(buf, 1)
+	gauge.DataPoints = slices.Grow(gauge.DataPoints, counts[0])
+	values := make(slab[otlpmetrics.NumberDataPoint], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Gauge")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DataPoints")
+			if err != nil {
+				return err
+			}
+			dp := values.next(0)
+			gauge.DataPoints = append(gauge.DataPoints, dp)
+			if err = d.decodeNumberDataPoint(buf[start:end], dp); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSum(buf []byte, sum *otlpmetrics.Sum) error {
+	counts := countFields(buf, 1)
+	sum.DataPoints = slices.Grow(sum.DataPoints, counts[0])
+	values := make(slab[otlpmetrics.NumberDataPoint], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Sum")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DataPoints")
+			if err != nil {
+				return err
+			}
+			dp := values.next(0)
+			sum.DataPoints = append(sum.DataPoints, dp)
+			if err = d.decodeNumberDataPoint(buf[start:end], dp); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			v, end, err := varintField(buf, next, wireType, "AggregationTemporality")
+			if err != nil {
+				return err
+			}
+			sum.AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+			i = end
+		case 3:
+			v, end, err := varintField(buf, next, wireType, "IsMonotonic")
+			if err != nil {
+				return err
+			}
+			sum.IsMonotonic = v != 0
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeHistogram(buf []byte, histogram *otlpmetrics.Histogram) error {
+	counts := countFields(buf, 1)
+	histogram.DataPoints = slices.Grow(histogram.DataPoints, counts[0])
+	values := make(slab[otlpmetrics.HistogramDataPoint], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Histogram")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DataPoints")
+			if err != nil {
+				return err
+			}
+			dp := values.next(0)
+			histogram.DataPoints = append(histogram.DataPoints, dp)
+			if err = d.decodeHistogramDataPoint(buf[start:end], dp); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			v, end, err := varintField(buf, next, wireType, "AggregationTemporality")
+			if err != nil {
+				return err
+			}
+			histogram.AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeExponentialHistogram(buf []byte, histogram *otlpmetrics.ExponentialHistogram) error {
+	counts := countFields(buf, 1)
+	histogram.DataPoints = slices.Grow(histogram.DataPoints, counts[0])
+	values := make(slab[otlpmetrics.ExponentialHistogramDataPoint], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ExponentialHistogram")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DataPoints")
+			if err != nil {
+				return err
+			}
+			dp := values.next(0)
+			histogram.DataPoints = append(histogram.DataPoints, dp)
+			if err = d.decodeExponentialHistogramDataPoint(buf[start:end], dp); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			v, end, err := varintField(buf, next, wireType, "AggregationTemporality")
+			if err != nil {
+				return err
+			}
+			histogram.AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSummary(buf []byte, summary *otlpmetrics.Summary) error {
+	counts := countFields(buf, 1)
+	summary.DataPoints = slices.Grow(summary.DataPoints, counts[0])
+	values := make(slab[otlpmetrics.SummaryDataPoint], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Summary")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DataPoints")
+			if err != nil {
+				return err
+			}
+			dp := values.next(0)
+			summary.DataPoints = append(summary.DataPoints, dp)
+			if err = d.decodeSummaryDataPoint(buf[start:end], dp); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeNumberDataPoint(buf []byte, dp *otlpmetrics.NumberDataPoint) error {
+	counts := countFields(buf, 7, 5)
+	dp.Attributes = slices.Grow(dp.Attributes, counts[0])
+	dp.Exemplars = slices.Grow(dp.Exemplars, counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "NumberDataPoint")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 2:
+			v, end, err := fixed64Field(buf, next, wireType, "StartTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.StartTimeUnixNano = v
+			i = end
+		case 3:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.TimeUnixNano = v
+			i = end
+		case 4:
+			f, end, err := fixed64Field(buf, next, wireType, "AsDouble")
+			if err != nil {
+				return err
+			}
+			v := d.numberDoubleValues.next(slabSize)
+			v.AsDouble = math.Float64frombits(f)
+			dp.Value = v
+			i = end
+		case 5:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Exemplars")
+			if err != nil {
+				return err
+			}
+			if dp.Exemplars, err = d.appendExemplar(buf[start:end], dp.Exemplars); err != nil {
+				return err
+			}
+			i = end
+		case 6:
+			n, end, err := fixed64Field(buf, next, wireType, "AsInt")
+			if err != nil {
+				return err
+			}
+			v := d.numberIntValues.next(slabSize)
+			v.AsInt = int64(n)
+			dp.Value = v
+			i = end
+		case 7:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if dp.Attributes, err = d.appendKeyValue(buf[start:end], dp.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 8:
+			v, end, err := varintField(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			dp.Flags = uint32(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeHistogramDataPoint(buf []byte, dp *otlpmetrics.HistogramDataPoint) error {
+	counts := countFields(buf, 9, 8)
+	dp.Attributes = slices.Grow(dp.Attributes, counts[0])
+	dp.Exemplars = slices.Grow(dp.Exemplars, counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "HistogramDataPoint")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 2:
+			v, end, err := fixed64Field(buf, next, wireType, "StartTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.StartTimeUnixNano = v
+			i = end
+		case 3:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.TimeUnixNano = v
+			i = end
+		case 4:
+			v, end, err := fixed64Field(buf, next, wireType, "Count")
+			if err != nil {
+				return err
+			}
+			dp.Count = v
+			i = end
+		case 5:
+			v, end, err := fixed64Field(buf, next, wireType, "Sum")
+			if err != nil {
+				return err
+			}
+			dp.Sum_ = &otlpmetrics.HistogramDataPoint_Sum{Sum: math.Float64frombits(v)}
+			i = end
+		case 6:
+			if dp.BucketCounts, i, err = readPackedFixed64(buf, next, wireType, dp.BucketCounts, identity, "BucketCounts"); err != nil {
+				return err
+			}
+		case 7:
+			if dp.ExplicitBounds, i, err = readPackedFixed64(buf, next, wireType, dp.ExplicitBounds, math.Float64frombits, "ExplicitBounds"); err != nil {
+				return err
+			}
+		case 8:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Exemplars")
+			if err != nil {
+				return err
+			}
+			if dp.Exemplars, err = d.appendExemplar(buf[start:end], dp.Exemplars); err != nil {
+				return err
+			}
+			i = end
+		case 9:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if dp.Attributes, err = d.appendKeyValue(buf[start:end], dp.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 10:
+			v, end, err := varintField(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			dp.Flags = uint32(v)
+			i = end
+		case 11:
+			v, end, err := fixed64Field(buf, next, wireType, "Min")
+			if err != nil {
+				return err
+			}
+			dp.Min_ = &otlpmetrics.HistogramDataPoint_Min{Min: math.Float64frombits(v)}
+			i = end
+		case 12:
+			v, end, err := fixed64Field(buf, next, wireType, "Max")
+			if err != nil {
+				return err
+			}
+			dp.Max_ = &otlpmetrics.HistogramDataPoint_Max{Max: math.Float64frombits(v)}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeExponentialHistogramDataPoint(buf []byte, dp *otlpmetrics.ExponentialHistogramDataPoint) error {
+	counts := countFields(buf, 1, 11)
+	dp.Attributes = slices.Grow(dp.Attributes, counts[0])
+	dp.Exemplars = slices.Grow(dp.Exemplars, counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ExponentialHistogramDataPoint")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if dp.Attributes, err = d.appendKeyValue(buf[start:end], dp.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			v, end, err := fixed64Field(buf, next, wireType, "StartTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.StartTimeUnixNano = v
+			i = end
+		case 3:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.TimeUnixNano = v
+			i = end
+		case 4:
+			v, end, err := fixed64Field(buf, next, wireType, "Count")
+			if err != nil {
+				return err
+			}
+			dp.Count = v
+			i = end
+		case 5:
+			v, end, err := fixed64Field(buf, next, wireType, "Sum")
+			if err != nil {
+				return err
+			}
+			dp.Sum_ = &otlpmetrics.ExponentialHistogramDataPoint_Sum{Sum: math.Float64frombits(v)}
+			i = end
+		case 6:
+			v, end, err := varintField(buf, next, wireType, "Scale")
+			if err != nil {
+				return err
+			}
+			dp.Scale = decodeZigZag32(v)
+			i = end
+		case 7:
+			v, end, err := fixed64Field(buf, next, wireType, "ZeroCount")
+			if err != nil {
+				return err
+			}
+			dp.ZeroCount = v
+			i = end
+		case 8:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Positive")
+			if err != nil {
+				return err
+			}
+			if err = decodeBuckets(buf[start:end], &dp.Positive); err != nil {
+				return err
+			}
+			i = end
+		case 9:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Negative")
+			if err != nil {
+				return err
+			}
+			if err = decodeBuckets(buf[start:end], &dp.Negative); err != nil {
+				return err
+			}
+			i = end
+		case 10:
+			v, end, err := varintField(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			dp.Flags = uint32(v)
+			i = end
+		case 11:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Exemplars")
+			if err != nil {
+				return err
+			}
+			if dp.Exemplars, err = d.appendExemplar(buf[start:end], dp.Exemplars); err != nil {
+				return err
+			}
+			i = end
+		case 12:
+			v, end, err := fixed64Field(buf, next, wireType, "Min")
+			if err != nil {
+				return err
+			}
+			dp.Min_ = &otlpmetrics.ExponentialHistogramDataPoint_Min{Min: math.Float64frombits(v)}
+			i = end
+		case 13:
+			v, end, err := fixed64Field(buf, next, wireType, "Max")
+			if err != nil {
+				return err
+			}
+			dp.Max_ = &otlpmetrics.ExponentialHistogramDataPoint_Max{Max: math.Float64frombits(v)}
+			i = end
+		case 14:
+			v, end, err := fixed64Field(buf, next, wireType, "ZeroThreshold")
+			if err != nil {
+				return err
+			}
+			dp.ZeroThreshold = math.Float64frombits(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeBuckets(buf []byte, buckets *otlpmetrics.ExponentialHistogramDataPoint_Buckets) error {
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Buckets")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			v, end, err := varintField(buf, next, wireType, "Offset")
+			if err != nil {
+				return err
+			}
+			buckets.Offset = decodeZigZag32(v)
+			i = end
+		case 2:
+			if buckets.BucketCounts, i, err = readPackedVarint(buf, next, wireType, buckets.BucketCounts, "BucketCounts"); err != nil {
+				return err
+			}
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSummaryDataPoint(buf []byte, dp *otlpmetrics.SummaryDataPoint) error {
+	counts := countFields(buf, 7, 6)
+	dp.Attributes = slices.Grow(dp.Attributes, counts[0])
+	dp.QuantileValues = slices.Grow(dp.QuantileValues, counts[1])
+	quantiles := make(slab[otlpmetrics.SummaryDataPoint_ValueAtQuantile], counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "SummaryDataPoint")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 2:
+			v, end, err := fixed64Field(buf, next, wireType, "StartTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.StartTimeUnixNano = v
+			i = end
+		case 3:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			dp.TimeUnixNano = v
+			i = end
+		case 4:
+			v, end, err := fixed64Field(buf, next, wireType, "Count")
+			if err != nil {
+				return err
+			}
+			dp.Count = v
+			i = end
+		case 5:
+			v, end, err := fixed64Field(buf, next, wireType, "Sum")
+			if err != nil {
+				return err
+			}
+			dp.Sum = math.Float64frombits(v)
+			i = end
+		case 6:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "QuantileValues")
+			if err != nil {
+				return err
+			}
+			quantile := quantiles.next(0)
+			dp.QuantileValues = append(dp.QuantileValues, quantile)
+			if err = decodeValueAtQuantile(buf[start:end], quantile); err != nil {
+				return err
+			}
+			i = end
+		case 7:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if dp.Attributes, err = d.appendKeyValue(buf[start:end], dp.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 8:
+			v, end, err := varintField(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			dp.Flags = uint32(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeValueAtQuantile(buf []byte, quantile *otlpmetrics.SummaryDataPoint_ValueAtQuantile) error {
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ValueAtQuantile")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			v, end, err := fixed64Field(buf, next, wireType, "Quantile")
+			if err != nil {
+				return err
+			}
+			quantile.Quantile = math.Float64frombits(v)
+			i = end
+		case 2:
+			v, end, err := fixed64Field(buf, next, wireType, "Value")
+			if err != nil {
+				return err
+			}
+			quantile.Value = math.Float64frombits(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendExemplar decodes an exemplar, and appends it to the given exemplars.
+func (d *decoder) appendExemplar(buf []byte, exemplars []otlpmetrics.Exemplar) ([]otlpmetrics.Exemplar, error) {
+	exemplars = append(exemplars, otlpmetrics.Exemplar{})
+	return exemplars, d.decodeExemplar(buf, &exemplars[len(exemplars)-1])
+}
+
+func (d *decoder) decodeExemplar(buf []byte, exemplar *otlpmetrics.Exemplar) error {
+	counts := countFields(buf, 7)
+	exemplar.FilteredAttributes = slices.Grow(exemplar.FilteredAttributes, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Exemplar")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 2:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			exemplar.TimeUnixNano = v
+			i = end
+		case 3:
+			v, end, err := fixed64Field(buf, next, wireType, "AsDouble")
+			if err != nil {
+				return err
+			}
+			exemplar.Value = &otlpmetrics.Exemplar_AsDouble{AsDouble: math.Float64frombits(v)}
+			i = end
+		case 4:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SpanId")
+			if err != nil {
+				return err
+			}
+			if err = exemplar.SpanId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 5:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "TraceId")
+			if err != nil {
+				return err
+			}
+			if err = exemplar.TraceId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 6:
+			v, end, err := fixed64Field(buf, next, wireType, "AsInt")
+			if err != nil {
+				return err
+			}
+			exemplar.Value = &otlpmetrics.Exemplar_AsInt{AsInt: int64(v)}
+			i = end
+		case 7:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "FilteredAttributes")
+			if err != nil {
+				return err
+			}
+			if exemplar.FilteredAttributes, err = d.appendKeyValue(buf[start:end], exemplar.FilteredAttributes); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func identity(v uint64) uint64 {
+	return v
+}
+
+// decodeZigZag32 decodes a sint32 value.
+func decodeZigZag32(v uint64) int32 {
+	u := uint32(v)
+	return int32(u>>1) ^ -int32(u&1)
+}
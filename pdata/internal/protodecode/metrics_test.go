@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+func testExemplars() []otlpmetrics.Exemplar {
+	return []otlpmetrics.Exemplar{
+		{
+			FilteredAttributes: testAttributes()[:1],
+			TimeUnixNano:       1,
+			Value:              &otlpmetrics.Exemplar_AsDouble{AsDouble: 1.5},
+			SpanId:             testSpanID(),
+			TraceId:            testTraceID(),
+		},
+		{Value: &otlpmetrics.Exemplar_AsInt{AsInt: -3}},
+	}
+}
+
+func testResourceMetrics() []*otlpmetrics.ResourceMetrics {
+	return []*otlpmetrics.ResourceMetrics{
+		{
+			Resource: testResource(),
+			ScopeMetrics: []*otlpmetrics.ScopeMetrics{
+				{
+					Scope: testScope(),
+					Metrics: []*otlpmetrics.Metric{
+						{
+							Name:        "gauge",
+							Description: "description",
+							Unit:        "1",
+							Metadata:    testAttributes()[:1],
+							Data: &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{
+								DataPoints: []*otlpmetrics.NumberDataPoint{
+									{
+										Attributes:        testAttributes(),
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Value:             &otlpmetrics.NumberDataPoint_AsDouble{AsDouble: 3.5},
+										Exemplars:         testExemplars(),
+										Flags:             1,
+									},
+									{Value: &otlpmetrics.NumberDataPoint_AsInt{AsInt: -4}},
+								},
+							}},
+						},
+						{
+							Name: "sum",
+							Data: &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{
+								DataPoints: []*otlpmetrics.NumberDataPoint{
+									{Value: &otlpmetrics.NumberDataPoint_AsInt{AsInt: 5}},
+								},
+								AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+								IsMonotonic:            true,
+							}},
+						},
+						{
+							Name: "histogram",
+							Data: &otlpmetrics.Metric_Histogram{Histogram: &otlpmetrics.Histogram{
+								DataPoints: []*otlpmetrics.HistogramDataPoint{
+									{
+										Attributes:        testAttributes()[1:3],
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Count:             6,
+										Sum_:              &otlpmetrics.HistogramDataPoint_Sum{Sum: 7.5},
+										BucketCounts:      []uint64{1, 2, 3},
+										ExplicitBounds:    []float64{0.5, 1.5},
+										Exemplars:         testExemplars(),
+										Flags:             1,
+										Min_:              &otlpmetrics.HistogramDataPoint_Min{Min: 0.25},
+										Max_:              &otlpmetrics.HistogramDataPoint_Max{Max: 2.5},
+									},
+									{},
+								},
+								AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+							}},
+						},
+						{
+							Name: "exponential_histogram",
+							Data: &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: &otlpmetrics.ExponentialHistogram{
+								DataPoints: []*otlpmetrics.ExponentialHistogramDataPoint{
+									{
+										Attributes:        testAttributes()[3:4],
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Count:             6,
+										Sum_:              &otlpmetrics.ExponentialHistogramDataPoint_Sum{Sum: 7.5},
+										Scale:             -3,
+										ZeroCount:         1,
+										Positive:          otlpmetrics.ExponentialHistogramDataPoint_Buckets{Offset: -2, BucketCounts: []uint64{1, 300, 70000}},
+										Negative:          otlpmetrics.ExponentialHistogramDataPoint_Buckets{Offset: 5, BucketCounts: []uint64{4}},
+										Flags:             1,
+										Exemplars:         testExemplars(),
+										Min_:              &otlpmetrics.ExponentialHistogramDataPoint_Min{Min: -1},
+										Max_:              &otlpmetrics.ExponentialHistogramDataPoint_Max{Max: 3},
+										ZeroThreshold:     0.001,
+									},
+								},
+								AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+							}},
+						},
+						{
+							Name: "summary",
+							Data: &otlpmetrics.Metric_Summary{Summary: &otlpmetrics.Summary{
+								DataPoints: []*otlpmetrics.SummaryDataPoint{
+									{
+										Attributes:        testAttributes()[4:5],
+										StartTimeUnixNano: 1,
+										TimeUnixNano:      2,
+										Count:             3,
+										Sum:               4.5,
+										QuantileValues: []*otlpmetrics.SummaryDataPoint_ValueAtQuantile{
+											{Quantile: 0.5, Value: 1},
+											{Quantile: 0.99, Value: 2},
+										},
+										Flags: 1,
+									},
+								},
+							}},
+						},
+						{Name: "empty"},
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			DeprecatedScopeMetrics: []*otlpmetrics.ScopeMetrics{
+				{Metrics: []*otlpmetrics.Metric{{Name: "deprecated"}}},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestUnmarshalMetricsData(t *testing.T) {
+	testEquivalence(t, &otlpmetrics.MetricsData{ResourceMetrics: testResourceMetrics()}, UnmarshalMetricsData)
+}
+
+func TestUnmarshalExportMetricsServiceRequest(t *testing.T) {
+	testEquivalence(t, &otlpcollectormetrics.ExportMetricsServiceRequest{ResourceMetrics: testResourceMetrics()}, UnmarshalExportMetricsServiceRequest)
+}
+
+func TestDecodeZigZag32(t *testing.T) {
+	for _, v := range []int32{0, -1, 1, -2, 2, 1<<31 - 1, -1 << 31} {
+		zigzag := uint64(uint32(v<<1) ^ uint32(v>>31))
+		assert.Equal(t, v, decodeZigZag32(zigzag))
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode // import "go.opentelemetry.io/collector/pdata/internal/protodecode"
+
+import (
+	"slices"
+
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// UnmarshalTracesData unmarshals the protobuf bytes of a TracesData into td, like td.Unmarshal does.
+func UnmarshalTracesData(buf []byte, td *otlptrace.TracesData) error {
+	d := getDecoder()
+	defer putDecoder(d)
+	return d.decodeTraces(buf, &td.ResourceSpans, "TracesData")
+}
+
+// UnmarshalExportTraceServiceRequest unmarshals the protobuf bytes of an ExportTraceServiceRequest into req,
+// like req.Unmarshal does.
+func UnmarshalExportTraceServiceRequest(buf []byte, req *otlpcollectortrace.ExportTraceServiceRequest) error {
+	d := getDecoder()
+	defer putDecoder(d)
+	return d.decodeTraces(buf, &req.ResourceSpans, "ExportTraceServiceRequest")
+}
+
+// decodeTraces decodes the TracesData and ExportTraceServiceRequest messages, which have the same fields.
+func (d *decoder) decodeTraces(buf []byte, resourceSpans *[]*otlptrace.ResourceSpans, name string) error {
+	counts := countFields(buf, 1)
+	*resourceSpans = slices.Grow(*resourceSpans, counts[0])
+	values := make(slab[otlptrace.ResourceSpans], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, name)
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ResourceSpans")
+			if err != nil {
+				return err
+			}
+			rs := values.next(0)
+			*resourceSpans = append(*resourceSpans, rs)
+			if err = d.decodeResourceSpans(buf[start:end], rs); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeResourceSpans(buf []byte, rs *otlptrace.ResourceSpans) error {
+	counts := countFields(buf, 2, 1000)
+	rs.ScopeSpans = slices.Grow(rs.ScopeSpans, counts[0])
+	rs.DeprecatedScopeSpans = slices.Grow(rs.DeprecatedScopeSpans, counts[1])
+	values := make(slab[otlptrace.ScopeSpans], counts[0]+counts[1])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ResourceSpans")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Resource")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeResource(buf[start:end], &rs.Resource); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ScopeSpans")
+			if err != nil {
+				return err
+			}
+			ss := values.next(0)
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+			if err = d.decodeScopeSpans(buf[start:end], ss); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SchemaUrl")
+			if err != nil {
+				return err
+			}
+			rs.SchemaUrl = string(buf[start:end])
+			i = end
+		case 1000:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "DeprecatedScopeSpans")
+			if err != nil {
+				return err
+			}
+			ss := values.next(0)
+			rs.DeprecatedScopeSpans = append(rs.DeprecatedScopeSpans, ss)
+			if err = d.decodeScopeSpans(buf[start:end], ss); err != nil {
+				return err
+			}
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeScopeSpans(buf []byte, ss *otlptrace.ScopeSpans) error {
+	counts := countFields(buf, 2)
+	ss.Spans = slices.Grow(ss.Spans, counts[0])
+	values := make(slab[otlptrace.Span], counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "ScopeSpans")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Scope")
+			if err != nil {
+				return err
+			}
+			if err = d.decodeInstrumentationScope(buf[start:end], &ss.Scope); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Spans")
+			if err != nil {
+				return err
+			}
+			span := values.next(0)
+			ss.Spans = append(ss.Spans, span)
+			if err = d.decodeSpan(buf[start:end], span); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SchemaUrl")
+			if err != nil {
+				return err
+			}
+			ss.SchemaUrl = string(buf[start:end])
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSpan(buf []byte, span *otlptrace.Span) error {
+	counts := countFields(buf, 9, 11, 13)
+	span.Attributes = slices.Grow(span.Attributes, counts[0])
+	span.Events = slices.Grow(span.Events, counts[1])
+	span.Links = slices.Grow(span.Links, counts[2])
+	events := make(slab[otlptrace.Span_Event], counts[1])
+	links := make(slab[otlptrace.Span_Link], counts[2])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Span")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "TraceId")
+			if err != nil {
+				return err
+			}
+			if err = span.TraceId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SpanId")
+			if err != nil {
+				return err
+			}
+			if err = span.SpanId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "TraceState")
+			if err != nil {
+				return err
+			}
+			span.TraceState = string(buf[start:end])
+			i = end
+		case 4:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "ParentSpanId")
+			if err != nil {
+				return err
+			}
+			if err = span.ParentSpanId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 5:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Name")
+			if err != nil {
+				return err
+			}
+			span.Name = string(buf[start:end])
+			i = end
+		case 6:
+			v, end, err := varintField(buf, next, wireType, "Kind")
+			if err != nil {
+				return err
+			}
+			span.Kind = otlptrace.Span_SpanKind(v)
+			i = end
+		case 7:
+			v, end, err := fixed64Field(buf, next, wireType, "StartTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			span.StartTimeUnixNano = v
+			i = end
+		case 8:
+			v, end, err := fixed64Field(buf, next, wireType, "EndTimeUnixNano")
+			if err != nil {
+				return err
+			}
+			span.EndTimeUnixNano = v
+			i = end
+		case 9:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if span.Attributes, err = d.appendKeyValue(buf[start:end], span.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 10:
+			v, end, err := varintField(buf, next, wireType, "DroppedAttributesCount")
+			if err != nil {
+				return err
+			}
+			span.DroppedAttributesCount = uint32(v)
+			i = end
+		case 11:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Events")
+			if err != nil {
+				return err
+			}
+			event := events.next(0)
+			span.Events = append(span.Events, event)
+			if err = d.decodeSpanEvent(buf[start:end], event); err != nil {
+				return err
+			}
+			i = end
+		case 12:
+			v, end, err := varintField(buf, next, wireType, "DroppedEventsCount")
+			if err != nil {
+				return err
+			}
+			span.DroppedEventsCount = uint32(v)
+			i = end
+		case 13:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Links")
+			if err != nil {
+				return err
+			}
+			link := links.next(0)
+			span.Links = append(span.Links, link)
+			if err = d.decodeSpanLink(buf[start:end], link); err != nil {
+				return err
+			}
+			i = end
+		case 14:
+			v, end, err := varintField(buf, next, wireType, "DroppedLinksCount")
+			if err != nil {
+				return err
+			}
+			span.DroppedLinksCount = uint32(v)
+			i = end
+		case 15:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Status")
+			if err != nil {
+				return err
+			}
+			if err = decodeStatus(buf[start:end], &span.Status); err != nil {
+				return err
+			}
+			i = end
+		case 16:
+			v, end, err := fixed32Field(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			span.Flags = v
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSpanEvent(buf []byte, event *otlptrace.Span_Event) error {
+	counts := countFields(buf, 3)
+	event.Attributes = slices.Grow(event.Attributes, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Event")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			v, end, err := fixed64Field(buf, next, wireType, "TimeUnixNano")
+			if err != nil {
+				return err
+			}
+			event.TimeUnixNano = v
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Name")
+			if err != nil {
+				return err
+			}
+			event.Name = string(buf[start:end])
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if event.Attributes, err = d.appendKeyValue(buf[start:end], event.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 4:
+			v, end, err := varintField(buf, next, wireType, "DroppedAttributesCount")
+			if err != nil {
+				return err
+			}
+			event.DroppedAttributesCount = uint32(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSpanLink(buf []byte, link *otlptrace.Span_Link) error {
+	counts := countFields(buf, 4)
+	link.Attributes = slices.Grow(link.Attributes, counts[0])
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Link")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 1:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "TraceId")
+			if err != nil {
+				return err
+			}
+			if err = link.TraceId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "SpanId")
+			if err != nil {
+				return err
+			}
+			if err = link.SpanId.Unmarshal(buf[start:end]); err != nil {
+				return err
+			}
+			i = end
+		case 3:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "TraceState")
+			if err != nil {
+				return err
+			}
+			link.TraceState = string(buf[start:end])
+			i = end
+		case 4:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Attributes")
+			if err != nil {
+				return err
+			}
+			if link.Attributes, err = d.appendKeyValue(buf[start:end], link.Attributes); err != nil {
+				return err
+			}
+			i = end
+		case 5:
+			v, end, err := varintField(buf, next, wireType, "DroppedAttributesCount")
+			if err != nil {
+				return err
+			}
+			link.DroppedAttributesCount = uint32(v)
+			i = end
+		case 6:
+			v, end, err := fixed32Field(buf, next, wireType, "Flags")
+			if err != nil {
+				return err
+			}
+			link.Flags = v
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeStatus(buf []byte, status *otlptrace.Status) error {
+	for i := 0; i < len(buf); {
+		fieldNum, wireType, next, err := readTag(buf, i, "Status")
+		if err != nil {
+			return err
+		}
+		switch fieldNum {
+		case 2:
+			start, end, err := lengthDelimitedField(buf, next, wireType, "Message")
+			if err != nil {
+				return err
+			}
+			status.Message = string(buf[start:end])
+			i = end
+		case 3:
+			v, end, err := varintField(buf, next, wireType, "Code")
+			if err != nil {
+				return err
+			}
+			status.Code = otlptrace.Status_StatusCode(v)
+			i = end
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
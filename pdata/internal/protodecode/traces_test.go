@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode
+
+import (
+	"testing"
+
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+func testResourceSpans() []*otlptrace.ResourceSpans {
+	return []*otlptrace.ResourceSpans{
+		{
+			Resource: testResource(),
+			ScopeSpans: []*otlptrace.ScopeSpans{
+				{
+					Scope: testScope(),
+					Spans: []*otlptrace.Span{
+						{
+							TraceId:                testTraceID(),
+							SpanId:                 testSpanID(),
+							TraceState:             "key=value",
+							ParentSpanId:           testSpanID(),
+							Flags:                  1,
+							Name:                   "span",
+							Kind:                   otlptrace.Span_SPAN_KIND_SERVER,
+							StartTimeUnixNano:      1,
+							EndTimeUnixNano:        2,
+							Attributes:             testAttributes(),
+							DroppedAttributesCount: 3,
+							Events: []*otlptrace.Span_Event{
+								{TimeUnixNano: 3, Name: "event", Attributes: testAttributes()[:1], DroppedAttributesCount: 4},
+								{Name: "other"},
+							},
+							DroppedEventsCount: 5,
+							Links: []*otlptrace.Span_Link{
+								{TraceId: testTraceID(), SpanId: testSpanID(), TraceState: "link", Attributes: testAttributes()[1:2], DroppedAttributesCount: 6, Flags: 2},
+							},
+							DroppedLinksCount: 7,
+							Status:            otlptrace.Status{Message: "error", Code: otlptrace.Status_STATUS_CODE_ERROR},
+						},
+						{Name: "empty"},
+					},
+					SchemaUrl: "scope_schema",
+				},
+			},
+			DeprecatedScopeSpans: []*otlptrace.ScopeSpans{
+				{Spans: []*otlptrace.Span{{Name: "deprecated"}}},
+			},
+			SchemaUrl: "resource_schema",
+		},
+		{},
+	}
+}
+
+func TestUnmarshalTracesData(t *testing.T) {
+	testEquivalence(t, &otlptrace.TracesData{ResourceSpans: testResourceSpans()}, UnmarshalTracesData)
+}
+
+func TestUnmarshalExportTraceServiceRequest(t *testing.T) {
+	testEquivalence(t, &otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: testResourceSpans()}, UnmarshalExportTraceServiceRequest)
+}
@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode // import "go.opentelemetry.io/collector/pdata/internal/protodecode"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	wireTypeVarint          = 0
+	wireTypeFixed64         = 1
+	wireTypeLengthDelimited = 2
+	wireTypeStartGroup      = 3
+	wireTypeEndGroup        = 4
+	wireTypeFixed32         = 5
+
+	// maxCountedFields is the maximum number of fields counted at once by countFields.
+	maxCountedFields = 4
+	// slabSize is the number of values allocated at once by the slabs of the oneof values.
+	slabSize = 64
+)
+
+// The errors are the same as the ones of the generated code.
+var (
+	errInvalidLength          = errors.New("proto: negative length found during unmarshaling")
+	errIntOverflow            = errors.New("proto: integer overflow")
+	errUnexpectedEndOfGroup   = errors.New("proto: unexpected end of group")
+	errUnexpectedEndOfMessage = io.ErrUnexpectedEOF
+)
+
+func errWrongWireType(wireType int, field string) error {
+	return fmt.Errorf("proto: wrong wireType = %d for field %s", wireType, field)
+}
+
+// readVarint reads the varint starting at buf[i], and returns it with the index following it.
+func readVarint(buf []byte, i int) (uint64, int, error) {
+	if i < len(buf) && buf[i] < 0x80 {
+		return uint64(buf[i]), i + 1, nil
+	}
+	var v uint64
+	for shift := uint(0); ; shift += 7 {
+		if shift >= 64 {
+			return 0, 0, errIntOverflow
+		}
+		if i >= len(buf) {
+			return 0, 0, errUnexpectedEndOfMessage
+		}
+		b := buf[i]
+		i++
+		v |= uint64(b&0x7F) << shift
+		if b < 0x80 {
+			return v, i, nil
+		}
+	}
+}
+
+// readTag reads the tag of the field starting at buf[i], and returns the field number and the wire type
+// with the index following it. The name of the message is only used for the error messages.
+func readTag(buf []byte, i int, name string) (int32, int, int, error) {
+	wire, i, err := readVarint(buf, i)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	fieldNum := int32(wire >> 3)
+	wireType := int(wire & 0x7)
+	if wireType == wireTypeEndGroup {
+		return 0, 0, 0, fmt.Errorf("proto: %s: wiretype end group for non-group", name)
+	}
+	if fieldNum <= 0 {
+		return 0, 0, 0, fmt.Errorf("proto: %s: illegal tag %d (wire type %d)", name, fieldNum, wire)
+	}
+	return fieldNum, wireType, i, nil
+}
+
+// readLengthDelimited reads the length of the length-delimited field value starting at buf[i], and returns
+// the start and end indexes of the value.
+func readLengthDelimited(buf []byte, i int) (int, int, error) {
+	v, i, err := readVarint(buf, i)
+	if err != nil {
+		return 0, 0, err
+	}
+	length := int(v)
+	if length < 0 {
+		return 0, 0, errInvalidLength
+	}
+	end := i + length
+	if end < 0 {
+		return 0, 0, errInvalidLength
+	}
+	if end > len(buf) {
+		return 0, 0, errUnexpectedEndOfMessage
+	}
+	return i, end, nil
+}
+
+// readFixed64 reads the fixed64 value starting at buf[i], and returns it with the index following it.
+func readFixed64(buf []byte, i int) (uint64, int, error) {
+	if i+8 > len(buf) {
+		return 0, 0, errUnexpectedEndOfMessage
+	}
+	return binary.LittleEndian.Uint64(buf[i:]), i + 8, nil
+}
+
+// readFixed32 reads the fixed32 value starting at buf[i], and returns it with the index following it.
+func readFixed32(buf []byte, i int) (uint32, int, error) {
+	if i+4 > len(buf) {
+		return 0, 0, errUnexpectedEndOfMessage
+	}
+	return binary.LittleEndian.Uint32(buf[i:]), i + 4, nil
+}
+
+// varintField checks the wire type of a varint field, and reads its value starting at buf[i].
+func varintField(buf []byte, i int, wireType int, field string) (uint64, int, error) {
+	if wireType != wireTypeVarint {
+		return 0, 0, errWrongWireType(wireType, field)
+	}
+	return readVarint(buf, i)
+}
+
+// fixed64Field checks the wire type of a fixed64 field, and reads its value starting at buf[i].
+func fixed64Field(buf []byte, i int, wireType int, field string) (uint64, int, error) {
+	if wireType != wireTypeFixed64 {
+		return 0, 0, errWrongWireType(wireType, field)
+	}
+	return readFixed64(buf, i)
+}
+
+// fixed32Field checks the wire type of a fixed32 field, and reads its value starting at buf[i].
+func fixed32Field(buf []byte, i int, wireType int, field string) (uint32, int, error) {
+	if wireType != wireTypeFixed32 {
+		return 0, 0, errWrongWireType(wireType, field)
+	}
+	return readFixed32(buf, i)
+}
+
+// lengthDelimitedField checks the wire type of a string, bytes or message field, and returns the start and
+// end indexes of its value starting at buf[i].
+func lengthDelimitedField(buf []byte, i int, wireType int, field string) (int, int, error) {
+	if wireType != wireTypeLengthDelimited {
+		return 0, 0, errWrongWireType(wireType, field)
+	}
+	return readLengthDelimited(buf, i)
+}
+
+// readPackedFixed64 appends the packed or unpacked fixed64 values of a repeated field to dst, converted by
+// conv. As the generated code does, a packed value is allowed to overflow the declared length of the field.
+func readPackedFixed64[T any](buf []byte, i int, wireType int, dst []T, conv func(uint64) T, field string) ([]T, int, error) {
+	switch wireType {
+	case wireTypeFixed64:
+		v, i, err := readFixed64(buf, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		return append(dst, conv(v)), i, nil
+	case wireTypeLengthDelimited:
+		start, end, err := readLengthDelimited(buf, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		if count := (end - start) / 8; count != 0 && len(dst) == 0 {
+			dst = make([]T, 0, count)
+		}
+		for i = start; i < end; {
+			var v uint64
+			if v, i, err = readFixed64(buf, i); err != nil {
+				return nil, 0, err
+			}
+			dst = append(dst, conv(v))
+		}
+		return dst, i, nil
+	default:
+		return nil, 0, errWrongWireType(wireType, field)
+	}
+}
+
+// readPackedVarint appends the packed or unpacked varint values of a repeated field to dst.
+func readPackedVarint(buf []byte, i int, wireType int, dst []uint64, field string) ([]uint64, int, error) {
+	switch wireType {
+	case wireTypeVarint:
+		v, i, err := readVarint(buf, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		return append(dst, v), i, nil
+	case wireTypeLengthDelimited:
+		start, end, err := readLengthDelimited(buf, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		count := 0
+		for _, b := range buf[start:end] {
+			if b < 0x80 {
+				count++
+			}
+		}
+		if count != 0 && len(dst) == 0 {
+			dst = make([]uint64, 0, count)
+		}
+		for i = start; i < end; {
+			var v uint64
+			if v, i, err = readVarint(buf, i); err != nil {
+				return nil, 0, err
+			}
+			dst = append(dst, v)
+		}
+		return dst, i, nil
+	default:
+		return nil, 0, errWrongWireType(wireType, field)
+	}
+}
+
+// skipField skips the unknown field starting at buf[i], tag included, and returns the index following it.
+func skipField(buf []byte, i int) (int, error) {
+	n, err := skip(buf[i:])
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || i+n < 0 {
+		return 0, errInvalidLength
+	}
+	if i+n > len(buf) {
+		return 0, errUnexpectedEndOfMessage
+	}
+	return i + n, nil
+}
+
+// skip returns the length of the field at the beginning of buf, including the nested fields of a group.
+// It is the same as the skip function of the generated code.
+func skip(buf []byte) (int, error) {
+	i := 0
+	depth := 0
+	for i < len(buf) {
+		wire, next, err := readVarint(buf, i)
+		if err != nil {
+			return 0, err
+		}
+		i = next
+		switch wireType := int(wire & 0x7); wireType {
+		case wireTypeVarint:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, errIntOverflow
+				}
+				if i >= len(buf) {
+					return 0, errUnexpectedEndOfMessage
+				}
+				i++
+				if buf[i-1] < 0x80 {
+					break
+				}
+			}
+		case wireTypeFixed64:
+			i += 8
+		case wireTypeLengthDelimited:
+			v, next, err := readVarint(buf, i)
+			if err != nil {
+				return 0, err
+			}
+			length := int(v)
+			if length < 0 {
+				return 0, errInvalidLength
+			}
+			i = next + length
+		case wireTypeStartGroup:
+			depth++
+		case wireTypeEndGroup:
+			if depth == 0 {
+				return 0, errUnexpectedEndOfGroup
+			}
+			depth--
+		case wireTypeFixed32:
+			i += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if i < 0 {
+			return 0, errInvalidLength
+		}
+		if depth == 0 {
+			return i, nil
+		}
+	}
+	return 0, errUnexpectedEndOfMessage
+}
+
+// countFields returns the number of occurrences of the given length-delimited fields in the message, at most
+// maxCountedFields of them, so that their repeated values are allocated at once. The counting stops at the
+// first malformed field, where the decoding of the message fails.
+func countFields(buf []byte, fields ...int32) (counts [maxCountedFields]int) {
+	for i := 0; i < len(buf); {
+		wire, next, err := readVarint(buf, i)
+		if err != nil {
+			return counts
+		}
+		switch int(wire & 0x7) {
+		case wireTypeVarint:
+			if _, i, err = readVarint(buf, next); err != nil {
+				return counts
+			}
+		case wireTypeFixed64:
+			i = next + 8
+		case wireTypeLengthDelimited:
+			if _, i, err = readLengthDelimited(buf, next); err != nil {
+				return counts
+			}
+			for j, field := range fields {
+				if field == int32(wire>>3) {
+					counts[j]++
+				}
+			}
+		case wireTypeFixed32:
+			i = next + 4
+		default:
+			if i, err = skipField(buf, i); err != nil {
+				return counts
+			}
+		}
+	}
+	return counts
+}
+
+// slab hands out the values of a type, allocated in arrays to reduce the number of allocations.
+type slab[T any] []T
+
+// next returns a pointer to a new zero value, allocating a new array of size values if needed.
+func (s *slab[T]) next(size int) *T {
+	if len(*s) == 0 {
+		*s = make([]T, max(size, 1))
+	}
+	v := &(*s)[0]
+	*s = (*s)[1:]
+	return v
+}
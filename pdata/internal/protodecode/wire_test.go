@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protodecode
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadVarint(t *testing.T) {
+	v, i, err := readVarint([]byte{0xac, 0x02, 0x01}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(300), v)
+	assert.Equal(t, 2, i)
+
+	_, _, err = readVarint([]byte{0xac}, 0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, _, err = readVarint([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, 0)
+	require.ErrorIs(t, err, errIntOverflow)
+}
+
+func TestReadTag(t *testing.T) {
+	fieldNum, wireType, i, err := readTag([]byte{0x0a}, 0, "Message")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fieldNum)
+	assert.Equal(t, wireTypeLengthDelimited, wireType)
+	assert.Equal(t, 1, i)
+
+	_, _, _, err = readTag([]byte{0x0c}, 0, "Message")
+	require.EqualError(t, err, "proto: Message: wiretype end group for non-group")
+
+	_, _, _, err = readTag([]byte{0x02}, 0, "Message")
+	require.EqualError(t, err, "proto: Message: illegal tag 0 (wire type 2)")
+}
+
+func TestReadLengthDelimited(t *testing.T) {
+	start, end, err := readLengthDelimited([]byte{0x02, 0x01, 0x02}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, start)
+	assert.Equal(t, 3, end)
+
+	_, _, err = readLengthDelimited([]byte{0x03, 0x01, 0x02}, 0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, _, err = readLengthDelimited([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, 0)
+	require.ErrorIs(t, err, errInvalidLength)
+}
+
+func TestSkipField(t *testing.T) {
+	buf := []byte{
+		0x0b,       // field 1, start group
+		0x10, 0x01, // field 2, varint
+		0x1b, 0x1c, // field 3, nested group
+		0x0c,       // field 1, end group
+		0x20, 0x01, // field 4, varint
+	}
+	i, err := skipField(buf, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 6, i)
+
+	_, err = skipField([]byte{0x0c}, 0)
+	require.ErrorIs(t, err, errUnexpectedEndOfGroup)
+
+	_, err = skipField([]byte{0x0b, 0x10, 0x01}, 0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = skipField([]byte{0x0e}, 0)
+	require.EqualError(t, err, "proto: illegal wireType 6")
+
+	_, err = skipField([]byte{0x09, 0x01}, 0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestCountFields(t *testing.T) {
+	buf := []byte{
+		0x0a, 0x00, // field 1
+		0x12, 0x01, 0x00, // field 2
+		0x08, 0x01, // field 1, varint
+		0x0a, 0x00, // field 1
+		0x1b, 0x0a, 0x00, 0x1c, // field 3, group containing field 1
+		0xc2, 0x3e, 0x00, // field 1000
+	}
+	counts := countFields(buf, 1, 1000, 3)
+	assert.Equal(t, [maxCountedFields]int{2, 1, 0, 0}, counts)
+
+	counts = countFields(append([]byte{0x0a, 0x00, 0x12, 0x7f}, buf...), 1)
+	assert.Equal(t, [maxCountedFields]int{1, 0, 0, 0}, counts)
+}
+
+func TestReadPacked(t *testing.T) {
+	values, i, err := readPackedVarint([]byte{0x03, 0x01, 0xac, 0x02}, 0, wireTypeLengthDelimited, nil, "Field")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 300}, values)
+	assert.Equal(t, 4, i)
+
+	values, _, err = readPackedVarint([]byte{0x05}, 0, wireTypeVarint, values, "Field")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 300, 5}, values)
+
+	_, _, err = readPackedVarint([]byte{0x05}, 0, wireTypeFixed32, nil, "Field")
+	require.EqualError(t, err, "proto: wrong wireType = 5 for field Field")
+
+	fixed, _, err := readPackedFixed64([]byte{0x08, 0x01, 0, 0, 0, 0, 0, 0, 0}, 0, wireTypeLengthDelimited, nil, identity, "Field")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1}, fixed)
+
+	_, _, err = readPackedFixed64([]byte{0x04, 0x01, 0, 0, 0}, 0, wireTypeLengthDelimited, nil, identity, "Field")
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestSlab(t *testing.T) {
+	var s slab[int]
+	first := s.next(2)
+	second := s.next(2)
+	third := s.next(2)
+	*first, *second, *third = 1, 2, 3
+	assert.Equal(t, 1, *first)
+	assert.Equal(t, 2, *second)
+	assert.Equal(t, 3, *third)
+	assert.Len(t, s, 1)
+
+	var empty slab[int]
+	assert.NotNil(t, empty.next(0))
+}
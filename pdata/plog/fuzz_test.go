@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/protodecode"
 )
 
 var unexpectedBytes = "expected the same bytes from unmarshaling and marshaling."
@@ -55,3 +58,29 @@ func FuzzUnmarshalPBLogs(f *testing.F) {
 		require.True(t, bytes.Equal(b1, b2), "%s. \nexpected %d but got %d\n", unexpectedBytes, b1, b2)
 	})
 }
+
+func FuzzUnmarshalPBLogsStreamingDecoder(f *testing.F) {
+	ld := NewLogs()
+	fillTestResourceLogsSlice(ld.ResourceLogs())
+	buf, err := (&ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(f, err)
+	f.Add(buf)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pb1 := otlplogs.LogsData{}
+		err1 := pb1.Unmarshal(data)
+		pb2 := otlplogs.LogsData{}
+		err2 := protodecode.UnmarshalLogsData(data, &pb2)
+		if err1 != nil {
+			require.EqualError(t, err2, err1.Error(), "expected the same error from both decoders")
+			return
+		}
+		require.NoError(t, err2, "failed to decode valid bytes")
+
+		b1, err := pb1.Marshal()
+		require.NoError(t, err, "failed to marshal valid struct")
+		b2, err := pb2.Marshal()
+		require.NoError(t, err, "failed to marshal valid struct")
+
+		require.True(t, bytes.Equal(b1, b2), "%s. \nexpected %d but got %d\n", unexpectedBytes, b1, b2)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package plog
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal/identity"
+)
+
+// Merge moves the log records of src into dest, and leaves src empty. The log records are appended to the
+// first ResourceLogs and ScopeLogs of dest with an identical Resource and Scope, which are appended to dest
+// if there are none. Resources and Scopes are identical if they have the same schema URL, attributes, dropped
+// attributes count, and for Scopes the same name and version.
+func Merge(dest, src Logs) {
+	m := newMerger(dest)
+	src.ResourceLogs().RemoveIf(func(srcRL ResourceLogs) bool {
+		m.setResource(srcRL)
+		destRL, ok := m.resources[string(m.key)]
+		if !ok {
+			destRL = dest.ResourceLogs().AppendEmpty()
+			srcRL.MoveTo(destRL)
+			m.add(destRL)
+			return true
+		}
+		srcRL.ScopeLogs().RemoveIf(func(srcSL ScopeLogs) bool {
+			m.setScope(srcSL)
+			destSL, ok := m.scopes[string(m.key)]
+			if !ok {
+				destSL = destRL.ScopeLogs().AppendEmpty()
+				srcSL.MoveTo(destSL)
+				m.scopes[string(m.key)] = destSL
+				return true
+			}
+			srcSL.LogRecords().MoveAndAppendTo(destSL.LogRecords())
+			return true
+		})
+		return true
+	})
+}
+
+// merger indexes the first ResourceLogs and ScopeLogs of the destination of a merge by the identity of
+// their Resource and Scope.
+type merger struct {
+	// key is the identity of the last Resource, followed by the identity of the last Scope set.
+	key         []byte
+	resourceLen int
+	resources   map[string]ResourceLogs
+	scopes      map[string]ScopeLogs
+}
+
+func newMerger(ld Logs) *merger {
+	m := &merger{resources: map[string]ResourceLogs{}, scopes: map[string]ScopeLogs{}}
+	for _, rl := range ld.ResourceLogs().All() {
+		m.setResource(rl)
+		m.add(rl)
+	}
+	return m
+}
+
+func (m *merger) setResource(rl ResourceLogs) {
+	m.key = identity.AppendResource(m.key[:0], rl.SchemaUrl(), rl.Resource())
+	m.resourceLen = len(m.key)
+}
+
+func (m *merger) setScope(sl ScopeLogs) {
+	m.key = identity.AppendScope(m.key[:m.resourceLen], sl.SchemaUrl(), sl.Scope())
+}
+
+// add indexes rl and its ScopeLogs under the last Resource set, unless it is already indexed.
+func (m *merger) add(rl ResourceLogs) {
+	if _, ok := m.resources[string(m.key)]; ok {
+		return
+	}
+	m.resources[string(m.key)] = rl
+	for _, sl := range rl.ScopeLogs().All() {
+		m.setScope(sl)
+		if _, ok := m.scopes[string(m.key)]; !ok {
+			m.scopes[string(m.key)] = sl
+		}
+	}
+}
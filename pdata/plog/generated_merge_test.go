@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package plog
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	splits := SplitByScope(generateTestSplitLogs(), func(_ ResourceLogs, sl ScopeLogs) string {
+		return sl.Scope().Name()
+	})
+	dest := NewLogs()
+	for _, scope := range []string{"scope1", "scope2", "scope3"} {
+		Merge(dest, splits[scope])
+		assert.Equal(t, 0, splits[scope].ResourceLogs().Len())
+	}
+	assert.Equal(t, generateTestSplitLogs(), dest)
+}
+
+func TestMergeLogRecords(t *testing.T) {
+	splits := SplitByLogRecord(generateTestSplitLogs(), func(_ ResourceLogs, _ ScopeLogs, lr LogRecord) string {
+		return lr.Body().Str()
+	})
+	dest := NewLogs()
+	Merge(dest, splits["item1"])
+	Merge(dest, splits["item2"])
+
+	want := generateTestSplitLogs()
+	sl := want.ResourceLogs().At(0).ScopeLogs().At(0)
+	sl.LogRecords().RemoveIf(func(lr LogRecord) bool { return lr.Body().Str() == "item2" })
+	appendTestSplitLogRecord(sl, "item2")
+	assert.Equal(t, want, dest)
+}
+
+func TestMergeDifferentResourcesAndScopes(t *testing.T) {
+	newLogs := func(mutate func(rl ResourceLogs, sl ScopeLogs)) Logs {
+		ld := NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("resource")
+		rl.Resource().Attributes().PutStr("resource", "1")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.SetSchemaUrl("scope")
+		sl.Scope().SetName("scope")
+		sl.Scope().SetVersion("v1")
+		sl.Scope().Attributes().PutStr("scope", "1")
+		appendTestSplitLogRecord(sl, "item")
+		mutate(rl, sl)
+		return ld
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(rl ResourceLogs, sl ScopeLogs)
+		resources int
+		scopes    int
+	}{
+		{
+			name:      "identical",
+			mutate:    func(ResourceLogs, ScopeLogs) {},
+			resources: 1,
+			scopes:    1,
+		},
+		{
+			name:      "resource_schema_url",
+			mutate:    func(rl ResourceLogs, _ ScopeLogs) { rl.SetSchemaUrl("other") },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "resource_attributes",
+			mutate:    func(rl ResourceLogs, _ ScopeLogs) { rl.Resource().Attributes().PutInt("resource", 1) },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "resource_dropped_attributes_count",
+			mutate:    func(rl ResourceLogs, _ ScopeLogs) { rl.Resource().SetDroppedAttributesCount(1) },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "scope_schema_url",
+			mutate:    func(_ ResourceLogs, sl ScopeLogs) { sl.SetSchemaUrl("other") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_name",
+			mutate:    func(_ ResourceLogs, sl ScopeLogs) { sl.Scope().SetName("other") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_version",
+			mutate:    func(_ ResourceLogs, sl ScopeLogs) { sl.Scope().SetVersion("v2") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_attributes",
+			mutate:    func(_ ResourceLogs, sl ScopeLogs) { sl.Scope().Attributes().Clear() },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_dropped_attributes_count",
+			mutate:    func(_ ResourceLogs, sl ScopeLogs) { sl.Scope().SetDroppedAttributesCount(1) },
+			resources: 1,
+			scopes:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := newLogs(func(ResourceLogs, ScopeLogs) {})
+			src := newLogs(tt.mutate)
+			Merge(dest, src)
+			assert.Equal(t, 0, src.ResourceLogs().Len())
+			assert.Equal(t, 2, dest.LogRecordCount())
+			assert.Equal(t, tt.resources, dest.ResourceLogs().Len())
+			last := dest.ResourceLogs().At(dest.ResourceLogs().Len() - 1)
+			assert.Equal(t, tt.scopes, last.ScopeLogs().Len())
+		})
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	dest := NewLogs()
+	for i := 0; i < 2; i++ {
+		appendTestSplitLogRecord(dest.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty(), "dest")
+	}
+	dest.ResourceLogs().At(0).ScopeLogs().AppendEmpty()
+	src := NewLogs()
+	appendTestSplitLogRecord(src.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty(), "src")
+	Merge(dest, src)
+
+	want := NewLogs()
+	sl := want.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	appendTestSplitLogRecord(sl, "dest")
+	appendTestSplitLogRecord(sl, "src")
+	want.ResourceLogs().At(0).ScopeLogs().AppendEmpty()
+	appendTestSplitLogRecord(want.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty(), "dest")
+	assert.Equal(t, want, dest)
+}
+
+func TestMergeEmpty(t *testing.T) {
+	dest := NewLogs()
+	Merge(dest, NewLogs())
+	assert.Equal(t, NewLogs(), dest)
+
+	src := generateTestSplitLogs()
+	Merge(dest, src)
+	assert.Equal(t, generateTestSplitLogs(), dest)
+	assert.Equal(t, 0, src.ResourceLogs().Len())
+}
+
+func TestMergeReadOnly(t *testing.T) {
+	src := generateTestSplitLogs()
+	src.MarkReadOnly()
+	assert.Panics(t, func() { Merge(NewLogs(), src) })
+
+	dest := generateTestSplitLogs()
+	dest.MarkReadOnly()
+	assert.Panics(t, func() { Merge(dest, generateTestSplitLogs()) })
+}
+
+func BenchmarkMerge(b *testing.B) {
+	ld := NewLogs()
+	for i := 0; i < 10; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutInt("resource", int64(i))
+		sl := rl.ScopeLogs().AppendEmpty()
+		for j := 0; j < 10; j++ {
+			appendTestSplitLogRecord(sl, strconv.Itoa(j))
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		dest := NewLogs()
+		clone := NewLogs()
+		ld.CopyTo(clone)
+		splits := SplitByLogRecord(clone, func(_ ResourceLogs, _ ScopeLogs, lr LogRecord) string {
+			return lr.Body().Str()
+		})
+		b.StartTimer()
+		for _, split := range splits {
+			Merge(dest, split)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package plog
+
+// SplitByResource moves the ResourceLogs of ld into one Logs per key returned by key,
+// and returns them by key. The order of the ResourceLogs is preserved, and ld is left empty.
+func SplitByResource[K comparable](ld Logs, key func(ResourceLogs) K) map[K]Logs {
+	res := map[K]Logs{}
+	ld.ResourceLogs().RemoveIf(func(rl ResourceLogs) bool {
+		k := key(rl)
+		dest, ok := res[k]
+		if !ok {
+			dest = NewLogs()
+			res[k] = dest
+		}
+		rl.MoveTo(dest.ResourceLogs().AppendEmpty())
+		return true
+	})
+	return res
+}
+
+// SplitByScope moves the ScopeLogs of ld into one Logs per key returned by key, under
+// copies of the Resource they belong to, and returns them by key. The order of the ScopeLogs is preserved,
+// and ld is left empty.
+func SplitByScope[K comparable](ld Logs, key func(ResourceLogs, ScopeLogs) K) map[K]Logs {
+	s := newSplitter[K]()
+	for i, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			sp := s.split(key(rl, sl))
+			sl.MoveTo(sp.resourceLogs(i, rl).ScopeLogs().AppendEmpty())
+		}
+	}
+	ld.ResourceLogs().RemoveIf(func(ResourceLogs) bool { return true })
+	return s.result()
+}
+
+// SplitByLogRecord moves the log records of ld into one Logs per key returned by key,
+// under copies of the Resource and Scope they belong to, and returns them by key. The order of the log records
+// is preserved, and ld is left empty.
+func SplitByLogRecord[K comparable](ld Logs, key func(ResourceLogs, ScopeLogs, LogRecord) K) map[K]Logs {
+	s := newSplitter[K]()
+	for i, rl := range ld.ResourceLogs().All() {
+		for j, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				sp := s.split(key(rl, sl, lr))
+				lr.MoveTo(sp.scopeLogs(i, rl, j, sl).LogRecords().AppendEmpty())
+			}
+		}
+	}
+	ld.ResourceLogs().RemoveIf(func(ResourceLogs) bool { return true })
+	return s.result()
+}
+
+// splitter tracks the Logs of each key of a split.
+type splitter[K comparable] struct {
+	splits map[K]*split
+}
+
+// split is the Logs of a key, with the last ResourceLogs and ScopeLogs the key's items were moved
+// to, copied from the ones at rlIndex and slIndex of the split Logs.
+type split struct {
+	logs    Logs
+	rl      ResourceLogs
+	rlIndex int
+	sl      ScopeLogs
+	slIndex int
+}
+
+func newSplitter[K comparable]() *splitter[K] {
+	return &splitter[K]{splits: map[K]*split{}}
+}
+
+func (s *splitter[K]) split(key K) *split {
+	sp, ok := s.splits[key]
+	if !ok {
+		sp = &split{logs: NewLogs(), rlIndex: -1}
+		s.splits[key] = sp
+	}
+	return sp
+}
+
+func (s *splitter[K]) result() map[K]Logs {
+	res := make(map[K]Logs, len(s.splits))
+	for key, sp := range s.splits {
+		res[key] = sp.logs
+	}
+	return res
+}
+
+// resourceLogs returns the ResourceLogs of the split for rl, at index i of the split Logs.
+func (sp *split) resourceLogs(i int, rl ResourceLogs) ResourceLogs {
+	if sp.rlIndex != i {
+		sp.rl = sp.logs.ResourceLogs().AppendEmpty()
+		rl.Resource().CopyTo(sp.rl.Resource())
+		sp.rl.SetSchemaUrl(rl.SchemaUrl())
+		sp.rlIndex = i
+		sp.slIndex = -1
+	}
+	return sp.rl
+}
+
+// scopeLogs returns the ScopeLogs of the split for sl, at index j of rl,
+// at index i of the split Logs.
+func (sp *split) scopeLogs(i int, rl ResourceLogs, j int, sl ScopeLogs) ScopeLogs {
+	if sp.rlIndex != i || sp.slIndex != j {
+		sp.sl = sp.resourceLogs(i, rl).ScopeLogs().AppendEmpty()
+		sl.Scope().CopyTo(sp.sl.Scope())
+		sp.sl.SetSchemaUrl(sl.SchemaUrl())
+		sp.slIndex = j
+	}
+	return sp.sl
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package plog
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendTestSplitLogRecord appends a LogRecord with the given name to sl.
+func appendTestSplitLogRecord(sl ScopeLogs, name string) {
+	sl.LogRecords().AppendEmpty().Body().SetStr(name)
+}
+
+// generateTestSplitLogs returns logs with two resources, the first one with two scopes.
+func generateTestSplitLogs() Logs {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("resource1")
+	rl.Resource().Attributes().PutStr("resource", "1")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.SetSchemaUrl("scope1")
+	sl.Scope().SetName("scope1")
+	appendTestSplitLogRecord(sl, "item1")
+	appendTestSplitLogRecord(sl, "item2")
+	appendTestSplitLogRecord(sl, "item1")
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope2")
+	appendTestSplitLogRecord(sl, "item2")
+
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("resource", "2")
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope3")
+	appendTestSplitLogRecord(sl, "item1")
+	return ld
+}
+
+func TestSplitByResource(t *testing.T) {
+	ld := generateTestSplitLogs()
+	orig := generateTestSplitLogs()
+
+	splits := SplitByResource(ld, func(rl ResourceLogs) string {
+		v, _ := rl.Resource().Attributes().Get("resource")
+		return v.Str()
+	})
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+	require.Len(t, splits, 2)
+
+	res1 := NewLogs()
+	orig.ResourceLogs().At(0).CopyTo(res1.ResourceLogs().AppendEmpty())
+	assert.Equal(t, res1, splits["1"])
+	res2 := NewLogs()
+	orig.ResourceLogs().At(1).CopyTo(res2.ResourceLogs().AppendEmpty())
+	assert.Equal(t, res2, splits["2"])
+}
+
+func TestSplitByScope(t *testing.T) {
+	ld := generateTestSplitLogs()
+	orig := generateTestSplitLogs()
+
+	splits := SplitByScope(ld, func(_ ResourceLogs, sl ScopeLogs) bool {
+		return sl.Scope().Name() == "scope2"
+	})
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+	require.Len(t, splits, 2)
+
+	scope2 := NewLogs()
+	rl := scope2.ResourceLogs().AppendEmpty()
+	orig.ResourceLogs().At(0).Resource().CopyTo(rl.Resource())
+	rl.SetSchemaUrl("resource1")
+	orig.ResourceLogs().At(0).ScopeLogs().At(1).CopyTo(rl.ScopeLogs().AppendEmpty())
+	assert.Equal(t, scope2, splits[true])
+
+	others := NewLogs()
+	rl = others.ResourceLogs().AppendEmpty()
+	orig.ResourceLogs().At(0).Resource().CopyTo(rl.Resource())
+	rl.SetSchemaUrl("resource1")
+	orig.ResourceLogs().At(0).ScopeLogs().At(0).CopyTo(rl.ScopeLogs().AppendEmpty())
+	orig.ResourceLogs().At(1).CopyTo(others.ResourceLogs().AppendEmpty())
+	assert.Equal(t, others, splits[false])
+}
+
+func TestSplitByLogRecord(t *testing.T) {
+	ld := generateTestSplitLogs()
+	splits := SplitByLogRecord(ld, func(_ ResourceLogs, _ ScopeLogs, lr LogRecord) string {
+		return lr.Body().Str()
+	})
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+	require.Len(t, splits, 2)
+
+	item1 := NewLogs()
+	rl := item1.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("resource1")
+	rl.Resource().Attributes().PutStr("resource", "1")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.SetSchemaUrl("scope1")
+	sl.Scope().SetName("scope1")
+	appendTestSplitLogRecord(sl, "item1")
+	appendTestSplitLogRecord(sl, "item1")
+	rl = item1.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("resource", "2")
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope3")
+	appendTestSplitLogRecord(sl, "item1")
+	assert.Equal(t, item1, splits["item1"])
+
+	item2 := NewLogs()
+	rl = item2.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("resource1")
+	rl.Resource().Attributes().PutStr("resource", "1")
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.SetSchemaUrl("scope1")
+	sl.Scope().SetName("scope1")
+	appendTestSplitLogRecord(sl, "item2")
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope2")
+	appendTestSplitLogRecord(sl, "item2")
+	assert.Equal(t, item2, splits["item2"])
+}
+
+func TestSplitByLogRecordEmpty(t *testing.T) {
+	ld := NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	assert.Empty(t, SplitByLogRecord(ld, func(ResourceLogs, ScopeLogs, LogRecord) int { return 0 }))
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+}
+
+func TestSplitByLogRecordReadOnly(t *testing.T) {
+	ld := generateTestSplitLogs()
+	ld.MarkReadOnly()
+	assert.Panics(t, func() { SplitByLogRecord(ld, func(ResourceLogs, ScopeLogs, LogRecord) int { return 0 }) })
+}
+
+func BenchmarkSplitByLogRecord(b *testing.B) {
+	ld := NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	for i := 0; i < 100; i++ {
+		appendTestSplitLogRecord(sl, strconv.Itoa(i%10))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		clone := NewLogs()
+		ld.CopyTo(clone)
+		b.StartTimer()
+		SplitByLogRecord(clone, func(_ ResourceLogs, _ ScopeLogs, lr LogRecord) string {
+			return lr.Body().Str()
+		})
+	}
+}
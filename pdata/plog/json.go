@@ -22,7 +22,7 @@ type JSONMarshaler struct{}
 func (*JSONMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
 	buf := bytes.Buffer{}
 	pb := internal.LogsToProto(internal.Logs(ld))
-	err := json.Marshal(&buf, &pb)
+	err := json.MarshalLogsData(&buf, &pb)
 	return buf.Bytes(), err
 }
 
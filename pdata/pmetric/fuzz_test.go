@@ -4,12 +4,51 @@
 package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/protodecode"
 )
 
+var unexpectedBytes = "expected the same bytes from unmarshaling and marshaling."
+
 func FuzzUnmarshalMetrics(f *testing.F) {
 	f.Fuzz(func(_ *testing.T, data []byte) {
 		u := &JSONUnmarshaler{}
 		_, _ = u.UnmarshalMetrics(data)
 	})
 }
+
+func FuzzUnmarshalPBMetricsStreamingDecoder(f *testing.F) {
+	md := NewMetrics()
+	fillTestResourceMetricsSlice(md.ResourceMetrics())
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	fillTestGauge(ms.AppendEmpty().SetEmptyGauge())
+	fillTestHistogram(ms.AppendEmpty().SetEmptyHistogram())
+	fillTestExponentialHistogram(ms.AppendEmpty().SetEmptyExponentialHistogram())
+	fillTestSummary(ms.AppendEmpty().SetEmptySummary())
+	buf, err := (&ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(f, err)
+	f.Add(buf)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pb1 := otlpmetrics.MetricsData{}
+		err1 := pb1.Unmarshal(data)
+		pb2 := otlpmetrics.MetricsData{}
+		err2 := protodecode.UnmarshalMetricsData(data, &pb2)
+		if err1 != nil {
+			require.EqualError(t, err2, err1.Error(), "expected the same error from both decoders")
+			return
+		}
+		require.NoError(t, err2, "failed to decode valid bytes")
+
+		b1, err := pb1.Marshal()
+		require.NoError(t, err, "failed to marshal valid struct")
+		b2, err := pb2.Marshal()
+		require.NoError(t, err, "failed to marshal valid struct")
+
+		require.True(t, bytes.Equal(b1, b2), "%s. \nexpected %d but got %d\n", unexpectedBytes, b1, b2)
+	})
+}
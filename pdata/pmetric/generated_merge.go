@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package pmetric
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal/identity"
+)
+
+// Merge moves the metrics of src into dest, and leaves src empty. The metrics are appended to the
+// first ResourceMetrics and ScopeMetrics of dest with an identical Resource and Scope, which are appended to dest
+// if there are none. Resources and Scopes are identical if they have the same schema URL, attributes, dropped
+// attributes count, and for Scopes the same name and version. Metrics with the same name are not merged.
+func Merge(dest, src Metrics) {
+	m := newMerger(dest)
+	src.ResourceMetrics().RemoveIf(func(srcRM ResourceMetrics) bool {
+		m.setResource(srcRM)
+		destRM, ok := m.resources[string(m.key)]
+		if !ok {
+			destRM = dest.ResourceMetrics().AppendEmpty()
+			srcRM.MoveTo(destRM)
+			m.add(destRM)
+			return true
+		}
+		srcRM.ScopeMetrics().RemoveIf(func(srcSM ScopeMetrics) bool {
+			m.setScope(srcSM)
+			destSM, ok := m.scopes[string(m.key)]
+			if !ok {
+				destSM = destRM.ScopeMetrics().AppendEmpty()
+				srcSM.MoveTo(destSM)
+				m.scopes[string(m.key)] = destSM
+				return true
+			}
+			srcSM.Metrics().MoveAndAppendTo(destSM.Metrics())
+			return true
+		})
+		return true
+	})
+}
+
+// merger indexes the first ResourceMetrics and ScopeMetrics of the destination of a merge by the identity of
+// their Resource and Scope.
+type merger struct {
+	// key is the identity of the last Resource, followed by the identity of the last Scope set.
+	key         []byte
+	resourceLen int
+	resources   map[string]ResourceMetrics
+	scopes      map[string]ScopeMetrics
+}
+
+func newMerger(md Metrics) *merger {
+	m := &merger{resources: map[string]ResourceMetrics{}, scopes: map[string]ScopeMetrics{}}
+	for _, rm := range md.ResourceMetrics().All() {
+		m.setResource(rm)
+		m.add(rm)
+	}
+	return m
+}
+
+func (m *merger) setResource(rm ResourceMetrics) {
+	m.key = identity.AppendResource(m.key[:0], rm.SchemaUrl(), rm.Resource())
+	m.resourceLen = len(m.key)
+}
+
+func (m *merger) setScope(sm ScopeMetrics) {
+	m.key = identity.AppendScope(m.key[:m.resourceLen], sm.SchemaUrl(), sm.Scope())
+}
+
+// add indexes rm and its ScopeMetrics under the last Resource set, unless it is already indexed.
+func (m *merger) add(rm ResourceMetrics) {
+	if _, ok := m.resources[string(m.key)]; ok {
+		return
+	}
+	m.resources[string(m.key)] = rm
+	for _, sm := range rm.ScopeMetrics().All() {
+		m.setScope(sm)
+		if _, ok := m.scopes[string(m.key)]; !ok {
+			m.scopes[string(m.key)] = sm
+		}
+	}
+}
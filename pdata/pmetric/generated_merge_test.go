@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package pmetric
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	splits := SplitByScope(generateTestSplitMetrics(), func(_ ResourceMetrics, sm ScopeMetrics) string {
+		return sm.Scope().Name()
+	})
+	dest := NewMetrics()
+	for _, scope := range []string{"scope1", "scope2", "scope3"} {
+		Merge(dest, splits[scope])
+		assert.Equal(t, 0, splits[scope].ResourceMetrics().Len())
+	}
+	assert.Equal(t, generateTestSplitMetrics(), dest)
+}
+
+func TestMergeMetrics(t *testing.T) {
+	splits := SplitByMetric(generateTestSplitMetrics(), func(_ ResourceMetrics, _ ScopeMetrics, m Metric) string {
+		return m.Name()
+	})
+	dest := NewMetrics()
+	Merge(dest, splits["item1"])
+	Merge(dest, splits["item2"])
+
+	want := generateTestSplitMetrics()
+	sm := want.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	sm.Metrics().RemoveIf(func(m Metric) bool { return m.Name() == "item2" })
+	appendTestSplitMetric(sm, "item2")
+	assert.Equal(t, want, dest)
+}
+
+func TestMergeDifferentResourcesAndScopes(t *testing.T) {
+	newMetrics := func(mutate func(rm ResourceMetrics, sm ScopeMetrics)) Metrics {
+		md := NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl("resource")
+		rm.Resource().Attributes().PutStr("resource", "1")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.SetSchemaUrl("scope")
+		sm.Scope().SetName("scope")
+		sm.Scope().SetVersion("v1")
+		sm.Scope().Attributes().PutStr("scope", "1")
+		appendTestSplitMetric(sm, "item")
+		mutate(rm, sm)
+		return md
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(rm ResourceMetrics, sm ScopeMetrics)
+		resources int
+		scopes    int
+	}{
+		{
+			name:      "identical",
+			mutate:    func(ResourceMetrics, ScopeMetrics) {},
+			resources: 1,
+			scopes:    1,
+		},
+		{
+			name:      "resource_schema_url",
+			mutate:    func(rm ResourceMetrics, _ ScopeMetrics) { rm.SetSchemaUrl("other") },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "resource_attributes",
+			mutate:    func(rm ResourceMetrics, _ ScopeMetrics) { rm.Resource().Attributes().PutInt("resource", 1) },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "resource_dropped_attributes_count",
+			mutate:    func(rm ResourceMetrics, _ ScopeMetrics) { rm.Resource().SetDroppedAttributesCount(1) },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "scope_schema_url",
+			mutate:    func(_ ResourceMetrics, sm ScopeMetrics) { sm.SetSchemaUrl("other") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_name",
+			mutate:    func(_ ResourceMetrics, sm ScopeMetrics) { sm.Scope().SetName("other") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_version",
+			mutate:    func(_ ResourceMetrics, sm ScopeMetrics) { sm.Scope().SetVersion("v2") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_attributes",
+			mutate:    func(_ ResourceMetrics, sm ScopeMetrics) { sm.Scope().Attributes().Clear() },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_dropped_attributes_count",
+			mutate:    func(_ ResourceMetrics, sm ScopeMetrics) { sm.Scope().SetDroppedAttributesCount(1) },
+			resources: 1,
+			scopes:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := newMetrics(func(ResourceMetrics, ScopeMetrics) {})
+			src := newMetrics(tt.mutate)
+			Merge(dest, src)
+			assert.Equal(t, 0, src.ResourceMetrics().Len())
+			assert.Equal(t, 2, dest.MetricCount())
+			assert.Equal(t, tt.resources, dest.ResourceMetrics().Len())
+			last := dest.ResourceMetrics().At(dest.ResourceMetrics().Len() - 1)
+			assert.Equal(t, tt.scopes, last.ScopeMetrics().Len())
+		})
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	dest := NewMetrics()
+	for i := 0; i < 2; i++ {
+		appendTestSplitMetric(dest.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty(), "dest")
+	}
+	dest.ResourceMetrics().At(0).ScopeMetrics().AppendEmpty()
+	src := NewMetrics()
+	appendTestSplitMetric(src.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty(), "src")
+	Merge(dest, src)
+
+	want := NewMetrics()
+	sm := want.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	appendTestSplitMetric(sm, "dest")
+	appendTestSplitMetric(sm, "src")
+	want.ResourceMetrics().At(0).ScopeMetrics().AppendEmpty()
+	appendTestSplitMetric(want.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty(), "dest")
+	assert.Equal(t, want, dest)
+}
+
+func TestMergeEmpty(t *testing.T) {
+	dest := NewMetrics()
+	Merge(dest, NewMetrics())
+	assert.Equal(t, NewMetrics(), dest)
+
+	src := generateTestSplitMetrics()
+	Merge(dest, src)
+	assert.Equal(t, generateTestSplitMetrics(), dest)
+	assert.Equal(t, 0, src.ResourceMetrics().Len())
+}
+
+func TestMergeReadOnly(t *testing.T) {
+	src := generateTestSplitMetrics()
+	src.MarkReadOnly()
+	assert.Panics(t, func() { Merge(NewMetrics(), src) })
+
+	dest := generateTestSplitMetrics()
+	dest.MarkReadOnly()
+	assert.Panics(t, func() { Merge(dest, generateTestSplitMetrics()) })
+}
+
+func BenchmarkMerge(b *testing.B) {
+	md := NewMetrics()
+	for i := 0; i < 10; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutInt("resource", int64(i))
+		sm := rm.ScopeMetrics().AppendEmpty()
+		for j := 0; j < 10; j++ {
+			appendTestSplitMetric(sm, strconv.Itoa(j))
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		dest := NewMetrics()
+		clone := NewMetrics()
+		md.CopyTo(clone)
+		splits := SplitByMetric(clone, func(_ ResourceMetrics, _ ScopeMetrics, m Metric) string {
+			return m.Name()
+		})
+		b.StartTimer()
+		for _, split := range splits {
+			Merge(dest, split)
+		}
+	}
+}
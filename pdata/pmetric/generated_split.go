@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package pmetric
+
+// SplitByResource moves the ResourceMetrics of md into one Metrics per key returned by key,
+// and returns them by key. The order of the ResourceMetrics is preserved, and md is left empty.
+func SplitByResource[K comparable](md Metrics, key func(ResourceMetrics) K) map[K]Metrics {
+	res := map[K]Metrics{}
+	md.ResourceMetrics().RemoveIf(func(rm ResourceMetrics) bool {
+		k := key(rm)
+		dest, ok := res[k]
+		if !ok {
+			dest = NewMetrics()
+			res[k] = dest
+		}
+		rm.MoveTo(dest.ResourceMetrics().AppendEmpty())
+		return true
+	})
+	return res
+}
+
+// SplitByScope moves the ScopeMetrics of md into one Metrics per key returned by key, under
+// copies of the Resource they belong to, and returns them by key. The order of the ScopeMetrics is preserved,
+// and md is left empty.
+func SplitByScope[K comparable](md Metrics, key func(ResourceMetrics, ScopeMetrics) K) map[K]Metrics {
+	s := newSplitter[K]()
+	for i, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			sp := s.split(key(rm, sm))
+			sm.MoveTo(sp.resourceMetrics(i, rm).ScopeMetrics().AppendEmpty())
+		}
+	}
+	md.ResourceMetrics().RemoveIf(func(ResourceMetrics) bool { return true })
+	return s.result()
+}
+
+// SplitByMetric moves the metrics of md into one Metrics per key returned by key,
+// under copies of the Resource and Scope they belong to, and returns them by key. The order of the metrics
+// is preserved, and md is left empty.
+func SplitByMetric[K comparable](md Metrics, key func(ResourceMetrics, ScopeMetrics, Metric) K) map[K]Metrics {
+	s := newSplitter[K]()
+	for i, rm := range md.ResourceMetrics().All() {
+		for j, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				sp := s.split(key(rm, sm, m))
+				m.MoveTo(sp.scopeMetrics(i, rm, j, sm).Metrics().AppendEmpty())
+			}
+		}
+	}
+	md.ResourceMetrics().RemoveIf(func(ResourceMetrics) bool { return true })
+	return s.result()
+}
+
+// splitter tracks the Metrics of each key of a split.
+type splitter[K comparable] struct {
+	splits map[K]*split
+}
+
+// split is the Metrics of a key, with the last ResourceMetrics and ScopeMetrics the key's items were moved
+// to, copied from the ones at rmIndex and smIndex of the split Metrics.
+type split struct {
+	metrics Metrics
+	rm      ResourceMetrics
+	rmIndex int
+	sm      ScopeMetrics
+	smIndex int
+}
+
+func newSplitter[K comparable]() *splitter[K] {
+	return &splitter[K]{splits: map[K]*split{}}
+}
+
+func (s *splitter[K]) split(key K) *split {
+	sp, ok := s.splits[key]
+	if !ok {
+		sp = &split{metrics: NewMetrics(), rmIndex: -1}
+		s.splits[key] = sp
+	}
+	return sp
+}
+
+func (s *splitter[K]) result() map[K]Metrics {
+	res := make(map[K]Metrics, len(s.splits))
+	for key, sp := range s.splits {
+		res[key] = sp.metrics
+	}
+	return res
+}
+
+// resourceMetrics returns the ResourceMetrics of the split for rm, at index i of the split Metrics.
+func (sp *split) resourceMetrics(i int, rm ResourceMetrics) ResourceMetrics {
+	if sp.rmIndex != i {
+		sp.rm = sp.metrics.ResourceMetrics().AppendEmpty()
+		rm.Resource().CopyTo(sp.rm.Resource())
+		sp.rm.SetSchemaUrl(rm.SchemaUrl())
+		sp.rmIndex = i
+		sp.smIndex = -1
+	}
+	return sp.rm
+}
+
+// scopeMetrics returns the ScopeMetrics of the split for sm, at index j of rm,
+// at index i of the split Metrics.
+func (sp *split) scopeMetrics(i int, rm ResourceMetrics, j int, sm ScopeMetrics) ScopeMetrics {
+	if sp.rmIndex != i || sp.smIndex != j {
+		sp.sm = sp.resourceMetrics(i, rm).ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(sp.sm.Scope())
+		sp.sm.SetSchemaUrl(sm.SchemaUrl())
+		sp.smIndex = j
+	}
+	return sp.sm
+}
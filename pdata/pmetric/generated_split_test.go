@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package pmetric
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendTestSplitMetric appends a Metric with the given name to sm.
+func appendTestSplitMetric(sm ScopeMetrics, name string) {
+	sm.Metrics().AppendEmpty().SetName(name)
+}
+
+// generateTestSplitMetrics returns metrics with two resources, the first one with two scopes.
+func generateTestSplitMetrics() Metrics {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("resource1")
+	rm.Resource().Attributes().PutStr("resource", "1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl("scope1")
+	sm.Scope().SetName("scope1")
+	appendTestSplitMetric(sm, "item1")
+	appendTestSplitMetric(sm, "item2")
+	appendTestSplitMetric(sm, "item1")
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope2")
+	appendTestSplitMetric(sm, "item2")
+
+	rm = md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("resource", "2")
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope3")
+	appendTestSplitMetric(sm, "item1")
+	return md
+}
+
+func TestSplitByResource(t *testing.T) {
+	md := generateTestSplitMetrics()
+	orig := generateTestSplitMetrics()
+
+	splits := SplitByResource(md, func(rm ResourceMetrics) string {
+		v, _ := rm.Resource().Attributes().Get("resource")
+		return v.Str()
+	})
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	require.Len(t, splits, 2)
+
+	res1 := NewMetrics()
+	orig.ResourceMetrics().At(0).CopyTo(res1.ResourceMetrics().AppendEmpty())
+	assert.Equal(t, res1, splits["1"])
+	res2 := NewMetrics()
+	orig.ResourceMetrics().At(1).CopyTo(res2.ResourceMetrics().AppendEmpty())
+	assert.Equal(t, res2, splits["2"])
+}
+
+func TestSplitByScope(t *testing.T) {
+	md := generateTestSplitMetrics()
+	orig := generateTestSplitMetrics()
+
+	splits := SplitByScope(md, func(_ ResourceMetrics, sm ScopeMetrics) bool {
+		return sm.Scope().Name() == "scope2"
+	})
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	require.Len(t, splits, 2)
+
+	scope2 := NewMetrics()
+	rm := scope2.ResourceMetrics().AppendEmpty()
+	orig.ResourceMetrics().At(0).Resource().CopyTo(rm.Resource())
+	rm.SetSchemaUrl("resource1")
+	orig.ResourceMetrics().At(0).ScopeMetrics().At(1).CopyTo(rm.ScopeMetrics().AppendEmpty())
+	assert.Equal(t, scope2, splits[true])
+
+	others := NewMetrics()
+	rm = others.ResourceMetrics().AppendEmpty()
+	orig.ResourceMetrics().At(0).Resource().CopyTo(rm.Resource())
+	rm.SetSchemaUrl("resource1")
+	orig.ResourceMetrics().At(0).ScopeMetrics().At(0).CopyTo(rm.ScopeMetrics().AppendEmpty())
+	orig.ResourceMetrics().At(1).CopyTo(others.ResourceMetrics().AppendEmpty())
+	assert.Equal(t, others, splits[false])
+}
+
+func TestSplitByMetric(t *testing.T) {
+	md := generateTestSplitMetrics()
+	splits := SplitByMetric(md, func(_ ResourceMetrics, _ ScopeMetrics, m Metric) string {
+		return m.Name()
+	})
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	require.Len(t, splits, 2)
+
+	item1 := NewMetrics()
+	rm := item1.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("resource1")
+	rm.Resource().Attributes().PutStr("resource", "1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl("scope1")
+	sm.Scope().SetName("scope1")
+	appendTestSplitMetric(sm, "item1")
+	appendTestSplitMetric(sm, "item1")
+	rm = item1.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("resource", "2")
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope3")
+	appendTestSplitMetric(sm, "item1")
+	assert.Equal(t, item1, splits["item1"])
+
+	item2 := NewMetrics()
+	rm = item2.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("resource1")
+	rm.Resource().Attributes().PutStr("resource", "1")
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl("scope1")
+	sm.Scope().SetName("scope1")
+	appendTestSplitMetric(sm, "item2")
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope2")
+	appendTestSplitMetric(sm, "item2")
+	assert.Equal(t, item2, splits["item2"])
+}
+
+func TestSplitByMetricEmpty(t *testing.T) {
+	md := NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	assert.Empty(t, SplitByMetric(md, func(ResourceMetrics, ScopeMetrics, Metric) int { return 0 }))
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+}
+
+func TestSplitByMetricReadOnly(t *testing.T) {
+	md := generateTestSplitMetrics()
+	md.MarkReadOnly()
+	assert.Panics(t, func() { SplitByMetric(md, func(ResourceMetrics, ScopeMetrics, Metric) int { return 0 }) })
+}
+
+func BenchmarkSplitByMetric(b *testing.B) {
+	md := NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for i := 0; i < 100; i++ {
+		appendTestSplitMetric(sm, strconv.Itoa(i%10))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		clone := NewMetrics()
+		md.CopyTo(clone)
+		b.StartTimer()
+		SplitByMetric(clone, func(_ ResourceMetrics, _ ScopeMetrics, m Metric) string {
+			return m.Name()
+		})
+	}
+}
@@ -24,7 +24,7 @@ type JSONMarshaler struct{}
 func (*JSONMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
 	buf := bytes.Buffer{}
 	pb := internal.MetricsToProto(internal.Metrics(md))
-	err := json.Marshal(&buf, &pb)
+	err := json.MarshalMetricsData(&buf, &pb)
 	return buf.Bytes(), err
 }
 
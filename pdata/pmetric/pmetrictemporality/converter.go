@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pmetrictemporality converts the aggregation temporality of metrics between cumulative and delta.
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// DefaultMaxStaleness is the default duration after which the state of a stream without data points is dropped.
+const DefaultMaxStaleness = 5 * time.Minute
+
+// Converter converts the Sum, Histogram and ExponentialHistogram metrics of a temporality to another one.
+// Converting requires to keep the state of each stream of data points, identified by their Resource, Scope,
+// metric name and type, and data point attributes.
+//
+// Cumulative data points are converted to the delta since the previous data point of their stream. The first data
+// point of a stream is dropped, as its delta is unknown. A data point with a start timestamp different from the
+// previous data point of its stream is a reset, and is kept as the delta since its start timestamp. A data point
+// with a value lower than the previous data point of its stream for monotonic sums and histograms is a reset at an
+// unknown time, and is kept as the delta since the previous data point.
+//
+// Delta data points are converted to the sum of the data points of their stream since its first data point, whose
+// start timestamp becomes the start timestamp of the stream. A data point that cannot be added to the previous data
+// points of its stream, e.g. a histogram with different bucket bounds, restarts the stream.
+//
+// In both cases, data points that are not after the previous data point of their stream are dropped, and metrics
+// left without data points are removed. A data point with the NoRecordedValue flag ends its stream.
+//
+// A Converter is safe for concurrent use.
+type Converter struct {
+	from, to     pmetric.AggregationTemporality
+	maxStaleness time.Duration
+	now          func() time.Time
+
+	mu            sync.Mutex
+	lastExpiry    time.Time
+	numbers       streams[numberState]
+	histograms    streams[histogramState]
+	expHistograms streams[expHistogramState]
+}
+
+// Option configures a Converter.
+type Option func(*Converter)
+
+// WithMaxStaleness sets the duration after which the state of a stream without data points is dropped, so that
+// its next data point starts a new stream. It is DefaultMaxStaleness by default, and a zero duration disables it.
+func WithMaxStaleness(maxStaleness time.Duration) Option {
+	return func(c *Converter) {
+		c.maxStaleness = maxStaleness
+	}
+}
+
+// NewConverter returns a Converter of metrics to the given temporality, which must be
+// pmetric.AggregationTemporalityCumulative or pmetric.AggregationTemporalityDelta.
+func NewConverter(to pmetric.AggregationTemporality, opts ...Option) *Converter {
+	c := &Converter{
+		to:            to,
+		maxStaleness:  DefaultMaxStaleness,
+		now:           time.Now,
+		numbers:       streams[numberState]{},
+		histograms:    streams[histogramState]{},
+		expHistograms: streams[expHistogramState]{},
+	}
+	switch to {
+	case pmetric.AggregationTemporalityCumulative:
+		c.from = pmetric.AggregationTemporalityDelta
+	case pmetric.AggregationTemporalityDelta:
+		c.from = pmetric.AggregationTemporalityCumulative
+	default:
+		panic("invalid aggregation temporality: " + to.String())
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Convert converts in place the metrics of md that have the temporality the Converter converts from. Other metrics
+// are left unchanged.
+func (c *Converter) Convert(md pmetric.Metrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.maxStaleness > 0 && now.Sub(c.lastExpiry) >= c.maxStaleness {
+		c.numbers.expire(now, c.maxStaleness)
+		c.histograms.expire(now, c.maxStaleness)
+		c.expHistograms.expire(now, c.maxStaleness)
+		c.lastExpiry = now
+	}
+
+	var id streamID
+	for _, rm := range md.ResourceMetrics().All() {
+		id.setResource(rm.Resource())
+		for _, sm := range rm.ScopeMetrics().All() {
+			id.setScope(sm.Scope())
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				id.setMetric(m)
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					sum := m.Sum()
+					if sum.AggregationTemporality() != c.from {
+						return false
+					}
+					sum.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return !convert(c, c.numbers, id.dataPoint(dp.Attributes()), dp, newNumberState(dp, sum.IsMonotonic()), now)
+					})
+					sum.SetAggregationTemporality(c.to)
+					return sum.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					histogram := m.Histogram()
+					if histogram.AggregationTemporality() != c.from {
+						return false
+					}
+					histogram.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						return !convert(c, c.histograms, id.dataPoint(dp.Attributes()), dp, newHistogramState(dp), now)
+					})
+					histogram.SetAggregationTemporality(c.to)
+					return histogram.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					histogram := m.ExponentialHistogram()
+					if histogram.AggregationTemporality() != c.from {
+						return false
+					}
+					histogram.DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+						return !convert(c, c.expHistograms, id.dataPoint(dp.Attributes()), dp, newExpHistogramState(dp), now)
+					})
+					histogram.SetAggregationTemporality(c.to)
+					return histogram.DataPoints().Len() == 0
+				}
+				return false
+			})
+		}
+	}
+}
+
+// dataPoint is the part of the data points common to all the converted metric types.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	Flags() pmetric.DataPointFlags
+}
+
+// state is the aggregated value of a data point of type P.
+type state[S any, P dataPoint] interface {
+	// sub returns the delta between the state and the previous state of its stream, or false if it is a reset.
+	sub(prev S) (S, bool)
+	// add returns the sum of the state and the delta state of its stream, or false if they cannot be added.
+	add(delta S) (S, bool)
+	// writeTo sets the value of dp to the state.
+	writeTo(dp P)
+}
+
+// convert converts dp, whose aggregated value is cur, and returns whether it must be kept.
+func convert[S state[S, P], P dataPoint](
+	c *Converter, streams streams[S], key []byte, dp P, cur S, now time.Time,
+) bool {
+	s, known := streams.get(key, now, c.maxStaleness)
+	if dp.Flags().NoRecordedValue() {
+		if known {
+			if c.to == pmetric.AggregationTemporalityDelta {
+				dp.SetStartTimestamp(s.last)
+			} else {
+				dp.SetStartTimestamp(s.start)
+			}
+		}
+		streams.delete(key)
+		return true
+	}
+	if known && dp.Timestamp() <= s.last {
+		return false
+	}
+	if c.to == pmetric.AggregationTemporalityDelta {
+		return toDelta(s, known, dp, cur)
+	}
+	toCumulative(s, known, dp, cur)
+	return true
+}
+
+// toDelta converts the cumulative dp of s, and returns whether it must be kept.
+func toDelta[S state[S, P], P dataPoint](s *stream[S], known bool, dp P, cur S) bool {
+	prevLast := s.last
+	s.last = dp.Timestamp()
+	if !known {
+		s.start = dp.StartTimestamp()
+		s.state = cur
+		return false
+	}
+	delta, ok := cur.sub(s.state)
+	start := dp.StartTimestamp()
+	restarted := start != 0 && start != s.start
+	s.start = start
+	s.state = cur
+	if restarted {
+		// The value is the delta since the new start timestamp.
+		return true
+	}
+	dp.SetStartTimestamp(prevLast)
+	if ok {
+		delta.writeTo(dp)
+	}
+	// Otherwise the stream was reset at an unknown time after the previous data point, and the value is the delta
+	// since then.
+	return true
+}
+
+// toCumulative converts the delta dp of s.
+func toCumulative[S state[S, P], P dataPoint](s *stream[S], known bool, dp P, cur S) {
+	s.last = dp.Timestamp()
+	if known {
+		if total, ok := s.state.add(cur); ok {
+			s.state = total
+			dp.SetStartTimestamp(s.start)
+			total.writeTo(dp)
+			return
+		}
+	}
+	s.start = dp.StartTimestamp()
+	if s.start == 0 {
+		s.start = dp.Timestamp()
+	}
+	s.state = cur
+	dp.SetStartTimestamp(s.start)
+}
@@ -0,0 +1,367 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// sumPoint is a data point of a sum in a test.
+type sumPoint struct {
+	start, ts int64
+	value     int64
+	attr      string
+	flags     pmetric.DataPointFlags
+}
+
+// newTestSum returns metrics with a sum of the given temporality and data points.
+func newTestSum(temporality pmetric.AggregationTemporality, monotonic bool, points ...sumPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("sum")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(temporality)
+	sum.SetIsMonotonic(monotonic)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+		dp.SetTimestamp(pcommon.Timestamp(p.ts))
+		dp.SetIntValue(p.value)
+		dp.SetFlags(p.flags)
+		if p.attr != "" {
+			dp.Attributes().PutStr("attr", p.attr)
+		}
+	}
+	return md
+}
+
+// sumPoints returns the data points of the sum of md, or nil if it was removed.
+func sumPoints(t *testing.T, md pmetric.Metrics, temporality pmetric.AggregationTemporality) []sumPoint {
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	if ms.Len() == 0 {
+		return nil
+	}
+	sum := ms.At(0).Sum()
+	assert.Equal(t, temporality, sum.AggregationTemporality())
+	var points []sumPoint
+	for _, dp := range sum.DataPoints().All() {
+		p := sumPoint{start: int64(dp.StartTimestamp()), ts: int64(dp.Timestamp()), value: dp.IntValue(), flags: dp.Flags()}
+		if v, ok := dp.Attributes().Get("attr"); ok {
+			p.attr = v.Str()
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+func TestNewConverterInvalidTemporality(t *testing.T) {
+	assert.Panics(t, func() { NewConverter(pmetric.AggregationTemporalityUnspecified) })
+}
+
+func TestCumulativeToDeltaSum(t *testing.T) {
+	tests := []struct {
+		name      string
+		monotonic bool
+		batches   [][]sumPoint
+		expected  [][]sumPoint
+	}{
+		{
+			name:      "first_point_dropped",
+			monotonic: true,
+			batches:   [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 1, ts: 20, value: 8}, {start: 1, ts: 30, value: 15}}},
+			expected:  [][]sumPoint{nil, {{start: 10, ts: 20, value: 3}, {start: 20, ts: 30, value: 7}}},
+		},
+		{
+			name:      "streams_by_attributes",
+			monotonic: true,
+			batches: [][]sumPoint{
+				{{start: 1, ts: 10, value: 5, attr: "a"}, {start: 1, ts: 10, value: 50, attr: "b"}},
+				{{start: 1, ts: 20, value: 8, attr: "a"}, {start: 1, ts: 20, value: 60, attr: "b"}},
+			},
+			expected: [][]sumPoint{nil, {{start: 10, ts: 20, value: 3, attr: "a"}, {start: 10, ts: 20, value: 10, attr: "b"}}},
+		},
+		{
+			name:      "reset_with_start_timestamp",
+			monotonic: true,
+			batches:   [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 15, ts: 20, value: 2}}, {{start: 15, ts: 30, value: 6}}},
+			expected:  [][]sumPoint{nil, {{start: 15, ts: 20, value: 2}}, {{start: 20, ts: 30, value: 4}}},
+		},
+		{
+			name:      "reset_without_start_timestamp",
+			monotonic: true,
+			batches:   [][]sumPoint{{{ts: 10, value: 5}}, {{ts: 20, value: 2}}, {{ts: 30, value: 6}}},
+			expected:  [][]sumPoint{nil, {{start: 10, ts: 20, value: 2}}, {{start: 20, ts: 30, value: 4}}},
+		},
+		{
+			name:      "non_monotonic_decrease",
+			monotonic: false,
+			batches:   [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 1, ts: 20, value: 2}}},
+			expected:  [][]sumPoint{nil, {{start: 10, ts: 20, value: -3}}},
+		},
+		{
+			name:      "out_of_order",
+			monotonic: true,
+			batches:   [][]sumPoint{{{start: 1, ts: 20, value: 5}}, {{start: 1, ts: 10, value: 3}, {start: 1, ts: 20, value: 5}, {start: 1, ts: 30, value: 9}}},
+			expected:  [][]sumPoint{nil, {{start: 20, ts: 30, value: 4}}},
+		},
+		{
+			name:      "no_recorded_value",
+			monotonic: true,
+			batches: [][]sumPoint{
+				{{start: 1, ts: 10, value: 5}},
+				{{start: 1, ts: 20, flags: pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)}},
+				{{start: 1, ts: 30, value: 7}},
+			},
+			expected: [][]sumPoint{nil, {{start: 10, ts: 20, flags: pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)}}, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter(pmetric.AggregationTemporalityDelta)
+			for i, batch := range tt.batches {
+				md := newTestSum(pmetric.AggregationTemporalityCumulative, tt.monotonic, batch...)
+				c.Convert(md)
+				assert.Equal(t, tt.expected[i], sumPoints(t, md, pmetric.AggregationTemporalityDelta), i)
+			}
+		})
+	}
+}
+
+func TestDeltaToCumulativeSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		batches  [][]sumPoint
+		expected [][]sumPoint
+	}{
+		{
+			name:     "accumulated",
+			batches:  [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 10, ts: 20, value: 3}, {start: 20, ts: 30, value: 7}}},
+			expected: [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 1, ts: 20, value: 8}, {start: 1, ts: 30, value: 15}}},
+		},
+		{
+			name: "streams_by_attributes",
+			batches: [][]sumPoint{
+				{{start: 1, ts: 10, value: 5, attr: "a"}, {start: 1, ts: 10, value: 50, attr: "b"}},
+				{{start: 10, ts: 20, value: 3, attr: "a"}, {start: 10, ts: 20, value: 10, attr: "b"}},
+			},
+			expected: [][]sumPoint{
+				{{start: 1, ts: 10, value: 5, attr: "a"}, {start: 1, ts: 10, value: 50, attr: "b"}},
+				{{start: 1, ts: 20, value: 8, attr: "a"}, {start: 1, ts: 20, value: 60, attr: "b"}},
+			},
+		},
+		{
+			name:     "gap",
+			batches:  [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 30, ts: 40, value: 3}}},
+			expected: [][]sumPoint{{{start: 1, ts: 10, value: 5}}, {{start: 1, ts: 40, value: 8}}},
+		},
+		{
+			name:     "missing_start_timestamp",
+			batches:  [][]sumPoint{{{ts: 10, value: 5}}, {{ts: 20, value: 3}}},
+			expected: [][]sumPoint{{{start: 10, ts: 10, value: 5}}, {{start: 10, ts: 20, value: 8}}},
+		},
+		{
+			name:     "out_of_order",
+			batches:  [][]sumPoint{{{start: 10, ts: 20, value: 5}}, {{start: 1, ts: 10, value: 3}, {start: 20, ts: 30, value: 1}}},
+			expected: [][]sumPoint{{{start: 10, ts: 20, value: 5}}, {{start: 10, ts: 30, value: 6}}},
+		},
+		{
+			name: "no_recorded_value",
+			batches: [][]sumPoint{
+				{{start: 1, ts: 10, value: 5}},
+				{{start: 10, ts: 20, flags: pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)}},
+				{{start: 20, ts: 30, value: 7}},
+			},
+			expected: [][]sumPoint{
+				{{start: 1, ts: 10, value: 5}},
+				{{start: 1, ts: 20, flags: pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)}},
+				{{start: 20, ts: 30, value: 7}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter(pmetric.AggregationTemporalityCumulative)
+			for i, batch := range tt.batches {
+				md := newTestSum(pmetric.AggregationTemporalityDelta, true, batch...)
+				c.Convert(md)
+				assert.Equal(t, tt.expected[i], sumPoints(t, md, pmetric.AggregationTemporalityCumulative), i)
+			}
+		})
+	}
+}
+
+func TestConvertDoubleSum(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityCumulative)
+	md := newTestSum(pmetric.AggregationTemporalityDelta, true, sumPoint{start: 1, ts: 10})
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetDoubleValue(1.5)
+	c.Convert(md)
+
+	md = newTestSum(pmetric.AggregationTemporalityDelta, true, sumPoint{start: 10, ts: 20})
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	dp.SetDoubleValue(2)
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.InDelta(t, 3.5, dp.DoubleValue(), 0)
+
+	// A change of value type restarts the stream.
+	md = newTestSum(pmetric.AggregationTemporalityDelta, true, sumPoint{start: 20, ts: 30, value: 4})
+	c.Convert(md)
+	assert.Equal(t, []sumPoint{{start: 20, ts: 30, value: 4}}, sumPoints(t, md, pmetric.AggregationTemporalityCumulative))
+}
+
+func TestConvertIdentity(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityDelta)
+	c.Convert(newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 10, value: 5}))
+
+	for name, mutate := range map[string]func(md pmetric.Metrics){
+		"resource": func(md pmetric.Metrics) {
+			md.ResourceMetrics().At(0).Resource().Attributes().PutStr("service.name", "other")
+		},
+		"scope_name": func(md pmetric.Metrics) {
+			md.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().SetName("other")
+		},
+		"scope_version": func(md pmetric.Metrics) {
+			md.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().SetVersion("v1")
+		},
+		"metric_name": func(md pmetric.Metrics) {
+			md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("other")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			md := newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 20, value: 8})
+			mutate(md)
+			c.Convert(md)
+			assert.Nil(t, sumPoints(t, md, pmetric.AggregationTemporalityDelta))
+		})
+	}
+
+	md := newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 20, value: 8})
+	c.Convert(md)
+	assert.Equal(t, []sumPoint{{start: 10, ts: 20, value: 3}}, sumPoints(t, md, pmetric.AggregationTemporalityDelta))
+}
+
+func TestConvertOtherMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().SetCount(1)
+	sum := ms.AppendEmpty().SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.DataPoints().AppendEmpty().SetIntValue(1)
+	sum = ms.AppendEmpty().SetEmptySum()
+	sum.DataPoints().AppendEmpty().SetIntValue(1)
+	histogram := ms.AppendEmpty().SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	histogram.DataPoints().AppendEmpty().SetCount(1)
+	expHistogram := ms.AppendEmpty().SetEmptyExponentialHistogram()
+	expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	expHistogram.DataPoints().AppendEmpty().SetCount(1)
+	expected := pmetric.NewMetrics()
+	md.CopyTo(expected)
+
+	NewConverter(pmetric.AggregationTemporalityDelta).Convert(md)
+	assert.Equal(t, expected, md)
+}
+
+func TestConvertMaxStaleness(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewConverter(pmetric.AggregationTemporalityDelta, WithMaxStaleness(time.Minute))
+	c.now = func() time.Time { return now }
+
+	c.Convert(newTestSum(pmetric.AggregationTemporalityCumulative, true,
+		sumPoint{start: 1, ts: 10, value: 5, attr: "a"}, sumPoint{start: 1, ts: 10, value: 5, attr: "b"}))
+	now = now.Add(time.Minute)
+	md := newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 20, value: 8, attr: "a"})
+	c.Convert(md)
+	assert.Equal(t, []sumPoint{{start: 10, ts: 20, value: 3, attr: "a"}}, sumPoints(t, md, pmetric.AggregationTemporalityDelta))
+	assert.Len(t, c.numbers, 2)
+
+	// The stream "b" is stale on its next data point, before being expired.
+	now = now.Add(time.Second)
+	md = newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 30, value: 9, attr: "b"})
+	c.Convert(md)
+	assert.Nil(t, sumPoints(t, md, pmetric.AggregationTemporalityDelta))
+
+	// The stream "a" is stale and expired.
+	now = now.Add(2 * time.Minute)
+	c.Convert(pmetric.NewMetrics())
+	assert.Empty(t, c.numbers)
+	md = newTestSum(pmetric.AggregationTemporalityCumulative, true,
+		sumPoint{start: 1, ts: 40, value: 10, attr: "a"})
+	c.Convert(md)
+	assert.Nil(t, sumPoints(t, md, pmetric.AggregationTemporalityDelta))
+}
+
+func TestConvertNoMaxStaleness(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewConverter(pmetric.AggregationTemporalityDelta, WithMaxStaleness(0))
+	c.now = func() time.Time { return now }
+
+	c.Convert(newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 10, value: 5}))
+	now = now.Add(24 * time.Hour)
+	md := newTestSum(pmetric.AggregationTemporalityCumulative, true, sumPoint{start: 1, ts: 20, value: 8})
+	c.Convert(md)
+	assert.Equal(t, []sumPoint{{start: 10, ts: 20, value: 3}}, sumPoints(t, md, pmetric.AggregationTemporalityDelta))
+}
+
+func TestConvertConcurrent(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityCumulative)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				md := newTestSum(pmetric.AggregationTemporalityDelta, true,
+					sumPoint{start: int64(j), ts: int64(j + 1), value: 1, attr: strconv.Itoa(i)})
+				c.Convert(md)
+				assert.Equal(t, []sumPoint{{start: 1, ts: int64(j + 1), value: int64(j + 1), attr: strconv.Itoa(i)}},
+					sumPoints(t, md, pmetric.AggregationTemporalityCumulative))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, c.numbers, 10)
+}
+
+func BenchmarkConvertCumulativeToDelta(b *testing.B) {
+	c := NewConverter(pmetric.AggregationTemporalityDelta)
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for i := 0; i < 10; i++ {
+		sum := ms.AppendEmpty().SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for j := 0; j < 10; j++ {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.Attributes().PutInt("index", int64(j))
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		clone := pmetric.NewMetrics()
+		md.CopyTo(clone)
+		for _, m := range clone.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().All() {
+			for _, dp := range m.Sum().DataPoints().All() {
+				dp.SetTimestamp(pcommon.Timestamp(i + 1))
+				dp.SetIntValue(int64(i))
+			}
+		}
+		b.StartTimer()
+		c.Convert(clone)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// expHistogramState is the value of a data point of an ExponentialHistogram.
+type expHistogramState struct {
+	count         uint64
+	scale         int32
+	zeroThreshold float64
+	zeroCount     uint64
+	positive      expBuckets
+	negative      expBuckets
+	sum           optionalFloat64
+	minimum       optionalFloat64
+	maximum       optionalFloat64
+}
+
+func newExpHistogramState(dp pmetric.ExponentialHistogramDataPoint) expHistogramState {
+	return expHistogramState{
+		count:         dp.Count(),
+		scale:         dp.Scale(),
+		zeroThreshold: dp.ZeroThreshold(),
+		zeroCount:     dp.ZeroCount(),
+		positive:      newExpBuckets(dp.Positive()),
+		negative:      newExpBuckets(dp.Negative()),
+		sum:           optionalFloat64{value: dp.Sum(), ok: dp.HasSum()},
+		minimum:       optionalFloat64{value: dp.Min(), ok: dp.HasMin()},
+		maximum:       optionalFloat64{value: dp.Max(), ok: dp.HasMax()},
+	}
+}
+
+func (s expHistogramState) sub(prev expHistogramState) (expHistogramState, bool) {
+	// The scale of a cumulative histogram can only decrease, when its range grows.
+	if s.scale > prev.scale || s.zeroThreshold != prev.zeroThreshold || s.count < prev.count ||
+		s.zeroCount < prev.zeroCount {
+		return expHistogramState{}, false
+	}
+	positive, ok := s.positive.sub(prev.positive.downscale(prev.scale - s.scale))
+	if !ok {
+		return expHistogramState{}, false
+	}
+	negative, ok := s.negative.sub(prev.negative.downscale(prev.scale - s.scale))
+	if !ok {
+		return expHistogramState{}, false
+	}
+	return expHistogramState{
+		count:         s.count - prev.count,
+		scale:         s.scale,
+		zeroThreshold: s.zeroThreshold,
+		zeroCount:     s.zeroCount - prev.zeroCount,
+		positive:      positive,
+		negative:      negative,
+		sum:           s.sum.sub(prev.sum),
+	}, true
+}
+
+func (s expHistogramState) add(delta expHistogramState) (expHistogramState, bool) {
+	if s.zeroThreshold != delta.zeroThreshold {
+		return expHistogramState{}, false
+	}
+	scale := min(s.scale, delta.scale)
+	return expHistogramState{
+		count:         s.count + delta.count,
+		scale:         scale,
+		zeroThreshold: s.zeroThreshold,
+		zeroCount:     s.zeroCount + delta.zeroCount,
+		positive:      s.positive.downscale(s.scale - scale).add(delta.positive.downscale(delta.scale - scale)),
+		negative:      s.negative.downscale(s.scale - scale).add(delta.negative.downscale(delta.scale - scale)),
+		sum:           s.sum.add(delta.sum),
+		minimum:       s.minimum.min(delta.minimum),
+		maximum:       s.maximum.max(delta.maximum),
+	}, true
+}
+
+func (s expHistogramState) writeTo(dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetCount(s.count)
+	dp.SetScale(s.scale)
+	dp.SetZeroCount(s.zeroCount)
+	s.positive.writeTo(dp.Positive())
+	s.negative.writeTo(dp.Negative())
+	s.sum.writeTo(dp.SetSum, dp.RemoveSum)
+	s.minimum.writeTo(dp.SetMin, dp.RemoveMin)
+	s.maximum.writeTo(dp.SetMax, dp.RemoveMax)
+}
+
+// expBuckets are the positive or negative buckets of an exponential histogram, where counts[i] is the count of the
+// bucket at index offset+i.
+type expBuckets struct {
+	offset int32
+	counts []uint64
+}
+
+func newExpBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets) expBuckets {
+	return expBuckets{offset: buckets.Offset(), counts: buckets.BucketCounts().AsRaw()}
+}
+
+// downscale returns the buckets at a scale lower by the given difference, where each bucket merges 2^by buckets.
+func (b expBuckets) downscale(by int32) expBuckets {
+	if by == 0 || len(b.counts) == 0 {
+		return b
+	}
+	offset := b.offset >> by
+	last := (b.offset + int32(len(b.counts)) - 1) >> by
+	counts := make([]uint64, last-offset+1)
+	for i, count := range b.counts {
+		counts[(b.offset+int32(i))>>by-offset] += count
+	}
+	return expBuckets{offset: offset, counts: counts}
+}
+
+// sub returns the buckets minus the previous buckets at the same scale, or false if a bucket decreased.
+func (b expBuckets) sub(prev expBuckets) (expBuckets, bool) {
+	counts := make([]uint64, len(b.counts))
+	copy(counts, b.counts)
+	for i, count := range prev.counts {
+		if count == 0 {
+			continue
+		}
+		j := int(prev.offset-b.offset) + i
+		if j < 0 || j >= len(counts) || counts[j] < count {
+			return expBuckets{}, false
+		}
+		counts[j] -= count
+	}
+	return expBuckets{offset: b.offset, counts: counts}, true
+}
+
+// add returns the sum of the buckets and the delta buckets at the same scale.
+func (b expBuckets) add(delta expBuckets) expBuckets {
+	if len(delta.counts) == 0 {
+		return b
+	}
+	if len(b.counts) == 0 {
+		return delta
+	}
+	offset := min(b.offset, delta.offset)
+	end := max(b.offset+int32(len(b.counts)), delta.offset+int32(len(delta.counts)))
+	counts := make([]uint64, end-offset)
+	for i, count := range b.counts {
+		counts[b.offset-offset+int32(i)] += count
+	}
+	for i, count := range delta.counts {
+		counts[delta.offset-offset+int32(i)] += count
+	}
+	return expBuckets{offset: offset, counts: counts}
+}
+
+func (b expBuckets) writeTo(buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	buckets.SetOffset(b.offset)
+	buckets.BucketCounts().FromRaw(b.counts)
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// newTestExpHistogram returns metrics with an exponential histogram of the given temporality and a single data point.
+func newTestExpHistogram(
+	temporality pmetric.AggregationTemporality, start, ts int64, scale, offset int32, counts []uint64,
+) (pmetric.Metrics, pmetric.ExponentialHistogramDataPoint) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("exponential_histogram")
+	histogram := m.SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(temporality)
+	dp := histogram.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.Timestamp(start))
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	dp.SetScale(scale)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().BucketCounts().FromRaw(counts)
+	count := dp.ZeroCount()
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(float64(count))
+	return md, dp
+}
+
+func TestCumulativeToDeltaExponentialHistogram(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityDelta)
+	md, _ := newTestExpHistogram(pmetric.AggregationTemporalityCumulative, 1, 10, 2, -1, []uint64{1, 2, 3, 4})
+	c.Convert(md)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	md, dp := newTestExpHistogram(pmetric.AggregationTemporalityCumulative, 1, 20, 2, -2, []uint64{1, 1, 3, 3, 4})
+	c.Convert(md)
+	histogram := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, histogram.AggregationTemporality())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, uint64(0), dp.ZeroCount())
+	assert.Equal(t, int32(-2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 0, 1, 0, 0}, dp.Positive().BucketCounts().AsRaw())
+	assert.InDelta(t, 2, dp.Sum(), 0)
+
+	// The previous data point is downscaled to the scale of the data point.
+	md, dp = newTestExpHistogram(pmetric.AggregationTemporalityCumulative, 1, 30, 1, -1, []uint64{3, 7, 4})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1, 0}, dp.Positive().BucketCounts().AsRaw())
+
+	// An increased scale is a reset.
+	md, dp = newTestExpHistogram(pmetric.AggregationTemporalityCumulative, 1, 40, 2, 0, []uint64{10})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(30), dp.StartTimestamp())
+	assert.Equal(t, []uint64{10}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestDeltaToCumulativeExponentialHistogram(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityCumulative)
+	md, dp := newTestExpHistogram(pmetric.AggregationTemporalityDelta, 1, 10, 2, -1, []uint64{1, 2, 3, 4})
+	c.Convert(md)
+	histogram := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, histogram.AggregationTemporality())
+	assert.Equal(t, uint64(11), dp.Count())
+
+	md, dp = newTestExpHistogram(pmetric.AggregationTemporalityDelta, 10, 20, 1, 1, []uint64{1, 1})
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(14), dp.Count())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 5, 5, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.InDelta(t, 14, dp.Sum(), 0)
+
+	// A different zero threshold restarts the stream.
+	md, dp = newTestExpHistogram(pmetric.AggregationTemporalityDelta, 20, 30, 1, 0, []uint64{1})
+	dp.SetZeroThreshold(0.1)
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+}
+
+func TestExpBucketsDownscale(t *testing.T) {
+	tests := []struct {
+		name     string
+		buckets  expBuckets
+		by       int32
+		expected expBuckets
+	}{
+		{
+			name:     "same_scale",
+			buckets:  expBuckets{offset: 3, counts: []uint64{1, 2}},
+			expected: expBuckets{offset: 3, counts: []uint64{1, 2}},
+		},
+		{
+			name:     "empty",
+			buckets:  expBuckets{offset: 3},
+			by:       2,
+			expected: expBuckets{offset: 3},
+		},
+		{
+			name:     "positive_offset",
+			buckets:  expBuckets{offset: 3, counts: []uint64{1, 2, 3, 4}},
+			by:       1,
+			expected: expBuckets{offset: 1, counts: []uint64{1, 5, 4}},
+		},
+		{
+			name:     "negative_offset",
+			buckets:  expBuckets{offset: -3, counts: []uint64{1, 2, 3, 4, 5}},
+			by:       2,
+			expected: expBuckets{offset: -1, counts: []uint64{6, 9}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.buckets.downscale(tt.by))
+		})
+	}
+}
+
+func TestExpBucketsAddSub(t *testing.T) {
+	a := expBuckets{offset: -1, counts: []uint64{1, 2}}
+	b := expBuckets{offset: 1, counts: []uint64{3}}
+	sum := a.add(b)
+	assert.Equal(t, expBuckets{offset: -1, counts: []uint64{1, 2, 3}}, sum)
+	assert.Equal(t, a, a.add(expBuckets{}))
+	assert.Equal(t, b, expBuckets{}.add(b))
+
+	diff, ok := sum.sub(a)
+	assert.True(t, ok)
+	assert.Equal(t, expBuckets{offset: -1, counts: []uint64{0, 0, 3}}, diff)
+	_, ok = a.sub(sum)
+	assert.False(t, ok)
+	_, ok = a.sub(expBuckets{offset: -1, counts: []uint64{2}})
+	assert.False(t, ok)
+	diff, ok = a.sub(expBuckets{offset: -5, counts: []uint64{0, 0, 0, 0, 1}})
+	assert.True(t, ok)
+	assert.Equal(t, expBuckets{offset: -1, counts: []uint64{0, 2}}, diff)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// histogramState is the value of a data point of a Histogram.
+type histogramState struct {
+	count        uint64
+	bounds       []float64
+	bucketCounts []uint64
+	sum          optionalFloat64
+	minimum      optionalFloat64
+	maximum      optionalFloat64
+}
+
+func newHistogramState(dp pmetric.HistogramDataPoint) histogramState {
+	return histogramState{
+		count:        dp.Count(),
+		bounds:       dp.ExplicitBounds().AsRaw(),
+		bucketCounts: dp.BucketCounts().AsRaw(),
+		sum:          optionalFloat64{value: dp.Sum(), ok: dp.HasSum()},
+		minimum:      optionalFloat64{value: dp.Min(), ok: dp.HasMin()},
+		maximum:      optionalFloat64{value: dp.Max(), ok: dp.HasMax()},
+	}
+}
+
+func (s histogramState) sub(prev histogramState) (histogramState, bool) {
+	if !slices.Equal(s.bounds, prev.bounds) || len(s.bucketCounts) != len(prev.bucketCounts) || s.count < prev.count {
+		return histogramState{}, false
+	}
+	bucketCounts := make([]uint64, len(s.bucketCounts))
+	for i, count := range s.bucketCounts {
+		if count < prev.bucketCounts[i] {
+			return histogramState{}, false
+		}
+		bucketCounts[i] = count - prev.bucketCounts[i]
+	}
+	return histogramState{
+		count:        s.count - prev.count,
+		bounds:       s.bounds,
+		bucketCounts: bucketCounts,
+		sum:          s.sum.sub(prev.sum),
+	}, true
+}
+
+func (s histogramState) add(delta histogramState) (histogramState, bool) {
+	if !slices.Equal(s.bounds, delta.bounds) || len(s.bucketCounts) != len(delta.bucketCounts) {
+		return histogramState{}, false
+	}
+	bucketCounts := make([]uint64, len(s.bucketCounts))
+	for i, count := range s.bucketCounts {
+		bucketCounts[i] = count + delta.bucketCounts[i]
+	}
+	return histogramState{
+		count:        s.count + delta.count,
+		bounds:       s.bounds,
+		bucketCounts: bucketCounts,
+		sum:          s.sum.add(delta.sum),
+		minimum:      s.minimum.min(delta.minimum),
+		maximum:      s.maximum.max(delta.maximum),
+	}, true
+}
+
+func (s histogramState) writeTo(dp pmetric.HistogramDataPoint) {
+	dp.SetCount(s.count)
+	dp.BucketCounts().FromRaw(s.bucketCounts)
+	s.sum.writeTo(dp.SetSum, dp.RemoveSum)
+	s.minimum.writeTo(dp.SetMin, dp.RemoveMin)
+	s.maximum.writeTo(dp.SetMax, dp.RemoveMax)
+}
+
+// optionalFloat64 is an optional field of a histogram.
+type optionalFloat64 struct {
+	value float64
+	ok    bool
+}
+
+func (o optionalFloat64) sub(prev optionalFloat64) optionalFloat64 {
+	return optionalFloat64{value: o.value - prev.value, ok: o.ok && prev.ok}
+}
+
+func (o optionalFloat64) add(delta optionalFloat64) optionalFloat64 {
+	return optionalFloat64{value: o.value + delta.value, ok: o.ok && delta.ok}
+}
+
+func (o optionalFloat64) min(delta optionalFloat64) optionalFloat64 {
+	return optionalFloat64{value: min(o.value, delta.value), ok: o.ok && delta.ok}
+}
+
+func (o optionalFloat64) max(delta optionalFloat64) optionalFloat64 {
+	return optionalFloat64{value: max(o.value, delta.value), ok: o.ok && delta.ok}
+}
+
+func (o optionalFloat64) writeTo(set func(float64), remove func()) {
+	if o.ok {
+		set(o.value)
+	} else {
+		remove()
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// newTestHistogram returns metrics with a histogram of the given temporality and a single data point.
+func newTestHistogram(
+	temporality pmetric.AggregationTemporality, start, ts int64, bounds []float64, counts []uint64, sum, minimum, maximum float64,
+) (pmetric.Metrics, pmetric.HistogramDataPoint) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("histogram")
+	histogram := m.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(temporality)
+	dp := histogram.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.Timestamp(start))
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	var count uint64
+	for _, c := range counts {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(minimum)
+	dp.SetMax(maximum)
+	return md, dp
+}
+
+func TestCumulativeToDeltaHistogram(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityDelta)
+	md, _ := newTestHistogram(pmetric.AggregationTemporalityCumulative, 1, 10, []float64{1, 10}, []uint64{1, 2, 3}, 50, 0.5, 20)
+	c.Convert(md)
+	assert.Equal(t, 0, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+
+	md, dp := newTestHistogram(pmetric.AggregationTemporalityCumulative, 1, 20, []float64{1, 10}, []uint64{2, 2, 5}, 80, 0.5, 30)
+	c.Convert(md)
+	histogram := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, histogram.AggregationTemporality())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, []uint64{1, 0, 2}, dp.BucketCounts().AsRaw())
+	assert.InDelta(t, 30, dp.Sum(), 0)
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+
+	// A bucket count decreasing is a reset.
+	md, dp = newTestHistogram(pmetric.AggregationTemporalityCumulative, 1, 30, []float64{1, 10}, []uint64{3, 1, 5}, 90, 0.1, 30)
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, []uint64{3, 1, 5}, dp.BucketCounts().AsRaw())
+	assert.True(t, dp.HasMin())
+
+	// Different bounds are a reset.
+	md, dp = newTestHistogram(pmetric.AggregationTemporalityCumulative, 1, 40, []float64{5}, []uint64{5, 10}, 100, 0.1, 30)
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(30), dp.StartTimestamp())
+	assert.Equal(t, []uint64{5, 10}, dp.BucketCounts().AsRaw())
+}
+
+func TestDeltaToCumulativeHistogram(t *testing.T) {
+	c := NewConverter(pmetric.AggregationTemporalityCumulative)
+	md, dp := newTestHistogram(pmetric.AggregationTemporalityDelta, 1, 10, []float64{1, 10}, []uint64{1, 2, 3}, 50, 0.5, 20)
+	c.Convert(md)
+	histogram := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, histogram.AggregationTemporality())
+	assert.Equal(t, []uint64{1, 2, 3}, dp.BucketCounts().AsRaw())
+
+	md, dp = newTestHistogram(pmetric.AggregationTemporalityDelta, 10, 20, []float64{1, 10}, []uint64{1, 0, 2}, 30, 0.1, 10)
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, []uint64{2, 2, 5}, dp.BucketCounts().AsRaw())
+	assert.InDelta(t, 80, dp.Sum(), 0)
+	assert.InDelta(t, 0.1, dp.Min(), 0)
+	assert.InDelta(t, 20, dp.Max(), 0)
+
+	// A data point without sum removes the sum of the stream.
+	md, dp = newTestHistogram(pmetric.AggregationTemporalityDelta, 20, 30, []float64{1, 10}, []uint64{0, 1, 0}, 0, 2, 2)
+	dp.RemoveSum()
+	c.Convert(md)
+	assert.Equal(t, []uint64{2, 3, 5}, dp.BucketCounts().AsRaw())
+	assert.False(t, dp.HasSum())
+
+	// Different bounds restart the stream.
+	md, dp = newTestHistogram(pmetric.AggregationTemporalityDelta, 30, 40, []float64{5}, []uint64{1, 1}, 10, 1, 9)
+	c.Convert(md)
+	assert.Equal(t, pcommon.Timestamp(30), dp.StartTimestamp())
+	assert.Equal(t, []uint64{1, 1}, dp.BucketCounts().AsRaw())
+	require.True(t, dp.HasSum())
+	assert.InDelta(t, 10, dp.Sum(), 0)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/internal/identity"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// stream is the state of a stream of data points.
+type stream[S any] struct {
+	// lastSeen is when the last data point of the stream was converted.
+	lastSeen time.Time
+	// start and last are the start timestamp of the stream and the timestamp of its last data point.
+	start pcommon.Timestamp
+	last  pcommon.Timestamp
+	state S
+}
+
+// streams are the streams of a metric type by identity.
+type streams[S any] map[string]*stream[S]
+
+// get returns the stream with the given identity, and whether it is known. Unknown streams are created, and stale
+// streams are returned as unknown.
+func (ss streams[S]) get(key []byte, now time.Time, maxStaleness time.Duration) (*stream[S], bool) {
+	s, ok := ss[string(key)]
+	switch {
+	case !ok:
+		s = &stream[S]{}
+		ss[string(key)] = s
+	case maxStaleness > 0 && now.Sub(s.lastSeen) > maxStaleness:
+		ok = false
+	}
+	s.lastSeen = now
+	return s, ok
+}
+
+func (ss streams[S]) delete(key []byte) {
+	delete(ss, string(key))
+}
+
+// expire deletes the streams without data points for more than maxStaleness.
+func (ss streams[S]) expire(now time.Time, maxStaleness time.Duration) {
+	for key, s := range ss {
+		if now.Sub(s.lastSeen) > maxStaleness {
+			delete(ss, key)
+		}
+	}
+}
+
+// streamID builds the identity of the streams of data points, from their Resource, Scope, metric and attributes.
+type streamID struct {
+	buf         []byte
+	resourceLen int
+	scopeLen    int
+	metricLen   int
+}
+
+func (id *streamID) setResource(res pcommon.Resource) {
+	id.buf = identity.AppendMap(id.buf[:0], res.Attributes())
+	id.resourceLen = len(id.buf)
+}
+
+func (id *streamID) setScope(scope pcommon.InstrumentationScope) {
+	id.buf = identity.AppendString(id.buf[:id.resourceLen], scope.Name())
+	id.buf = identity.AppendString(id.buf, scope.Version())
+	id.buf = identity.AppendMap(id.buf, scope.Attributes())
+	id.scopeLen = len(id.buf)
+}
+
+func (id *streamID) setMetric(m pmetric.Metric) {
+	id.buf = identity.AppendString(id.buf[:id.scopeLen], m.Name())
+	id.buf = append(id.buf, byte(m.Type()))
+	id.metricLen = len(id.buf)
+}
+
+// dataPoint returns the identity of the stream of a data point with the given attributes. It is only valid until
+// the next call.
+func (id *streamID) dataPoint(attrs pcommon.Map) []byte {
+	id.buf = identity.AppendMap(id.buf[:id.metricLen], attrs)
+	return id.buf
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// numberState is the value of a data point of a Sum.
+type numberState struct {
+	monotonic bool
+	valueType pmetric.NumberDataPointValueType
+	intValue  int64
+	double    float64
+}
+
+func newNumberState(dp pmetric.NumberDataPoint, monotonic bool) numberState {
+	return numberState{
+		monotonic: monotonic,
+		valueType: dp.ValueType(),
+		intValue:  dp.IntValue(),
+		double:    dp.DoubleValue(),
+	}
+}
+
+func (s numberState) sub(prev numberState) (numberState, bool) {
+	if s.valueType != prev.valueType {
+		return numberState{}, false
+	}
+	if s.monotonic && (s.intValue < prev.intValue || s.double < prev.double) {
+		return numberState{}, false
+	}
+	s.intValue -= prev.intValue
+	s.double -= prev.double
+	return s, true
+}
+
+func (s numberState) add(delta numberState) (numberState, bool) {
+	if s.valueType != delta.valueType {
+		return numberState{}, false
+	}
+	s.intValue += delta.intValue
+	s.double += delta.double
+	return s, true
+}
+
+func (s numberState) writeTo(dp pmetric.NumberDataPoint) {
+	switch s.valueType {
+	case pmetric.NumberDataPointValueTypeInt:
+		dp.SetIntValue(s.intValue)
+	case pmetric.NumberDataPointValueTypeDouble:
+		dp.SetDoubleValue(s.double)
+	}
+}
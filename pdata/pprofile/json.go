@@ -22,7 +22,7 @@ type JSONMarshaler struct{}
 func (*JSONMarshaler) MarshalProfiles(td Profiles) ([]byte, error) {
 	buf := bytes.Buffer{}
 	pb := internal.ProfilesToProto(internal.Profiles(td))
-	err := json.Marshal(&buf, &pb)
+	err := json.MarshalProfilesData(&buf, &pb)
 	return buf.Bytes(), err
 }
 
@@ -58,7 +58,7 @@ func (ms ExportRequest) UnmarshalProto(data []byte) error {
 // MarshalJSON marshals ExportRequest into JSON bytes.
 func (ms ExportRequest) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
-	if err := json.Marshal(&buf, ms.orig); err != nil {
+	if err := json.MarshalExportProfilesServiceRequest(&buf, ms.orig); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/protodecode"
 )
 
 var unexpectedBytes = "expected the same bytes from unmarshaling and marshaling."
@@ -55,3 +58,29 @@ func FuzzUnmarshalPBTraces(f *testing.F) {
 		require.True(t, bytes.Equal(b1, b2), "%s. \nexpected %d but got %d\n", unexpectedBytes, b1, b2)
 	})
 }
+
+func FuzzUnmarshalPBTracesStreamingDecoder(f *testing.F) {
+	td := NewTraces()
+	fillTestResourceSpansSlice(td.ResourceSpans())
+	buf, err := (&ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(f, err)
+	f.Add(buf)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pb1 := otlptrace.TracesData{}
+		err1 := pb1.Unmarshal(data)
+		pb2 := otlptrace.TracesData{}
+		err2 := protodecode.UnmarshalTracesData(data, &pb2)
+		if err1 != nil {
+			require.EqualError(t, err2, err1.Error(), "expected the same error from both decoders")
+			return
+		}
+		require.NoError(t, err2, "failed to decode valid bytes")
+
+		b1, err := pb1.Marshal()
+		require.NoError(t, err, "failed to marshal valid struct")
+		b2, err := pb2.Marshal()
+		require.NoError(t, err, "failed to marshal valid struct")
+
+		require.True(t, bytes.Equal(b1, b2), "%s. \nexpected %d but got %d\n", unexpectedBytes, b1, b2)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package ptrace
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal/identity"
+)
+
+// Merge moves the spans of src into dest, and leaves src empty. The spans are appended to the
+// first ResourceSpans and ScopeSpans of dest with an identical Resource and Scope, which are appended to dest
+// if there are none. Resources and Scopes are identical if they have the same schema URL, attributes, dropped
+// attributes count, and for Scopes the same name and version.
+func Merge(dest, src Traces) {
+	m := newMerger(dest)
+	src.ResourceSpans().RemoveIf(func(srcRS ResourceSpans) bool {
+		m.setResource(srcRS)
+		destRS, ok := m.resources[string(m.key)]
+		if !ok {
+			destRS = dest.ResourceSpans().AppendEmpty()
+			srcRS.MoveTo(destRS)
+			m.add(destRS)
+			return true
+		}
+		srcRS.ScopeSpans().RemoveIf(func(srcSS ScopeSpans) bool {
+			m.setScope(srcSS)
+			destSS, ok := m.scopes[string(m.key)]
+			if !ok {
+				destSS = destRS.ScopeSpans().AppendEmpty()
+				srcSS.MoveTo(destSS)
+				m.scopes[string(m.key)] = destSS
+				return true
+			}
+			srcSS.Spans().MoveAndAppendTo(destSS.Spans())
+			return true
+		})
+		return true
+	})
+}
+
+// merger indexes the first ResourceSpans and ScopeSpans of the destination of a merge by the identity of
+// their Resource and Scope.
+type merger struct {
+	// key is the identity of the last Resource, followed by the identity of the last Scope set.
+	key         []byte
+	resourceLen int
+	resources   map[string]ResourceSpans
+	scopes      map[string]ScopeSpans
+}
+
+func newMerger(td Traces) *merger {
+	m := &merger{resources: map[string]ResourceSpans{}, scopes: map[string]ScopeSpans{}}
+	for _, rs := range td.ResourceSpans().All() {
+		m.setResource(rs)
+		m.add(rs)
+	}
+	return m
+}
+
+func (m *merger) setResource(rs ResourceSpans) {
+	m.key = identity.AppendResource(m.key[:0], rs.SchemaUrl(), rs.Resource())
+	m.resourceLen = len(m.key)
+}
+
+func (m *merger) setScope(ss ScopeSpans) {
+	m.key = identity.AppendScope(m.key[:m.resourceLen], ss.SchemaUrl(), ss.Scope())
+}
+
+// add indexes rs and its ScopeSpans under the last Resource set, unless it is already indexed.
+func (m *merger) add(rs ResourceSpans) {
+	if _, ok := m.resources[string(m.key)]; ok {
+		return
+	}
+	m.resources[string(m.key)] = rs
+	for _, ss := range rs.ScopeSpans().All() {
+		m.setScope(ss)
+		if _, ok := m.scopes[string(m.key)]; !ok {
+			m.scopes[string(m.key)] = ss
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package ptrace
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	splits := SplitByScope(generateTestSplitTraces(), func(_ ResourceSpans, ss ScopeSpans) string {
+		return ss.Scope().Name()
+	})
+	dest := NewTraces()
+	for _, scope := range []string{"scope1", "scope2", "scope3"} {
+		Merge(dest, splits[scope])
+		assert.Equal(t, 0, splits[scope].ResourceSpans().Len())
+	}
+	assert.Equal(t, generateTestSplitTraces(), dest)
+}
+
+func TestMergeSpans(t *testing.T) {
+	splits := SplitBySpan(generateTestSplitTraces(), func(_ ResourceSpans, _ ScopeSpans, span Span) string {
+		return span.Name()
+	})
+	dest := NewTraces()
+	Merge(dest, splits["item1"])
+	Merge(dest, splits["item2"])
+
+	want := generateTestSplitTraces()
+	ss := want.ResourceSpans().At(0).ScopeSpans().At(0)
+	ss.Spans().RemoveIf(func(span Span) bool { return span.Name() == "item2" })
+	appendTestSplitSpan(ss, "item2")
+	assert.Equal(t, want, dest)
+}
+
+func TestMergeDifferentResourcesAndScopes(t *testing.T) {
+	newTraces := func(mutate func(rs ResourceSpans, ss ScopeSpans)) Traces {
+		td := NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl("resource")
+		rs.Resource().Attributes().PutStr("resource", "1")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl("scope")
+		ss.Scope().SetName("scope")
+		ss.Scope().SetVersion("v1")
+		ss.Scope().Attributes().PutStr("scope", "1")
+		appendTestSplitSpan(ss, "item")
+		mutate(rs, ss)
+		return td
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(rs ResourceSpans, ss ScopeSpans)
+		resources int
+		scopes    int
+	}{
+		{
+			name:      "identical",
+			mutate:    func(ResourceSpans, ScopeSpans) {},
+			resources: 1,
+			scopes:    1,
+		},
+		{
+			name:      "resource_schema_url",
+			mutate:    func(rs ResourceSpans, _ ScopeSpans) { rs.SetSchemaUrl("other") },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "resource_attributes",
+			mutate:    func(rs ResourceSpans, _ ScopeSpans) { rs.Resource().Attributes().PutInt("resource", 1) },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "resource_dropped_attributes_count",
+			mutate:    func(rs ResourceSpans, _ ScopeSpans) { rs.Resource().SetDroppedAttributesCount(1) },
+			resources: 2,
+			scopes:    1,
+		},
+		{
+			name:      "scope_schema_url",
+			mutate:    func(_ ResourceSpans, ss ScopeSpans) { ss.SetSchemaUrl("other") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_name",
+			mutate:    func(_ ResourceSpans, ss ScopeSpans) { ss.Scope().SetName("other") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_version",
+			mutate:    func(_ ResourceSpans, ss ScopeSpans) { ss.Scope().SetVersion("v2") },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_attributes",
+			mutate:    func(_ ResourceSpans, ss ScopeSpans) { ss.Scope().Attributes().Clear() },
+			resources: 1,
+			scopes:    2,
+		},
+		{
+			name:      "scope_dropped_attributes_count",
+			mutate:    func(_ ResourceSpans, ss ScopeSpans) { ss.Scope().SetDroppedAttributesCount(1) },
+			resources: 1,
+			scopes:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := newTraces(func(ResourceSpans, ScopeSpans) {})
+			src := newTraces(tt.mutate)
+			Merge(dest, src)
+			assert.Equal(t, 0, src.ResourceSpans().Len())
+			assert.Equal(t, 2, dest.SpanCount())
+			assert.Equal(t, tt.resources, dest.ResourceSpans().Len())
+			last := dest.ResourceSpans().At(dest.ResourceSpans().Len() - 1)
+			assert.Equal(t, tt.scopes, last.ScopeSpans().Len())
+		})
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	dest := NewTraces()
+	for i := 0; i < 2; i++ {
+		appendTestSplitSpan(dest.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty(), "dest")
+	}
+	dest.ResourceSpans().At(0).ScopeSpans().AppendEmpty()
+	src := NewTraces()
+	appendTestSplitSpan(src.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty(), "src")
+	Merge(dest, src)
+
+	want := NewTraces()
+	ss := want.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	appendTestSplitSpan(ss, "dest")
+	appendTestSplitSpan(ss, "src")
+	want.ResourceSpans().At(0).ScopeSpans().AppendEmpty()
+	appendTestSplitSpan(want.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty(), "dest")
+	assert.Equal(t, want, dest)
+}
+
+func TestMergeEmpty(t *testing.T) {
+	dest := NewTraces()
+	Merge(dest, NewTraces())
+	assert.Equal(t, NewTraces(), dest)
+
+	src := generateTestSplitTraces()
+	Merge(dest, src)
+	assert.Equal(t, generateTestSplitTraces(), dest)
+	assert.Equal(t, 0, src.ResourceSpans().Len())
+}
+
+func TestMergeReadOnly(t *testing.T) {
+	src := generateTestSplitTraces()
+	src.MarkReadOnly()
+	assert.Panics(t, func() { Merge(NewTraces(), src) })
+
+	dest := generateTestSplitTraces()
+	dest.MarkReadOnly()
+	assert.Panics(t, func() { Merge(dest, generateTestSplitTraces()) })
+}
+
+func BenchmarkMerge(b *testing.B) {
+	td := NewTraces()
+	for i := 0; i < 10; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", int64(i))
+		ss := rs.ScopeSpans().AppendEmpty()
+		for j := 0; j < 10; j++ {
+			appendTestSplitSpan(ss, strconv.Itoa(j))
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		dest := NewTraces()
+		clone := NewTraces()
+		td.CopyTo(clone)
+		splits := SplitBySpan(clone, func(_ ResourceSpans, _ ScopeSpans, span Span) string {
+			return span.Name()
+		})
+		b.StartTimer()
+		for _, split := range splits {
+			Merge(dest, split)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package ptrace
+
+// SplitByResource moves the ResourceSpans of td into one Traces per key returned by key,
+// and returns them by key. The order of the ResourceSpans is preserved, and td is left empty.
+func SplitByResource[K comparable](td Traces, key func(ResourceSpans) K) map[K]Traces {
+	res := map[K]Traces{}
+	td.ResourceSpans().RemoveIf(func(rs ResourceSpans) bool {
+		k := key(rs)
+		dest, ok := res[k]
+		if !ok {
+			dest = NewTraces()
+			res[k] = dest
+		}
+		rs.MoveTo(dest.ResourceSpans().AppendEmpty())
+		return true
+	})
+	return res
+}
+
+// SplitByScope moves the ScopeSpans of td into one Traces per key returned by key, under
+// copies of the Resource they belong to, and returns them by key. The order of the ScopeSpans is preserved,
+// and td is left empty.
+func SplitByScope[K comparable](td Traces, key func(ResourceSpans, ScopeSpans) K) map[K]Traces {
+	s := newSplitter[K]()
+	for i, rs := range td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			sp := s.split(key(rs, ss))
+			ss.MoveTo(sp.resourceSpans(i, rs).ScopeSpans().AppendEmpty())
+		}
+	}
+	td.ResourceSpans().RemoveIf(func(ResourceSpans) bool { return true })
+	return s.result()
+}
+
+// SplitBySpan moves the spans of td into one Traces per key returned by key,
+// under copies of the Resource and Scope they belong to, and returns them by key. The order of the spans
+// is preserved, and td is left empty.
+func SplitBySpan[K comparable](td Traces, key func(ResourceSpans, ScopeSpans, Span) K) map[K]Traces {
+	s := newSplitter[K]()
+	for i, rs := range td.ResourceSpans().All() {
+		for j, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				sp := s.split(key(rs, ss, span))
+				span.MoveTo(sp.scopeSpans(i, rs, j, ss).Spans().AppendEmpty())
+			}
+		}
+	}
+	td.ResourceSpans().RemoveIf(func(ResourceSpans) bool { return true })
+	return s.result()
+}
+
+// splitter tracks the Traces of each key of a split.
+type splitter[K comparable] struct {
+	splits map[K]*split
+}
+
+// split is the Traces of a key, with the last ResourceSpans and ScopeSpans the key's items were moved
+// to, copied from the ones at rsIndex and ssIndex of the split Traces.
+type split struct {
+	traces  Traces
+	rs      ResourceSpans
+	rsIndex int
+	ss      ScopeSpans
+	ssIndex int
+}
+
+func newSplitter[K comparable]() *splitter[K] {
+	return &splitter[K]{splits: map[K]*split{}}
+}
+
+func (s *splitter[K]) split(key K) *split {
+	sp, ok := s.splits[key]
+	if !ok {
+		sp = &split{traces: NewTraces(), rsIndex: -1}
+		s.splits[key] = sp
+	}
+	return sp
+}
+
+func (s *splitter[K]) result() map[K]Traces {
+	res := make(map[K]Traces, len(s.splits))
+	for key, sp := range s.splits {
+		res[key] = sp.traces
+	}
+	return res
+}
+
+// resourceSpans returns the ResourceSpans of the split for rs, at index i of the split Traces.
+func (sp *split) resourceSpans(i int, rs ResourceSpans) ResourceSpans {
+	if sp.rsIndex != i {
+		sp.rs = sp.traces.ResourceSpans().AppendEmpty()
+		rs.Resource().CopyTo(sp.rs.Resource())
+		sp.rs.SetSchemaUrl(rs.SchemaUrl())
+		sp.rsIndex = i
+		sp.ssIndex = -1
+	}
+	return sp.rs
+}
+
+// scopeSpans returns the ScopeSpans of the split for ss, at index j of rs,
+// at index i of the split Traces.
+func (sp *split) scopeSpans(i int, rs ResourceSpans, j int, ss ScopeSpans) ScopeSpans {
+	if sp.rsIndex != i || sp.ssIndex != j {
+		sp.ss = sp.resourceSpans(i, rs).ScopeSpans().AppendEmpty()
+		ss.Scope().CopyTo(sp.ss.Scope())
+		sp.ss.SetSchemaUrl(ss.SchemaUrl())
+		sp.ssIndex = j
+	}
+	return sp.ss
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by "pdata/internal/cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "make genpdata".
+
+package ptrace
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendTestSplitSpan appends a Span with the given name to ss.
+func appendTestSplitSpan(ss ScopeSpans, name string) {
+	ss.Spans().AppendEmpty().SetName(name)
+}
+
+// generateTestSplitTraces returns traces with two resources, the first one with two scopes.
+func generateTestSplitTraces() Traces {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("resource1")
+	rs.Resource().Attributes().PutStr("resource", "1")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl("scope1")
+	ss.Scope().SetName("scope1")
+	appendTestSplitSpan(ss, "item1")
+	appendTestSplitSpan(ss, "item2")
+	appendTestSplitSpan(ss, "item1")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope2")
+	appendTestSplitSpan(ss, "item2")
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope3")
+	appendTestSplitSpan(ss, "item1")
+	return td
+}
+
+func TestSplitByResource(t *testing.T) {
+	td := generateTestSplitTraces()
+	orig := generateTestSplitTraces()
+
+	splits := SplitByResource(td, func(rs ResourceSpans) string {
+		v, _ := rs.Resource().Attributes().Get("resource")
+		return v.Str()
+	})
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	require.Len(t, splits, 2)
+
+	res1 := NewTraces()
+	orig.ResourceSpans().At(0).CopyTo(res1.ResourceSpans().AppendEmpty())
+	assert.Equal(t, res1, splits["1"])
+	res2 := NewTraces()
+	orig.ResourceSpans().At(1).CopyTo(res2.ResourceSpans().AppendEmpty())
+	assert.Equal(t, res2, splits["2"])
+}
+
+func TestSplitByScope(t *testing.T) {
+	td := generateTestSplitTraces()
+	orig := generateTestSplitTraces()
+
+	splits := SplitByScope(td, func(_ ResourceSpans, ss ScopeSpans) bool {
+		return ss.Scope().Name() == "scope2"
+	})
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	require.Len(t, splits, 2)
+
+	scope2 := NewTraces()
+	rs := scope2.ResourceSpans().AppendEmpty()
+	orig.ResourceSpans().At(0).Resource().CopyTo(rs.Resource())
+	rs.SetSchemaUrl("resource1")
+	orig.ResourceSpans().At(0).ScopeSpans().At(1).CopyTo(rs.ScopeSpans().AppendEmpty())
+	assert.Equal(t, scope2, splits[true])
+
+	others := NewTraces()
+	rs = others.ResourceSpans().AppendEmpty()
+	orig.ResourceSpans().At(0).Resource().CopyTo(rs.Resource())
+	rs.SetSchemaUrl("resource1")
+	orig.ResourceSpans().At(0).ScopeSpans().At(0).CopyTo(rs.ScopeSpans().AppendEmpty())
+	orig.ResourceSpans().At(1).CopyTo(others.ResourceSpans().AppendEmpty())
+	assert.Equal(t, others, splits[false])
+}
+
+func TestSplitBySpan(t *testing.T) {
+	td := generateTestSplitTraces()
+	splits := SplitBySpan(td, func(_ ResourceSpans, _ ScopeSpans, span Span) string {
+		return span.Name()
+	})
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	require.Len(t, splits, 2)
+
+	item1 := NewTraces()
+	rs := item1.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("resource1")
+	rs.Resource().Attributes().PutStr("resource", "1")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl("scope1")
+	ss.Scope().SetName("scope1")
+	appendTestSplitSpan(ss, "item1")
+	appendTestSplitSpan(ss, "item1")
+	rs = item1.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope3")
+	appendTestSplitSpan(ss, "item1")
+	assert.Equal(t, item1, splits["item1"])
+
+	item2 := NewTraces()
+	rs = item2.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("resource1")
+	rs.Resource().Attributes().PutStr("resource", "1")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl("scope1")
+	ss.Scope().SetName("scope1")
+	appendTestSplitSpan(ss, "item2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope2")
+	appendTestSplitSpan(ss, "item2")
+	assert.Equal(t, item2, splits["item2"])
+}
+
+func TestSplitBySpanEmpty(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	assert.Empty(t, SplitBySpan(td, func(ResourceSpans, ScopeSpans, Span) int { return 0 }))
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestSplitBySpanReadOnly(t *testing.T) {
+	td := generateTestSplitTraces()
+	td.MarkReadOnly()
+	assert.Panics(t, func() { SplitBySpan(td, func(ResourceSpans, ScopeSpans, Span) int { return 0 }) })
+}
+
+func BenchmarkSplitBySpan(b *testing.B) {
+	td := NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < 100; i++ {
+		appendTestSplitSpan(ss, strconv.Itoa(i%10))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		clone := NewTraces()
+		td.CopyTo(clone)
+		b.StartTimer()
+		SplitBySpan(clone, func(_ ResourceSpans, _ ScopeSpans, span Span) string {
+			return span.Name()
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// IndexedSpan is a span of a TracesIndex, with the ResourceSpans and ScopeSpans it belongs to.
+type IndexedSpan struct {
+	ResourceSpans ResourceSpans
+	ScopeSpans    ScopeSpans
+	Span          Span
+}
+
+// TracesIndex indexes the spans of a Traces by trace and span IDs, and by parent span.
+// Span IDs are only unique within a trace, so spans are looked up by both their trace ID and span ID.
+//
+// The index is computed on its first lookup, after which the Traces must not be modified while the index
+// is in use. A TracesIndex is safe for concurrent use.
+type TracesIndex struct {
+	td   Traces
+	once sync.Once
+
+	spans []IndexedSpan
+	// byID are the indexes in spans of the first span with a trace and span ID.
+	byID map[spanKey]int
+	// children are the indexes in spans of the children of a span.
+	children map[spanKey][]int
+	traces   map[pcommon.TraceID]*indexedTrace
+	// traceIDs are the trace IDs in the order of their first span.
+	traceIDs []pcommon.TraceID
+}
+
+type spanKey struct {
+	traceID pcommon.TraceID
+	spanID  pcommon.SpanID
+}
+
+type indexedTrace struct {
+	spans []int
+	roots []int
+}
+
+// NewTracesIndex returns a TracesIndex of the spans of td.
+func NewTracesIndex(td Traces) *TracesIndex {
+	return &TracesIndex{td: td}
+}
+
+func (idx *TracesIndex) build() {
+	idx.spans = make([]IndexedSpan, 0, idx.td.SpanCount())
+	idx.byID = make(map[spanKey]int, cap(idx.spans))
+	idx.children = make(map[spanKey][]int)
+	idx.traces = make(map[pcommon.TraceID]*indexedTrace)
+	for _, rs := range idx.td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				i := len(idx.spans)
+				idx.spans = append(idx.spans, IndexedSpan{ResourceSpans: rs, ScopeSpans: ss, Span: span})
+
+				traceID := span.TraceID()
+				key := spanKey{traceID: traceID, spanID: span.SpanID()}
+				if _, ok := idx.byID[key]; !ok {
+					idx.byID[key] = i
+				}
+				trace, ok := idx.traces[traceID]
+				if !ok {
+					trace = &indexedTrace{}
+					idx.traces[traceID] = trace
+					idx.traceIDs = append(idx.traceIDs, traceID)
+				}
+				trace.spans = append(trace.spans, i)
+				if parentID := span.ParentSpanID(); parentID.IsEmpty() {
+					trace.roots = append(trace.roots, i)
+				} else {
+					parent := spanKey{traceID: traceID, spanID: parentID}
+					idx.children[parent] = append(idx.children[parent], i)
+				}
+			}
+		}
+	}
+}
+
+// values returns an iterator over the spans at the given indexes.
+func (idx *TracesIndex) values(indexes []int) iter.Seq[IndexedSpan] {
+	return func(yield func(IndexedSpan) bool) {
+		for _, i := range indexes {
+			if !yield(idx.spans[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Span returns the span with the given trace and span IDs. If several spans have the same IDs, the first one
+// is returned.
+func (idx *TracesIndex) Span(traceID pcommon.TraceID, spanID pcommon.SpanID) (IndexedSpan, bool) {
+	idx.once.Do(idx.build)
+	i, ok := idx.byID[spanKey{traceID: traceID, spanID: spanID}]
+	if !ok {
+		return IndexedSpan{}, false
+	}
+	return idx.spans[i], true
+}
+
+// Children returns an iterator over the spans whose parent is the span with the given trace and span IDs,
+// in the order of the Traces.
+func (idx *TracesIndex) Children(traceID pcommon.TraceID, spanID pcommon.SpanID) iter.Seq[IndexedSpan] {
+	idx.once.Do(idx.build)
+	return idx.values(idx.children[spanKey{traceID: traceID, spanID: spanID}])
+}
+
+// TraceIDs returns an iterator over the trace IDs of the spans, in the order of their first span.
+func (idx *TracesIndex) TraceIDs() iter.Seq[pcommon.TraceID] {
+	idx.once.Do(idx.build)
+	return slices.Values(idx.traceIDs)
+}
+
+// TraceSpans returns an iterator over the spans of the trace with the given ID, in the order of the Traces.
+func (idx *TracesIndex) TraceSpans(traceID pcommon.TraceID) iter.Seq[IndexedSpan] {
+	idx.once.Do(idx.build)
+	if trace, ok := idx.traces[traceID]; ok {
+		return idx.values(trace.spans)
+	}
+	return idx.values(nil)
+}
+
+// RootSpans returns an iterator over the spans of the trace with the given ID without a parent span ID, in the
+// order of the Traces. Spans whose parent is missing from the Traces are not root spans.
+func (idx *TracesIndex) RootSpans(traceID pcommon.TraceID) iter.Seq[IndexedSpan] {
+	idx.once.Do(idx.build)
+	if trace, ok := idx.traces[traceID]; ok {
+		return idx.values(trace.roots)
+	}
+	return idx.values(nil)
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	testTraceID1 = pcommon.TraceID([16]byte{1})
+	testTraceID2 = pcommon.TraceID([16]byte{2})
+)
+
+// appendTestSpan appends a span with the given IDs and name to ss.
+func appendTestSpan(ss ScopeSpans, traceID pcommon.TraceID, spanID, parentSpanID byte, name string) {
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(pcommon.SpanID([8]byte{spanID}))
+	if parentSpanID != 0 {
+		span.SetParentSpanID(pcommon.SpanID([8]byte{parentSpanID}))
+	}
+	span.SetName(name)
+}
+
+// generateTestIndexedTraces returns traces with two traces interleaved across two resources and scopes.
+func generateTestIndexedTraces() Traces {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("resource1")
+	rs.Resource().Attributes().PutStr("resource", "1")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl("scope1")
+	ss.Scope().SetName("scope1")
+	appendTestSpan(ss, testTraceID1, 1, 0, "root1")
+	appendTestSpan(ss, testTraceID2, 1, 0, "root2")
+	appendTestSpan(ss, testTraceID1, 2, 1, "child1")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope2")
+	appendTestSpan(ss, testTraceID1, 3, 1, "child2")
+	appendTestSpan(ss, testTraceID2, 2, 1, "child3")
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope3")
+	appendTestSpan(ss, testTraceID1, 4, 2, "grandchild")
+	appendTestSpan(ss, testTraceID1, 5, 9, "orphan")
+	appendTestSpan(ss, testTraceID1, 1, 0, "duplicate")
+	return td
+}
+
+func spanNames(spans iter.Seq[IndexedSpan]) []string {
+	var names []string
+	for span := range spans {
+		names = append(names, span.Span.Name())
+	}
+	return names
+}
+
+func TestTracesIndex(t *testing.T) {
+	td := generateTestIndexedTraces()
+	idx := NewTracesIndex(td)
+
+	span, ok := idx.Span(testTraceID1, pcommon.SpanID([8]byte{2}))
+	require.True(t, ok)
+	assert.Equal(t, "child1", span.Span.Name())
+	assert.Equal(t, "scope1", span.ScopeSpans.Scope().Name())
+	assert.Equal(t, td.ResourceSpans().At(0), span.ResourceSpans)
+
+	span, ok = idx.Span(testTraceID2, pcommon.SpanID([8]byte{1}))
+	require.True(t, ok)
+	assert.Equal(t, "root2", span.Span.Name())
+
+	span, ok = idx.Span(testTraceID1, pcommon.SpanID([8]byte{1}))
+	require.True(t, ok)
+	assert.Equal(t, "root1", span.Span.Name())
+
+	_, ok = idx.Span(testTraceID2, pcommon.SpanID([8]byte{3}))
+	assert.False(t, ok)
+
+	assert.Equal(t, []pcommon.TraceID{testTraceID1, testTraceID2}, slices.Collect(idx.TraceIDs()))
+	assert.Equal(t, []string{"root1", "child1", "child2", "grandchild", "orphan", "duplicate"}, spanNames(idx.TraceSpans(testTraceID1)))
+	assert.Equal(t, []string{"root2", "child3"}, spanNames(idx.TraceSpans(testTraceID2)))
+	assert.Empty(t, spanNames(idx.TraceSpans(pcommon.NewTraceIDEmpty())))
+
+	assert.Equal(t, []string{"root1", "duplicate"}, spanNames(idx.RootSpans(testTraceID1)))
+	assert.Equal(t, []string{"root2"}, spanNames(idx.RootSpans(testTraceID2)))
+	assert.Empty(t, spanNames(idx.RootSpans(pcommon.NewTraceIDEmpty())))
+
+	assert.Equal(t, []string{"child1", "child2"}, spanNames(idx.Children(testTraceID1, pcommon.SpanID([8]byte{1}))))
+	assert.Equal(t, []string{"grandchild"}, spanNames(idx.Children(testTraceID1, pcommon.SpanID([8]byte{2}))))
+	assert.Equal(t, []string{"orphan"}, spanNames(idx.Children(testTraceID1, pcommon.SpanID([8]byte{9}))))
+	assert.Equal(t, []string{"child3"}, spanNames(idx.Children(testTraceID2, pcommon.SpanID([8]byte{1}))))
+	assert.Empty(t, spanNames(idx.Children(testTraceID1, pcommon.SpanID([8]byte{4}))))
+}
+
+func TestTracesIndexStopIteration(t *testing.T) {
+	idx := NewTracesIndex(generateTestIndexedTraces())
+	for span := range idx.TraceSpans(testTraceID1) {
+		assert.Equal(t, "root1", span.Span.Name())
+		break
+	}
+	for traceID := range idx.TraceIDs() {
+		assert.Equal(t, testTraceID1, traceID)
+		break
+	}
+}
+
+func TestTracesIndexEmpty(t *testing.T) {
+	idx := NewTracesIndex(NewTraces())
+	_, ok := idx.Span(testTraceID1, pcommon.SpanID([8]byte{1}))
+	assert.False(t, ok)
+	assert.Empty(t, slices.Collect(idx.TraceIDs()))
+}
+
+func TestTracesIndexConcurrent(t *testing.T) {
+	idx := NewTracesIndex(generateTestIndexedTraces())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok := idx.Span(testTraceID1, pcommon.SpanID([8]byte{5}))
+			assert.True(t, ok)
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkTracesIndex(b *testing.B) {
+	td := NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < 100; i++ {
+		traceID := pcommon.TraceID([16]byte{byte(i % 10)})
+		appendTestSpan(ss, traceID, byte(i/10+1), byte(i/20), "span")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := NewTracesIndex(td)
+		_, _ = idx.Span(testTraceID1, pcommon.SpanID([8]byte{1}))
+	}
+}
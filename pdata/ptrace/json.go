@@ -22,7 +22,7 @@ type JSONMarshaler struct{}
 func (*JSONMarshaler) MarshalTraces(td Traces) ([]byte, error) {
 	buf := bytes.Buffer{}
 	pb := internal.TracesToProto(internal.Traces(td))
-	err := json.Marshal(&buf, &pb)
+	err := json.MarshalTracesData(&buf, &pb)
 	return buf.Bytes(), err
 }
 
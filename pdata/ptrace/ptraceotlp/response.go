@@ -41,7 +41,7 @@ func (ms ExportResponse) UnmarshalProto(data []byte) error {
 // MarshalJSON marshals ExportResponse into JSON bytes.
 func (ms ExportResponse) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
-	if err := json.Marshal(&buf, ms.orig); err != nil {
+	if err := json.MarshalExportTraceServiceResponse(&buf, ms.orig); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// SplitByTraceID moves the spans of td into one Traces per trace ID, under copies of the Resource and Scope
+// they belong to, and returns them by trace ID. The order of the spans is preserved, and td is left empty.
+func SplitByTraceID(td Traces) map[pcommon.TraceID]Traces {
+	return SplitBySpan(td, func(_ ResourceSpans, _ ScopeSpans, span Span) pcommon.TraceID {
+		return span.TraceID()
+	})
+}
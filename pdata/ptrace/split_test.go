@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSplitByTraceID(t *testing.T) {
+	td := generateTestIndexedTraces()
+	orig := NewTraces()
+	td.CopyTo(orig)
+
+	splits := SplitByTraceID(td)
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	require.Len(t, splits, 2)
+
+	trace1 := NewTraces()
+	rs := trace1.ResourceSpans().AppendEmpty()
+	orig.ResourceSpans().At(0).Resource().CopyTo(rs.Resource())
+	rs.SetSchemaUrl("resource1")
+	ss := rs.ScopeSpans().AppendEmpty()
+	orig.ResourceSpans().At(0).ScopeSpans().At(0).Scope().CopyTo(ss.Scope())
+	ss.SetSchemaUrl("scope1")
+	appendTestSpan(ss, testTraceID1, 1, 0, "root1")
+	appendTestSpan(ss, testTraceID1, 2, 1, "child1")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope2")
+	appendTestSpan(ss, testTraceID1, 3, 1, "child2")
+	rs = trace1.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("resource", "2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope3")
+	appendTestSpan(ss, testTraceID1, 4, 2, "grandchild")
+	appendTestSpan(ss, testTraceID1, 5, 9, "orphan")
+	appendTestSpan(ss, testTraceID1, 1, 0, "duplicate")
+	assert.Equal(t, trace1, splits[testTraceID1])
+
+	trace2 := NewTraces()
+	rs = trace2.ResourceSpans().AppendEmpty()
+	orig.ResourceSpans().At(0).Resource().CopyTo(rs.Resource())
+	rs.SetSchemaUrl("resource1")
+	ss = rs.ScopeSpans().AppendEmpty()
+	orig.ResourceSpans().At(0).ScopeSpans().At(0).Scope().CopyTo(ss.Scope())
+	ss.SetSchemaUrl("scope1")
+	appendTestSpan(ss, testTraceID2, 1, 0, "root2")
+	ss = rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope2")
+	appendTestSpan(ss, testTraceID2, 2, 1, "child3")
+	assert.Equal(t, trace2, splits[testTraceID2])
+}
+
+func TestSplitByTraceIDEmpty(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	assert.Empty(t, SplitByTraceID(td))
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestSplitByTraceIDReadOnly(t *testing.T) {
+	td := generateTestIndexedTraces()
+	td.MarkReadOnly()
+	assert.Panics(t, func() { SplitByTraceID(td) })
+}
+
+func BenchmarkSplitByTraceID(b *testing.B) {
+	td := NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < 100; i++ {
+		appendTestSpan(ss, pcommon.TraceID([16]byte{byte(i % 10)}), byte(i/10+1), 0, "span")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		clone := NewTraces()
+		td.CopyTo(clone)
+		b.StartTimer()
+		SplitByTraceID(clone)
+	}
+}
@@ -0,0 +1,5 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package xpdata provides the experimental APIs of pdata, which may change or be removed without notice.
+package xpdata // import "go.opentelemetry.io/collector/pdata/xpdata"
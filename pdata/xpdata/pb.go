@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xpdata // import "go.opentelemetry.io/collector/pdata/xpdata"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/protodecode"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+var (
+	_ ptrace.Unmarshaler  = (*StreamingProtoUnmarshaler)(nil)
+	_ pmetric.Unmarshaler = (*StreamingProtoUnmarshaler)(nil)
+	_ plog.Unmarshaler    = (*StreamingProtoUnmarshaler)(nil)
+)
+
+// StreamingProtoUnmarshaler unmarshals the OTLP protobuf payloads with a streaming decoder. It decodes the same
+// payloads as the ProtoUnmarshaler of ptrace, pmetric and plog, with the same errors, but allocates less memory:
+// the repeated fields are presized and the attribute values are pooled. The attribute values are still decoded
+// eagerly.
+type StreamingProtoUnmarshaler struct{}
+
+func (d *StreamingProtoUnmarshaler) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	pb := otlptrace.TracesData{}
+	err := protodecode.UnmarshalTracesData(buf, &pb)
+	return ptrace.Traces(internal.TracesFromProto(pb)), err
+}
+
+func (d *StreamingProtoUnmarshaler) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	pb := otlpmetrics.MetricsData{}
+	err := protodecode.UnmarshalMetricsData(buf, &pb)
+	return pmetric.Metrics(internal.MetricsFromProto(pb)), err
+}
+
+func (d *StreamingProtoUnmarshaler) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	pb := otlplogs.LogsData{}
+	err := protodecode.UnmarshalLogsData(buf, &pb)
+	return plog.Logs(internal.LogsFromProto(pb)), err
+}
+
+// UnmarshalTracesExportRequest unmarshals a ptraceotlp.ExportRequest from proto bytes, like its UnmarshalProto method.
+func (d *StreamingProtoUnmarshaler) UnmarshalTracesExportRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+	orig := &otlpcollectortrace.ExportTraceServiceRequest{}
+	if err := protodecode.UnmarshalExportTraceServiceRequest(buf, orig); err != nil {
+		return ptraceotlp.NewExportRequest(), err
+	}
+	otlp.MigrateTraces(orig.ResourceSpans)
+	state := internal.StateMutable
+	return ptraceotlp.NewExportRequestFromTraces(ptrace.Traces(internal.NewTraces(orig, &state))), nil
+}
+
+// UnmarshalMetricsExportRequest unmarshals a pmetricotlp.ExportRequest from proto bytes, like its UnmarshalProto method.
+func (d *StreamingProtoUnmarshaler) UnmarshalMetricsExportRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+	orig := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+	if err := protodecode.UnmarshalExportMetricsServiceRequest(buf, orig); err != nil {
+		return pmetricotlp.NewExportRequest(), err
+	}
+	state := internal.StateMutable
+	return pmetricotlp.NewExportRequestFromMetrics(pmetric.Metrics(internal.NewMetrics(orig, &state))), nil
+}
+
+// UnmarshalLogsExportRequest unmarshals a plogotlp.ExportRequest from proto bytes, like its UnmarshalProto method.
+func (d *StreamingProtoUnmarshaler) UnmarshalLogsExportRequest(buf []byte) (plogotlp.ExportRequest, error) {
+	orig := &otlpcollectorlogs.ExportLogsServiceRequest{}
+	if err := protodecode.UnmarshalExportLogsServiceRequest(buf, orig); err != nil {
+		return plogotlp.NewExportRequest(), err
+	}
+	otlp.MigrateLogs(orig.ResourceLogs)
+	state := internal.StateMutable
+	return plogotlp.NewExportRequestFromLogs(plog.Logs(internal.NewLogs(orig, &state))), nil
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xpdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestStreamingProtoUnmarshalerTraces(t *testing.T) {
+	td := testdata.GenerateTraces(10)
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	got, err := unmarshaler.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	_, err = unmarshaler.UnmarshalTraces([]byte("+$%"))
+	assert.Error(t, err)
+}
+
+func TestStreamingProtoUnmarshalerMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsAllTypes()
+	buf, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	got, err := unmarshaler.UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+
+	_, err = unmarshaler.UnmarshalMetrics([]byte("+$%"))
+	assert.Error(t, err)
+}
+
+func TestStreamingProtoUnmarshalerLogs(t *testing.T) {
+	ld := testdata.GenerateLogs(10)
+	buf, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	got, err := unmarshaler.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+
+	_, err = unmarshaler.UnmarshalLogs([]byte("+$%"))
+	assert.Error(t, err)
+}
+
+func TestStreamingProtoUnmarshalerTracesExportRequest(t *testing.T) {
+	buf, err := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(10)).MarshalProto()
+	require.NoError(t, err)
+	want := ptraceotlp.NewExportRequest()
+	require.NoError(t, want.UnmarshalProto(buf))
+
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	got, err := unmarshaler.UnmarshalTracesExportRequest(buf)
+	require.NoError(t, err)
+	assert.Equal(t, want.Traces(), got.Traces())
+
+	_, err = unmarshaler.UnmarshalTracesExportRequest([]byte("+$%"))
+	assert.EqualError(t, err, want.UnmarshalProto([]byte("+$%")).Error())
+}
+
+func TestStreamingProtoUnmarshalerMetricsExportRequest(t *testing.T) {
+	buf, err := pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetricsAllTypes()).MarshalProto()
+	require.NoError(t, err)
+	want := pmetricotlp.NewExportRequest()
+	require.NoError(t, want.UnmarshalProto(buf))
+
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	got, err := unmarshaler.UnmarshalMetricsExportRequest(buf)
+	require.NoError(t, err)
+	assert.Equal(t, want.Metrics(), got.Metrics())
+
+	_, err = unmarshaler.UnmarshalMetricsExportRequest([]byte("+$%"))
+	assert.EqualError(t, err, want.UnmarshalProto([]byte("+$%")).Error())
+}
+
+func TestStreamingProtoUnmarshalerLogsExportRequest(t *testing.T) {
+	buf, err := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(10)).MarshalProto()
+	require.NoError(t, err)
+	want := plogotlp.NewExportRequest()
+	require.NoError(t, want.UnmarshalProto(buf))
+
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	got, err := unmarshaler.UnmarshalLogsExportRequest(buf)
+	require.NoError(t, err)
+	assert.Equal(t, want.Logs(), got.Logs())
+
+	_, err = unmarshaler.UnmarshalLogsExportRequest([]byte("+$%"))
+	assert.EqualError(t, err, want.UnmarshalProto([]byte("+$%")).Error())
+}
+
+func BenchmarkTracesFromProtoStreamingDecoder(b *testing.B) {
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	baseTraces := testdata.GenerateTraces(128)
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(baseTraces)
+	require.NoError(b, err)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		traces, err := unmarshaler.UnmarshalTraces(buf)
+		require.NoError(b, err)
+		assert.Equal(b, baseTraces.ResourceSpans().Len(), traces.ResourceSpans().Len())
+	}
+}
+
+func BenchmarkMetricsFromProtoStreamingDecoder(b *testing.B) {
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	baseMetrics := testdata.GenerateMetrics(128)
+	buf, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(baseMetrics)
+	require.NoError(b, err)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		metrics, err := unmarshaler.UnmarshalMetrics(buf)
+		require.NoError(b, err)
+		assert.Equal(b, baseMetrics.ResourceMetrics().Len(), metrics.ResourceMetrics().Len())
+	}
+}
+
+func BenchmarkLogsFromProtoStreamingDecoder(b *testing.B) {
+	unmarshaler := &StreamingProtoUnmarshaler{}
+	baseLogs := testdata.GenerateLogs(128)
+	buf, err := (&plog.ProtoMarshaler{}).MarshalLogs(baseLogs)
+	require.NoError(b, err)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		logs, err := unmarshaler.UnmarshalLogs(buf)
+		require.NoError(b, err)
+		assert.Equal(b, baseLogs.ResourceLogs().Len(), logs.ResourceLogs().Len())
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor // import "go.opentelemetry.io/collector/processor/samplingprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines configuration for sampling processor.
+type Config struct {
+	// SamplingPercentage is the percentage of the traces to keep, from 0 to 100.
+	// The log records are sampled by their trace ID with the same percentage.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+
+	// SamplingPrecision is the number of hexadecimal digits of the sampling threshold
+	// written in the `th` value of the tracestate, from 1 to 14.
+	// The leading `f` digits of the threshold are not counted.
+	SamplingPrecision int `mapstructure:"sampling_precision"`
+
+	// FailClosed indicates whether the items without randomness, i.e. without a trace ID
+	// nor an `rv` value in the tracestate, are dropped. Otherwise, they are kept.
+	FailClosed bool `mapstructure:"fail_closed"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.SamplingPercentage < 0 || cfg.SamplingPercentage > 100 {
+		return errors.New("sampling_percentage must be between 0 and 100")
+	}
+	if cfg.SamplingPrecision < 1 || cfg.SamplingPrecision > thresholdDigits {
+		return fmt.Errorf("sampling_precision must be between 1 and %d", thresholdDigits)
+	}
+	return nil
+}
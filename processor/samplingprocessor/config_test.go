@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			SamplingPercentage: 15.3,
+			SamplingPrecision:  6,
+			FailClosed:         false,
+		}, cfg)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{SamplingPercentage: 25, SamplingPrecision: 4},
+		},
+		{
+			name: "zero_percentage",
+			cfg:  &Config{SamplingPercentage: 0, SamplingPrecision: 14},
+		},
+		{
+			name:        "negative_percentage",
+			cfg:         &Config{SamplingPercentage: -1, SamplingPrecision: 4},
+			expectedErr: "sampling_percentage must be between 0 and 100",
+		},
+		{
+			name:        "percentage_too_high",
+			cfg:         &Config{SamplingPercentage: 100.1, SamplingPrecision: 4},
+			expectedErr: "sampling_percentage must be between 0 and 100",
+		},
+		{
+			name:        "zero_precision",
+			cfg:         &Config{SamplingPercentage: 25, SamplingPrecision: 0},
+			expectedErr: "sampling_precision must be between 1 and 14",
+		},
+		{
+			name:        "precision_too_high",
+			cfg:         &Config{SamplingPercentage: 25, SamplingPrecision: 15},
+			expectedErr: "sampling_precision must be between 1 and 14",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package samplingprocessor implements a processor sampling the traces and the logs by trace ID,
+// consistently with the other samplers following the OpenTelemetry specification.
+package samplingprocessor // import "go.opentelemetry.io/collector/processor/samplingprocessor"
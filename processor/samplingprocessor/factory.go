@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor // import "go.opentelemetry.io/collector/processor/samplingprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/samplingprocessor/internal/metadata"
+)
+
+const (
+	defaultSamplingPercentage = 100
+	defaultSamplingPrecision  = 4
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the Sampling processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTraces, metadata.TracesStability),
+		processor.WithLogs(createLogs, metadata.LogsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		SamplingPercentage: defaultSamplingPercentage,
+		SamplingPrecision:  defaultSamplingPrecision,
+		FailClosed:         true,
+	}
+}
+
+func createTraces(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (processor.Traces, error) {
+	sp := newSamplingProcessor(cfg.(*Config))
+	return processorhelper.NewTraces(ctx, set, cfg, nextConsumer,
+		sp.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogs(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	sp := newSamplingProcessor(cfg.(*Config))
+	return processorhelper.NewLogs(ctx, set, cfg, nextConsumer,
+		sp.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
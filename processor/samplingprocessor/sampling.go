@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor // import "go.opentelemetry.io/collector/processor/samplingprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type samplingProcessor struct {
+	// threshold is the rejection threshold of the configured sampling percentage.
+	threshold  uint64
+	failClosed bool
+}
+
+func newSamplingProcessor(cfg *Config) *samplingProcessor {
+	return &samplingProcessor{
+		threshold:  probabilityToThreshold(cfg.SamplingPercentage/100, cfg.SamplingPrecision),
+		failClosed: cfg.FailClosed,
+	}
+}
+
+func (sp *samplingProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !sp.sampleSpan(span)
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (sp *samplingProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !sp.sampleLogRecord(lr)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// sampleSpan returns whether the span is kept, and updates the threshold of its tracestate if so.
+//
+// The span is sampled with the highest of the configured threshold and the threshold of its tracestate,
+// so the spans already sampled with a lower probability by the previous samplers are kept as is.
+func (sp *samplingProcessor) sampleSpan(span ptrace.Span) bool {
+	raw := span.TraceState().AsRaw()
+	ts := parseTraceState(raw)
+	randomness, ok := ts.randomness, ts.hasRandomness
+	if !ok {
+		randomness, ok = traceIDRandomness(span.TraceID())
+	}
+	if !ok {
+		return !sp.failClosed
+	}
+
+	threshold := sp.threshold
+	// A threshold inconsistent with the randomness is replaced.
+	if ts.hasThreshold && ts.threshold <= randomness {
+		threshold = max(threshold, ts.threshold)
+	}
+	if randomness < threshold {
+		return false
+	}
+	if (ts.hasThreshold && threshold != ts.threshold) || (!ts.hasThreshold && threshold != 0) {
+		span.TraceState().FromRaw(withThreshold(raw, threshold))
+	}
+	return true
+}
+
+// sampleLogRecord returns whether the log record is kept, based on its trace ID.
+func (sp *samplingProcessor) sampleLogRecord(lr plog.LogRecord) bool {
+	randomness, ok := traceIDRandomness(lr.TraceID())
+	if !ok {
+		return !sp.failClosed
+	}
+	return randomness >= sp.threshold
+}
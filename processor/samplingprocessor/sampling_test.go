@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// traceIDWithRandomness returns a trace ID with the given randomness in its 56 least significant bits.
+func traceIDWithRandomness(randomness uint64) pcommon.TraceID {
+	traceID := pcommon.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	for i := 15; i > 8; i-- {
+		traceID[i] = byte(randomness)
+		randomness >>= 8
+	}
+	return traceID
+}
+
+func TestSampleSpan(t *testing.T) {
+	tests := []struct {
+		name               string
+		percentage         float64
+		traceID            pcommon.TraceID
+		traceState         string
+		expectedSampled    bool
+		expectedTraceState string
+	}{
+		{
+			name:               "sampled",
+			percentage:         25,
+			traceID:            traceIDWithRandomness(0xd0000000000000),
+			traceState:         "vendor=value",
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:c,vendor=value",
+		},
+		{
+			name:            "dropped",
+			percentage:      25,
+			traceID:         traceIDWithRandomness(0xbfffffffffffff),
+			expectedSampled: false,
+		},
+		{
+			name:               "randomness_from_tracestate",
+			percentage:         25,
+			traceID:            traceIDWithRandomness(0),
+			traceState:         "ot=rv:d0000000000000",
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:c;rv:d0000000000000",
+		},
+		{
+			name:            "dropped_by_randomness_from_tracestate",
+			percentage:      25,
+			traceID:         traceIDWithRandomness(0xd0000000000000),
+			traceState:      "ot=rv:00000000000000",
+			expectedSampled: false,
+		},
+		{
+			name:               "higher_upstream_probability",
+			percentage:         25,
+			traceID:            traceIDWithRandomness(0xd0000000000000),
+			traceState:         "ot=th:8",
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:c",
+		},
+		{
+			name:            "dropped_despite_upstream_sampling",
+			percentage:      25,
+			traceID:         traceIDWithRandomness(0x90000000000000),
+			traceState:      "ot=th:8",
+			expectedSampled: false,
+		},
+		{
+			name:               "lower_upstream_probability",
+			percentage:         50,
+			traceID:            traceIDWithRandomness(0xd0000000000000),
+			traceState:         "vendor=value,ot=th:c",
+			expectedSampled:    true,
+			expectedTraceState: "vendor=value,ot=th:c",
+		},
+		{
+			name:               "inconsistent_upstream_threshold",
+			percentage:         50,
+			traceID:            traceIDWithRandomness(0x90000000000000),
+			traceState:         "ot=th:c",
+			expectedSampled:    true,
+			expectedTraceState: "ot=th:8",
+		},
+		{
+			name:               "all_sampled",
+			percentage:         100,
+			traceID:            traceIDWithRandomness(0),
+			traceState:         "vendor=value",
+			expectedSampled:    true,
+			expectedTraceState: "vendor=value",
+		},
+		{
+			name:            "none_sampled",
+			percentage:      0,
+			traceID:         traceIDWithRandomness(0xffffffffffffff),
+			expectedSampled: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := newSamplingProcessor(&Config{SamplingPercentage: tt.percentage, SamplingPrecision: 4, FailClosed: true})
+			span := ptrace.NewSpan()
+			span.SetTraceID(tt.traceID)
+			span.TraceState().FromRaw(tt.traceState)
+			assert.Equal(t, tt.expectedSampled, sp.sampleSpan(span))
+			if tt.expectedSampled {
+				assert.Equal(t, tt.expectedTraceState, span.TraceState().AsRaw())
+			}
+		})
+	}
+}
+
+func TestSampleWithoutRandomness(t *testing.T) {
+	for _, failClosed := range []bool{true, false} {
+		sp := newSamplingProcessor(&Config{SamplingPercentage: 50, SamplingPrecision: 4, FailClosed: failClosed})
+		assert.Equal(t, !failClosed, sp.sampleSpan(ptrace.NewSpan()))
+		assert.Equal(t, !failClosed, sp.sampleLogRecord(plog.NewLogRecord()))
+	}
+}
+
+func TestProcessTraces(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SamplingPercentage = 50
+	sink := new(consumertest.TracesSink)
+	tp, err := factory.CreateTraces(context.Background(), processortest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	dropped := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	dropped.SetTraceID(traceIDWithRandomness(0x10000000000000))
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	ss.Spans().AppendEmpty().SetTraceID(traceIDWithRandomness(0x90000000000000))
+	ss.Spans().AppendEmpty().SetTraceID(traceIDWithRandomness(0x20000000000000))
+	ss.Spans().AppendEmpty().SetTraceID(traceIDWithRandomness(0xf0000000000000))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0]
+	require.Equal(t, 1, got.ResourceSpans().Len())
+	spans := got.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	assert.Equal(t, traceIDWithRandomness(0x90000000000000), spans.At(0).TraceID())
+	assert.Equal(t, "ot=th:8", spans.At(0).TraceState().AsRaw())
+	assert.Equal(t, traceIDWithRandomness(0xf0000000000000), spans.At(1).TraceID())
+
+	// The data is not passed to the next consumer when all of it is dropped.
+	td = ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(traceIDWithRandomness(0))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestProcessLogs(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SamplingPercentage = 50
+	cfg.FailClosed = false
+	sink := new(consumertest.LogsSink)
+	lp, err := factory.CreateLogs(context.Background(), processortest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().SetTraceID(traceIDWithRandomness(0x90000000000000))
+	lrs.AppendEmpty().SetTraceID(traceIDWithRandomness(0x20000000000000))
+	lrs.AppendEmpty().Body().SetStr("without trace ID")
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetTraceID(traceIDWithRandomness(0))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, sink.AllLogs(), 1)
+	got := sink.AllLogs()[0]
+	require.Equal(t, 1, got.ResourceLogs().Len())
+	records := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assert.Equal(t, traceIDWithRandomness(0x90000000000000), records.At(0).TraceID())
+	assert.Equal(t, "without trace ID", records.At(1).Body().Str())
+}
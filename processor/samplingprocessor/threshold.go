@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor // import "go.opentelemetry.io/collector/processor/samplingprocessor"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// The sampling decision follows the consistent probability sampling of the OpenTelemetry specification,
+// see https://opentelemetry.io/docs/specs/otel/trace/tracestate-probability-sampling/: an item is kept
+// when its 56 bits of randomness are greater than or equal to the rejection threshold.
+const (
+	// randomnessBits is the number of bits of the randomness and of the threshold.
+	randomnessBits = 56
+	// maxThreshold is the threshold rejecting all the items, it cannot be encoded in the tracestate.
+	maxThreshold = uint64(1) << randomnessBits
+	// thresholdDigits is the maximum number of hexadecimal digits of the threshold.
+	thresholdDigits = randomnessBits / 4
+)
+
+// probabilityToThreshold returns the rejection threshold of the given sampling probability, rounded
+// to the given number of hexadecimal digits, not counting the leading `f` digits.
+func probabilityToThreshold(probability float64, precision int) uint64 {
+	if probability <= 0 {
+		return maxThreshold
+	}
+	if probability >= 1 {
+		return 0
+	}
+	// Computed from the accepted randomness values so that the small probabilities are not lost by
+	// the floating point subtraction.
+	accepted := max(uint64(math.Round(probability*float64(maxThreshold))), 1)
+	threshold := maxThreshold - accepted
+
+	digits := precision
+	for i := thresholdDigits - 1; i >= 0 && (threshold>>(4*i))&0xf == 0xf; i-- {
+		digits++
+	}
+	if digits >= thresholdDigits {
+		return threshold
+	}
+	// The first digit after the leading `f` digits is not `f`, so rounding up cannot overflow.
+	shift := 4 * (thresholdDigits - digits)
+	return (threshold + 1<<(shift-1)) >> shift << shift
+}
+
+// parseThreshold parses the `th` value of the tracestate, made of 1 to 14 hexadecimal digits
+// without the trailing zeros.
+func parseThreshold(s string) (uint64, bool) {
+	if s == "" || len(s) > thresholdDigits {
+		return 0, false
+	}
+	threshold, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, false
+	}
+	return threshold << (4 * (thresholdDigits - len(s))), true
+}
+
+// encodeThreshold returns the `th` value of the tracestate for the given threshold.
+func encodeThreshold(threshold uint64) string {
+	s := strings.TrimRight(fmt.Sprintf("%0*x", thresholdDigits, threshold), "0")
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// parseRandomness parses the `rv` value of the tracestate, made of exactly 14 hexadecimal digits.
+func parseRandomness(s string) (uint64, bool) {
+	if len(s) != thresholdDigits {
+		return 0, false
+	}
+	randomness, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, false
+	}
+	return randomness, true
+}
+
+// traceIDRandomness returns the randomness of the trace ID, its 56 least significant bits.
+func traceIDRandomness(traceID pcommon.TraceID) (uint64, bool) {
+	if traceID.IsEmpty() {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(traceID[8:]) & (maxThreshold - 1), true
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestProbabilityToThreshold(t *testing.T) {
+	tests := []struct {
+		name        string
+		probability float64
+		precision   int
+		expected    string
+	}{
+		{name: "all", probability: 1, precision: 4, expected: "0"},
+		{name: "half", probability: 0.5, precision: 4, expected: "8"},
+		{name: "quarter", probability: 0.25, precision: 4, expected: "c"},
+		{name: "tenth", probability: 0.1, precision: 4, expected: "e666"},
+		{name: "tenth_rounded_up", probability: 0.1, precision: 1, expected: "e"},
+		{name: "tenth_full_precision", probability: 0.1, precision: 14, expected: "e6666666666666"},
+		{name: "ninety_nine_percent", probability: 0.99, precision: 4, expected: "028f"},
+		// The leading f digits are not counted in the precision.
+		{name: "one_percent", probability: 0.01, precision: 4, expected: "fd70a"},
+		{name: "one_in_a_million", probability: 1e-6, precision: 3, expected: "ffffef4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, encodeThreshold(probabilityToThreshold(tt.probability, tt.precision)))
+		})
+	}
+	assert.Equal(t, maxThreshold, probabilityToThreshold(0, 4))
+	assert.Less(t, probabilityToThreshold(1e-20, 4), maxThreshold)
+}
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected uint64
+		valid    bool
+	}{
+		{value: "0", expected: 0, valid: true},
+		{value: "8", expected: 1 << 55, valid: true},
+		{value: "c", expected: 3 << 54, valid: true},
+		{value: "fffffffffffff", expected: maxThreshold - 1<<4, valid: true},
+		{value: "ffffffffffffff", expected: maxThreshold - 1, valid: true},
+		{value: ""},
+		{value: "fffffffffffffff"},
+		{value: "g"},
+		{value: "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			threshold, valid := parseThreshold(tt.value)
+			assert.Equal(t, tt.valid, valid)
+			assert.Equal(t, tt.expected, threshold)
+			if valid {
+				assert.Equal(t, tt.value, encodeThreshold(threshold))
+			}
+		})
+	}
+}
+
+func TestParseRandomness(t *testing.T) {
+	randomness, ok := parseRandomness("6e6d1a75832a2f")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0x6e6d1a75832a2f), randomness)
+
+	_, ok = parseRandomness("6e6d1a75832a2")
+	assert.False(t, ok)
+	_, ok = parseRandomness("6e6d1a75832a2g")
+	assert.False(t, ok)
+}
+
+func TestTraceIDRandomness(t *testing.T) {
+	randomness, ok := traceIDRandomness(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0x0a0b0c0d0e0f10), randomness)
+
+	_, ok = traceIDRandomness(pcommon.NewTraceIDEmpty())
+	assert.False(t, ok)
+}
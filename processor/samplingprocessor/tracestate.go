@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor // import "go.opentelemetry.io/collector/processor/samplingprocessor"
+
+import (
+	"strings"
+)
+
+const (
+	// otKey is the key of the OpenTelemetry entry of the W3C tracestate,
+	// see https://opentelemetry.io/docs/specs/otel/trace/tracestate-handling/.
+	otKey = "ot"
+
+	thresholdKey  = "th"
+	randomnessKey = "rv"
+)
+
+// otTraceState holds the sampling values of the OpenTelemetry entry of a tracestate.
+// The invalid values are ignored.
+type otTraceState struct {
+	threshold     uint64
+	hasThreshold  bool
+	randomness    uint64
+	hasRandomness bool
+}
+
+// parseTraceState parses the sampling values of the given W3C tracestate.
+func parseTraceState(raw string) otTraceState {
+	var ts otTraceState
+	ot, _ := splitTraceState(raw)
+	for _, field := range strings.Split(ot, ";") {
+		key, value, _ := strings.Cut(field, ":")
+		switch key {
+		case thresholdKey:
+			ts.threshold, ts.hasThreshold = parseThreshold(value)
+		case randomnessKey:
+			ts.randomness, ts.hasRandomness = parseRandomness(value)
+		}
+	}
+	return ts
+}
+
+// withThreshold returns the given W3C tracestate with the `th` value of the OpenTelemetry entry
+// replaced by the given threshold. As it is modified, the entry is moved to the beginning of the list.
+func withThreshold(raw string, threshold uint64) string {
+	ot, others := splitTraceState(raw)
+	fields := []string{thresholdKey + ":" + encodeThreshold(threshold)}
+	for _, field := range strings.Split(ot, ";") {
+		if field != "" && !strings.HasPrefix(field, thresholdKey+":") {
+			fields = append(fields, field)
+		}
+	}
+	return strings.Join(append([]string{otKey + "=" + strings.Join(fields, ";")}, others...), ",")
+}
+
+// splitTraceState returns the value of the OpenTelemetry entry of the given W3C tracestate,
+// and the other entries.
+func splitTraceState(raw string) (string, []string) {
+	var ot string
+	var others []string
+	for _, member := range strings.Split(raw, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		if value, ok := strings.CutPrefix(member, otKey+"="); ok {
+			ot = value
+			continue
+		}
+		others = append(others, member)
+	}
+	return ot, others
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceState(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected otTraceState
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "other_vendors",
+			raw:  "vendor1=value1,vendor2=value2",
+		},
+		{
+			name:     "threshold",
+			raw:      "ot=th:c",
+			expected: otTraceState{threshold: 3 << 54, hasThreshold: true},
+		},
+		{
+			name: "threshold_and_randomness",
+			raw:  "vendor1=value1, ot=p:8;th:8;rv:6e6d1a75832a2f , vendor2=value2",
+			expected: otTraceState{
+				threshold:     1 << 55,
+				hasThreshold:  true,
+				randomness:    0x6e6d1a75832a2f,
+				hasRandomness: true,
+			},
+		},
+		{
+			name: "invalid_values",
+			raw:  "ot=th:xyz;rv:123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseTraceState(tt.raw))
+		})
+	}
+}
+
+func TestWithThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{
+			name:     "empty",
+			expected: "ot=th:c",
+		},
+		{
+			name:     "other_vendors",
+			raw:      "vendor1=value1,vendor2=value2",
+			expected: "ot=th:c,vendor1=value1,vendor2=value2",
+		},
+		{
+			name:     "replaced_threshold",
+			raw:      "vendor1=value1, ot=p:8;th:8;rv:6e6d1a75832a2f",
+			expected: "ot=th:c;p:8;rv:6e6d1a75832a2f,vendor1=value1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, withThreshold(tt.raw, 3<<54))
+		})
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/gogo/protobuf/proto"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/xpdata"
 )
 
 const (
@@ -21,8 +23,17 @@ const (
 	jsonContentType = "application/json"
 )
 
+var useStreamingProtoDecoderFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"receiver.otlp.useStreamingProtoDecoder",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.126.0"),
+	featuregate.WithRegisterDescription("When enabled, the OTLP/HTTP protobuf requests of traces, metrics and logs are "+
+		"unmarshaled by a streaming decoder allocating less memory than the generated code."),
+)
+
 var (
 	pbEncoder       = &protoEncoder{}
+	pbUnmarshaler   = &xpdata.StreamingProtoUnmarshaler{}
 	jsEncoder       = &jsonEncoder{}
 	jsonPbMarshaler = &jsonpb.Marshaler{}
 )
@@ -46,18 +57,27 @@ type encoder interface {
 type protoEncoder struct{}
 
 func (protoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+	if useStreamingProtoDecoderFeatureGate.IsEnabled() {
+		return pbUnmarshaler.UnmarshalTracesExportRequest(buf)
+	}
 	req := ptraceotlp.NewExportRequest()
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
 func (protoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+	if useStreamingProtoDecoderFeatureGate.IsEnabled() {
+		return pbUnmarshaler.UnmarshalMetricsExportRequest(buf)
+	}
 	req := pmetricotlp.NewExportRequest()
 	err := req.UnmarshalProto(buf)
 	return req, err
 }
 
 func (protoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
+	if useStreamingProtoDecoderFeatureGate.IsEnabled() {
+		return pbUnmarshaler.UnmarshalLogsExportRequest(buf)
+	}
 	req := plogotlp.NewExportRequest()
 	err := req.UnmarshalProto(buf)
 	return req, err
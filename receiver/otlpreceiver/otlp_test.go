@@ -40,6 +40,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -412,6 +413,30 @@ func TestProtoHttp(t *testing.T) {
 	}
 }
 
+func TestProtoHttpStreamingDecoder(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(useStreamingProtoDecoderFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(useStreamingProtoDecoderFeatureGate.ID(), false))
+	}()
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	for _, dr := range generateDataRequests(t) {
+		sink.Reset()
+		url := "http://" + addr + dr.path
+		respBytes := doHTTPRequest(t, url, "", "application/x-protobuf", dr.protoBytes, 0)
+		tr := ptraceotlp.NewExportResponse()
+		require.NoError(t, tr.UnmarshalProto(respBytes))
+		sink.checkData(t, dr.data, 1)
+
+		doHTTPRequest(t, url, "", "application/x-protobuf", []byte("+$%"), http.StatusBadRequest)
+	}
+}
+
 func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -112,6 +112,11 @@ func (b *ProcessorBuilder) Factory(componentType component.Type) component.Facto
 	return b.factories[componentType]
 }
 
+// Config returns the configuration of the component with the given ID, or nil if it is not configured.
+func (b *ProcessorBuilder) Config(componentID component.ID) component.Config {
+	return b.cfgs[componentID]
+}
+
 // NewNopProcessorConfigsAndFactories returns a configuration and factories that allows building a new nop processor.
 func NewNopProcessorConfigsAndFactories() (map[component.ID]component.Config, map[component.Type]processor.Factory) {
 	nopFactory := processortest.NewNopFactory()
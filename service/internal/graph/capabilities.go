@@ -4,8 +4,15 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"context"
+	"sync/atomic"
+
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/attribute"
 )
@@ -17,14 +24,16 @@ var _ consumerNode = (*capabilitiesNode)(nil)
 // 1. Present aggregated capabilities to receivers, such as whether the pipeline mutates data.
 // 2. Present a consistent "first consumer" for each pipeline.
 // The nodeID is derived from "pipeline ID".
+//
+// The node is preserved when the graph is reloaded, so the receivers keep emitting to it
+// while the rest of the pipeline is replaced.
 type capabilitiesNode struct {
 	attribute.Attributes
 	pipelineID pipeline.ID
-	baseConsumer
-	consumer.ConsumeTracesFunc
-	consumer.ConsumeMetricsFunc
-	consumer.ConsumeLogsFunc
-	xconsumer.ConsumeProfilesFunc
+	// next holds the baseConsumer created with the capabilityconsumer for the pipeline signal.
+	next atomic.Pointer[baseConsumer]
+	// mutatesData is the aggregated capability of the pipeline.
+	mutatesData atomic.Bool
 }
 
 func newCapabilitiesNode(pipelineID pipeline.ID) *capabilitiesNode {
@@ -34,6 +43,46 @@ func newCapabilitiesNode(pipelineID pipeline.ID) *capabilitiesNode {
 	}
 }
 
+// getPipelineID returns the pipeline of the node, it is used by the connectors to route the data.
+func (n *capabilitiesNode) getPipelineID() pipeline.ID {
+	return n.pipelineID
+}
+
 func (n *capabilitiesNode) getConsumer() baseConsumer {
 	return n
 }
+
+func (n *capabilitiesNode) setConsumer(next baseConsumer) {
+	n.next.Store(&next)
+}
+
+func (n *capabilitiesNode) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: n.mutatesData.Load()}
+}
+
+// capabilitiesView is a capabilities node with the capabilities of its pipeline once reloaded. It is used by
+// the components built while reloading the graph, since the node is still used by the running graph.
+type capabilitiesView struct {
+	*capabilitiesNode
+	mutatesData bool
+}
+
+func (v capabilitiesView) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: v.mutatesData}
+}
+
+func (n *capabilitiesNode) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return (*n.next.Load()).(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (n *capabilitiesNode) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return (*n.next.Load()).(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (n *capabilitiesNode) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return (*n.next.Load()).(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+func (n *capabilitiesNode) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return (*n.next.Load()).(xconsumer.Profiles).ConsumeProfiles(ctx, pd)
+}
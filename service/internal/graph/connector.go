@@ -76,7 +76,7 @@ func (n *connectorNode) buildTraces(
 ) error {
 	consumers := make(map[pipeline.ID]consumer.Traces, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Traces)
+		consumers[next.(interface{ getPipelineID() pipeline.ID }).getPipelineID()] = next.(consumer.Traces)
 	}
 	next := connector.NewTracesRouter(consumers)
 
@@ -111,7 +111,7 @@ func (n *connectorNode) buildMetrics(
 ) error {
 	consumers := make(map[pipeline.ID]consumer.Metrics, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Metrics)
+		consumers[next.(interface{ getPipelineID() pipeline.ID }).getPipelineID()] = next.(consumer.Metrics)
 	}
 	next := connector.NewMetricsRouter(consumers)
 
@@ -146,7 +146,7 @@ func (n *connectorNode) buildLogs(
 ) error {
 	consumers := make(map[pipeline.ID]consumer.Logs, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Logs)
+		consumers[next.(interface{ getPipelineID() pipeline.ID }).getPipelineID()] = next.(consumer.Logs)
 	}
 	next := connector.NewLogsRouter(consumers)
 
@@ -181,7 +181,7 @@ func (n *connectorNode) buildProfiles(
 ) error {
 	consumers := make(map[pipeline.ID]xconsumer.Profiles, len(nexts))
 	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = next.(xconsumer.Profiles)
+		consumers[next.(interface{ getPipelineID() pipeline.ID }).getPipelineID()] = next.(xconsumer.Profiles)
 	}
 	next := xconnector.NewProfilesRouter(consumers)
 
@@ -66,29 +66,45 @@ type Graph struct {
 	// Keep track of status source per node
 	instanceIDs map[int64]*componentstatus.InstanceID
 
+	// The consumers and capabilities of the capabilities nodes to set once the rest of the pipeline is built.
+	pendingCapabilities map[int64]baseConsumer
+	pendingMutatesData  map[int64]bool
+
+	// The settings the graph was built with, used to detect the changed components when the graph is reloaded.
+	settings Settings
+
 	telemetry component.TelemetrySettings
 }
 
 // Build builds a full pipeline graph.
 // Build also validates the configuration of the pipelines and does the actual initialization of each Component in the Graph.
 func Build(ctx context.Context, set Settings) (*Graph, error) {
-	pipelines := &Graph{
-		componentGraph: simple.NewDirectedGraph(),
-		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
-		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
-		telemetry:      set.Telemetry,
+	pipelines := newGraph(set)
+	if err := pipelines.createNodes(set); err != nil {
+		return nil, err
+	}
+	pipelines.createEdges()
+	return pipelines, pipelines.buildComponents(ctx, set)
+}
+
+// newGraph creates an empty graph for the given settings.
+func newGraph(set Settings) *Graph {
+	g := &Graph{
+		componentGraph:      simple.NewDirectedGraph(),
+		pipelines:           make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
+		instanceIDs:         make(map[int64]*componentstatus.InstanceID),
+		pendingCapabilities: make(map[int64]baseConsumer),
+		pendingMutatesData:  make(map[int64]bool),
+		settings:            set,
+		telemetry:           set.Telemetry,
 	}
 	for pipelineID := range set.PipelineConfigs {
-		pipelines.pipelines[pipelineID] = &pipelineNodes{
+		g.pipelines[pipelineID] = &pipelineNodes{
 			receivers: make(map[int64]graph.Node),
 			exporters: make(map[int64]graph.Node),
 		}
 	}
-	if err := pipelines.createNodes(set); err != nil {
-		return nil, err
-	}
-	pipelines.createEdges()
-	return pipelines, pipelines.buildComponents(ctx, set)
+	return g
 }
 
 // Creates a node for each instance of a component and adds it to the graph.
@@ -296,79 +312,89 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	}
 
 	for i := len(nodes) - 1; i >= 0; i-- {
-		node := nodes[i]
+		if err = g.buildComponent(ctx, set, nodes[i]); err != nil {
+			return err
+		}
+		if n, ok := nodes[i].(*capabilitiesNode); ok {
+			g.applyCapabilities(n)
+		}
+	}
+	return nil
+}
 
-		switch n := node.(type) {
-		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
-		case *processorNode:
-			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
-		case *exporterNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
-		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
-		case *capabilitiesNode:
-			capability := consumer.Capabilities{
-				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
-				MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
+// applyCapabilities sets the pending consumer and capabilities of the capabilities node.
+func (g *Graph) applyCapabilities(n *capabilitiesNode) {
+	n.mutatesData.Store(g.pendingMutatesData[n.ID()])
+	n.setConsumer(g.pendingCapabilities[n.ID()])
+	delete(g.pendingMutatesData, n.ID())
+	delete(g.pendingCapabilities, n.ID())
+}
+
+// buildComponent instantiates the component of a single node. The next consumers of the node must already be built.
+// The consumer and capabilities of a capabilities node are not set, but kept in pendingCapabilities and
+// pendingMutatesData, since the node may be used by the running graph when it is reloaded.
+func (g *Graph) buildComponent(ctx context.Context, set Settings, node graph.Node) error {
+	var err error
+	switch n := node.(type) {
+	case *receiverNode:
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+	case *processorNode:
+		// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+	case *exporterNode:
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
+	case *connectorNode:
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+	case *capabilitiesNode:
+		capability := consumer.Capabilities{
+			// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
+			MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
+		}
+		for _, proc := range g.pipelines[n.pipelineID].processors {
+			capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+		}
+		next := g.nextConsumers(n.ID())[0]
+		g.pendingMutatesData[n.ID()] = capability.MutatesData
+		switch n.pipelineID.Signal() {
+		case pipeline.SignalTraces:
+			g.pendingCapabilities[n.ID()] = capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
+		case pipeline.SignalMetrics:
+			g.pendingCapabilities[n.ID()] = capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
+		case pipeline.SignalLogs:
+			g.pendingCapabilities[n.ID()] = capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
+		case xpipeline.SignalProfiles:
+			g.pendingCapabilities[n.ID()] = capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
+		}
+	case *fanOutNode:
+		nexts := g.nextConsumers(n.ID())
+		switch n.pipelineID.Signal() {
+		case pipeline.SignalTraces:
+			consumers := make([]consumer.Traces, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Traces))
 			}
-			for _, proc := range g.pipelines[n.pipelineID].processors {
-				capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+			n.baseConsumer = fanoutconsumer.NewTraces(consumers)
+		case pipeline.SignalMetrics:
+			consumers := make([]consumer.Metrics, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Metrics))
 			}
-			next := g.nextConsumers(n.ID())[0]
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
-				n.baseConsumer = cc
-				n.ConsumeTracesFunc = cc.ConsumeTraces
-			case pipeline.SignalMetrics:
-				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
-				n.baseConsumer = cc
-				n.ConsumeMetricsFunc = cc.ConsumeMetrics
-			case pipeline.SignalLogs:
-				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
-				n.baseConsumer = cc
-				n.ConsumeLogsFunc = cc.ConsumeLogs
-			case xpipeline.SignalProfiles:
-				cc := capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
-				n.baseConsumer = cc
-				n.ConsumeProfilesFunc = cc.ConsumeProfiles
+			n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
+		case pipeline.SignalLogs:
+			consumers := make([]consumer.Logs, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Logs))
 			}
-		case *fanOutNode:
-			nexts := g.nextConsumers(n.ID())
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				consumers := make([]consumer.Traces, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Traces))
-				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
-			case pipeline.SignalMetrics:
-				consumers := make([]consumer.Metrics, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Metrics))
-				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
-			case pipeline.SignalLogs:
-				consumers := make([]consumer.Logs, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Logs))
-				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
-			case xpipeline.SignalProfiles:
-				consumers := make([]xconsumer.Profiles, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(xconsumer.Profiles))
-				}
-				n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
+			n.baseConsumer = fanoutconsumer.NewLogs(consumers)
+		case xpipeline.SignalProfiles:
+			consumers := make([]xconsumer.Profiles, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(xconsumer.Profiles))
 			}
-		}
-		if err != nil {
-			return err
+			n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
 		}
 	}
-	return nil
+	return err
 }
 
 // Find all nodes
@@ -376,7 +402,13 @@ func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		next := nextNodes.Node()
+		if mutatesData, pending := g.pendingMutatesData[next.ID()]; pending {
+			// The components built before the capabilities are set must see the new ones.
+			nexts = append(nexts, capabilitiesView{capabilitiesNode: next.(*capabilitiesNode), mutatesData: mutatesData})
+			continue
+		}
+		nexts = append(nexts, next.(consumerNode).getConsumer())
 	}
 	return nexts
 }
@@ -0,0 +1,452 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+// Reload updates the running graph to match the new settings. Only the components whose configuration or
+// connectivity changed are restarted, the other components keep running with their state intact.
+//
+// A component is kept if its configuration and the pipelines it belongs to are unchanged, and all the components
+// it emits to are kept as well. The receivers emit to the first node of each pipeline, which is preserved across
+// reloads, so they are kept even if the processors or exporters of the pipeline are replaced as long as the
+// pipeline still has the same capabilities. Since the instances of a component may share their state across
+// pipelines, a component is either kept or restarted in all the pipelines.
+//
+// The new components are built once without modifying the running graph. The new processors, exporters and
+// connectors are started first, then the preserved pipelines are switched to them and the replaced components
+// are shut down, upstream first. The new receivers are started last, since they may listen on the same endpoints
+// as the ones they replace.
+//
+// If the new graph cannot be built, or a new component other than a receiver fails to start, the running graph
+// is left untouched and the error is returned. If a new receiver fails to start, the error is returned and the
+// graph must be shut down.
+func (g *Graph) Reload(ctx context.Context, set Settings, host *Host) error {
+	if host == nil {
+		return errors.New("host cannot be nil")
+	}
+
+	newG, nodes, kept, err := g.buildReloaded(ctx, set)
+	if err != nil {
+		return err
+	}
+
+	// Start the new components except the receivers, downstream first, while the running graph still consumes.
+	var started []graph.Node
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		if _, ok := node.(*receiverNode); ok || !isNewComponent(node, kept) {
+			continue
+		}
+		if err = newG.startNode(ctx, host, node); err != nil {
+			return multierr.Append(err, newG.discard(ctx, host.Reporter, nodes, kept, started))
+		}
+		started = append(started, node)
+	}
+
+	// Switch the preserved pipelines to their new consumers.
+	for id := range newG.pendingCapabilities {
+		newG.applyCapabilities(newG.componentGraph.Node(id).(*capabilitiesNode))
+	}
+
+	// Shutdown the replaced components of the running graph, upstream first.
+	var errs error
+	oldNodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return err
+	}
+	for _, node := range oldNodes {
+		if _, ok := node.(component.Component); !ok || kept[node.ID()] {
+			continue
+		}
+		errs = multierr.Append(errs, g.shutdownNode(ctx, host.Reporter, node))
+	}
+
+	*g = *newG
+
+	// Start the new receivers.
+	for _, node := range nodes {
+		if _, ok := node.(*receiverNode); !ok || kept[node.ID()] {
+			continue
+		}
+		if err = g.startNode(ctx, host, node); err != nil {
+			return multierr.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// isNewComponent returns true if the node is a component that is not kept from the running graph.
+func isNewComponent(node graph.Node, kept map[int64]bool) bool {
+	_, ok := node.(component.Component)
+	return ok && !kept[node.ID()]
+}
+
+// discard shuts down the new components of a graph that replaces the running graph, upstream first.
+// The components that are not started are shut down without reporting their status.
+func (g *Graph) discard(ctx context.Context, reporter status.Reporter, nodes []graph.Node, kept map[int64]bool, started []graph.Node) error {
+	isStarted := make(map[int64]bool, len(started))
+	for _, node := range started {
+		isStarted[node.ID()] = true
+	}
+	var errs error
+	for _, node := range nodes {
+		if !isNewComponent(node, kept) {
+			continue
+		}
+		if isStarted[node.ID()] {
+			errs = multierr.Append(errs, g.shutdownNode(ctx, reporter, node))
+			continue
+		}
+		// The nodes are built downstream first, so some may not be built if the build failed.
+		if comp := builtComponent(node); comp != nil {
+			errs = multierr.Append(errs, comp.Shutdown(ctx))
+		}
+	}
+	return errs
+}
+
+// builtComponent returns the component of the node, or nil if it is not built.
+func builtComponent(node graph.Node) component.Component {
+	switch n := node.(type) {
+	case *receiverNode:
+		return n.Component
+	case *processorNode:
+		return n.Component
+	case *exporterNode:
+		return n.Component
+	case *connectorNode:
+		return n.Component
+	}
+	return nil
+}
+
+// componentKey identifies all the instances of a component in the graph.
+type componentKey struct {
+	kind component.Kind
+	id   component.ID
+}
+
+func nodeComponentKey(node graph.Node) (componentKey, bool) {
+	switch n := node.(type) {
+	case *receiverNode:
+		return componentKey{kind: component.KindReceiver, id: n.componentID}, true
+	case *processorNode:
+		return componentKey{kind: component.KindProcessor, id: n.componentID}, true
+	case *exporterNode:
+		return componentKey{kind: component.KindExporter, id: n.componentID}, true
+	case *connectorNode:
+		return componentKey{kind: component.KindConnector, id: n.componentID}, true
+	}
+	return componentKey{}, false
+}
+
+// changedComponents returns the components that have at least one instance that is not kept.
+func (g *Graph) changedComponents(newG *Graph, kept map[int64]bool) map[componentKey]struct{} {
+	changed := make(map[componentKey]struct{})
+	for _, cg := range []*Graph{g, newG} {
+		nodes := cg.componentGraph.Nodes()
+		for nodes.Next() {
+			if key, ok := nodeComponentKey(nodes.Node()); ok && !kept[nodes.Node().ID()] {
+				changed[key] = struct{}{}
+			}
+		}
+	}
+	// Only the components that are kept somewhere need to be restarted.
+	for key := range changed {
+		keptSomewhere := false
+		nodes := newG.componentGraph.Nodes()
+		for nodes.Next() {
+			if nodeKey, ok := nodeComponentKey(nodes.Node()); ok && nodeKey == key && kept[nodes.Node().ID()] {
+				keptSomewhere = true
+				break
+			}
+		}
+		if !keptSomewhere {
+			delete(changed, key)
+		}
+	}
+	return changed
+}
+
+// buildReloaded builds the graph for the new settings, reusing the unchanged components of the running graph.
+// It returns the new graph, its nodes in topological order and the kept nodes. The running graph is not modified,
+// the consumers and capabilities of its preserved capabilities nodes are pending in the new graph.
+func (g *Graph) buildReloaded(ctx context.Context, set Settings) (*Graph, []graph.Node, map[int64]bool, error) {
+	newG := newGraph(set)
+	if err := newG.createNodes(set); err != nil {
+		return nil, nil, nil, err
+	}
+	// Preserve the first node of the existing pipelines, so the kept receivers and connectors emit to the new ones.
+	for pipelineID, pipe := range newG.pipelines {
+		if oldPipe, ok := g.pipelines[pipelineID]; ok {
+			pipe.capabilitiesNode = oldPipe.capabilitiesNode
+		}
+	}
+	newG.createEdges()
+
+	nodes, err := topo.Sort(newG.componentGraph)
+	if err != nil {
+		return nil, nil, nil, cycleErr(err, topo.DirectedCyclesIn(newG.componentGraph))
+	}
+
+	// Build the new components, downstream first, reusing the unchanged ones. The receivers are decided last,
+	// once the capabilities of the pipelines are known.
+	kept := g.keptComponents(newG, nodes)
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		if _, ok := node.(*receiverNode); ok {
+			continue
+		}
+		if kept[node.ID()] {
+			newG.reuse(g, node)
+			continue
+		}
+		if err = newG.buildComponent(ctx, set, node); err != nil {
+			return nil, nil, nil, multierr.Append(err, newG.discard(ctx, nil, nodes, kept, nil))
+		}
+		if n, ok := node.(*capabilitiesNode); ok && g.componentGraph.Node(n.ID()) == nil {
+			// The node of a new pipeline is not used by any running component yet.
+			newG.applyCapabilities(n)
+		}
+	}
+
+	g.keepReceivers(newG, nodes, kept)
+	for _, node := range nodes {
+		if _, ok := node.(*receiverNode); !ok {
+			continue
+		}
+		if kept[node.ID()] {
+			newG.reuse(g, node)
+			continue
+		}
+		if err = newG.buildComponent(ctx, set, node); err != nil {
+			return nil, nil, nil, multierr.Append(err, newG.discard(ctx, nil, nodes, kept, nil))
+		}
+	}
+	return newG, nodes, kept, nil
+}
+
+// keptComponents returns the nodes of the new graph, except the receivers, whose component can be kept from
+// the running graph. A component is restarted in all the pipelines if any of its instances cannot be kept.
+// The connectors are only kept if the pipelines they emit to are kept entirely, since their capabilities are
+// only known once built.
+func (g *Graph) keptComponents(newG *Graph, nodes []graph.Node) map[int64]bool {
+	restarted := make(map[componentKey]bool)
+	for {
+		kept := make(map[int64]bool)
+		for i := len(nodes) - 1; i >= 0; i-- {
+			node := nodes[i]
+			if _, ok := node.(*receiverNode); ok {
+				continue
+			}
+			if key, ok := nodeComponentKey(node); ok && restarted[key] {
+				continue
+			}
+			if newG.canReuse(g, node, kept) {
+				kept[node.ID()] = true
+			}
+		}
+		partial := false
+		for key := range g.changedComponents(newG, kept) {
+			if !restarted[key] {
+				restarted[key] = true
+				partial = true
+			}
+		}
+		if !partial {
+			return kept
+		}
+	}
+}
+
+// keepReceivers adds the receivers of the new graph whose component can be kept from the running graph to kept.
+// The receivers do not emit to other components, so restarting all the instances of a receiver does not change
+// the other kept components.
+func (g *Graph) keepReceivers(newG *Graph, nodes []graph.Node, kept map[int64]bool) {
+	for _, node := range nodes {
+		if _, ok := node.(*receiverNode); ok && newG.canReuse(g, node, kept) {
+			kept[node.ID()] = true
+		}
+	}
+	for key := range g.changedComponents(newG, kept) {
+		for _, node := range nodes {
+			if nodeKey, ok := nodeComponentKey(node); ok && nodeKey == key {
+				delete(kept, node.ID())
+			}
+		}
+	}
+}
+
+// canReuse checks if the component of the node can be reused from the old graph. The nodes the node emits to
+// must already be processed, the kept nodes are marked in the kept map.
+func (g *Graph) canReuse(old *Graph, node graph.Node, kept map[int64]bool) bool {
+	if old.componentGraph.Node(node.ID()) == nil {
+		return false
+	}
+
+	switch n := node.(type) {
+	case *capabilitiesNode:
+		// The node is preserved, its consumer is replaced only if the next node changed.
+		next := g.componentGraph.From(n.ID())
+		return next.Next() && kept[next.Node().ID()]
+	case *fanOutNode:
+		return g.sameNextNodes(old, node, kept)
+	}
+
+	if !g.sameNextNodes(old, node, kept) || !samePipelines(old.instanceIDs[node.ID()], g.instanceIDs[node.ID()]) {
+		return false
+	}
+	switch n := node.(type) {
+	case *receiverNode:
+		return sameConfig(old.settings.ReceiverBuilder.Config(n.componentID), g.settings.ReceiverBuilder.Config(n.componentID))
+	case *processorNode:
+		return sameConfig(old.settings.ProcessorBuilder.Config(n.componentID), g.settings.ProcessorBuilder.Config(n.componentID))
+	case *exporterNode:
+		return sameConfig(old.settings.ExporterBuilder.Config(n.componentID), g.settings.ExporterBuilder.Config(n.componentID))
+	case *connectorNode:
+		return sameConfig(old.settings.ConnectorBuilder.Config(n.componentID), g.settings.ConnectorBuilder.Config(n.componentID))
+	}
+	return false
+}
+
+// reuse reuses the component or consumer of the node from the old graph.
+func (g *Graph) reuse(old *Graph, node graph.Node) {
+	oldNode := old.componentGraph.Node(node.ID())
+	switch n := node.(type) {
+	case *capabilitiesNode:
+		// The node is preserved with its consumer.
+		return
+	case *fanOutNode:
+		n.baseConsumer = oldNode.(*fanOutNode).baseConsumer
+		return
+	case *receiverNode:
+		n.Component = oldNode.(*receiverNode).Component
+	case *processorNode:
+		n.Component = oldNode.(*processorNode).Component
+	case *exporterNode:
+		n.Component = oldNode.(*exporterNode).Component
+	case *connectorNode:
+		n.Component = oldNode.(*connectorNode).Component
+	}
+	// Keep reporting the status with the same instance.
+	g.instanceIDs[node.ID()] = old.instanceIDs[node.ID()]
+}
+
+// sameNextNodes checks if the node emits to the same nodes in both graphs, and that all of them are kept.
+// The receivers can also emit to a preserved pipeline that is not kept, as long as its capabilities are unchanged,
+// since the receivers decide if the data must be cloned when they are created.
+func (g *Graph) sameNextNodes(old *Graph, node graph.Node, kept map[int64]bool) bool {
+	nexts := g.componentGraph.From(node.ID())
+	if nexts.Len() != old.componentGraph.From(node.ID()).Len() {
+		return false
+	}
+	_, isReceiver := node.(*receiverNode)
+	for nexts.Next() {
+		next := nexts.Node()
+		if !old.componentGraph.HasEdgeFromTo(node.ID(), next.ID()) {
+			return false
+		}
+		if kept[next.ID()] {
+			continue
+		}
+		capNode, ok := next.(*capabilitiesNode)
+		if !isReceiver || !ok || g.pendingMutatesData[capNode.ID()] != old.pipelineMutatesData(capNode.pipelineID) {
+			return false
+		}
+	}
+	return true
+}
+
+// pipelineMutatesData returns the capabilities of the pipeline as computed by the old graph.
+func (g *Graph) pipelineMutatesData(pipelineID pipeline.ID) bool {
+	pipe := g.pipelines[pipelineID]
+	mutatesData := pipe.fanOutNode.getConsumer().Capabilities().MutatesData
+	for _, proc := range pipe.processors {
+		mutatesData = mutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+	}
+	return mutatesData
+}
+
+func sameConfig(oldCfg, newCfg component.Config) bool {
+	return oldCfg != nil && reflect.DeepEqual(oldCfg, newCfg)
+}
+
+func samePipelines(oldID, newID *componentstatus.InstanceID) bool {
+	pipelineIDs := func(id *componentstatus.InstanceID) map[pipeline.ID]struct{} {
+		ids := make(map[pipeline.ID]struct{})
+		id.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+			ids[pipelineID] = struct{}{}
+			return true
+		})
+		return ids
+	}
+	return reflect.DeepEqual(pipelineIDs(oldID), pipelineIDs(newID))
+}
+
+func (g *Graph) startNode(ctx context.Context, host *Host, node graph.Node) error {
+	comp := node.(component.Component)
+	instanceID := g.instanceIDs[node.ID()]
+	host.Reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStarting),
+	)
+
+	if compErr := comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); compErr != nil {
+		host.Reporter.ReportStatus(
+			instanceID,
+			componentstatus.NewPermanentErrorEvent(compErr),
+		)
+		// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
+		g.telemetry.Logger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).
+			Error("Failed to start component",
+				zap.Error(compErr),
+				zap.String("type", instanceID.Kind().String()),
+				zap.String("id", instanceID.ComponentID().String()),
+			)
+		return fmt.Errorf("failed to start %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), compErr)
+	}
+
+	host.Reporter.ReportOKIfStarting(instanceID)
+	return nil
+}
+
+func (g *Graph) shutdownNode(ctx context.Context, reporter status.Reporter, node graph.Node) error {
+	comp := node.(component.Component)
+	instanceID := g.instanceIDs[node.ID()]
+	reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopping),
+	)
+
+	if compErr := comp.Shutdown(ctx); compErr != nil {
+		reporter.ReportStatus(
+			instanceID,
+			componentstatus.NewPermanentErrorEvent(compErr),
+		)
+		return compErr
+	}
+
+	reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopped),
+	)
+	return nil
+}
@@ -0,0 +1,469 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+type reloadTestConfig struct {
+	Value string
+}
+
+var (
+	reloadRcvrID     = component.MustNewID("examplereceiver")
+	reloadRcvr1ID    = component.MustNewIDWithName("examplereceiver", "1")
+	reloadProcID     = component.MustNewID("exampleprocessor")
+	reloadMutateID   = component.MustNewIDWithName("exampleprocessor", "mutate")
+	reloadExpID      = component.MustNewID("exampleexporter")
+	reloadExp1ID     = component.MustNewIDWithName("exampleexporter", "1")
+	reloadConnID     = component.MustNewID("exampleconnector")
+	reloadTracesID   = pipeline.NewID(pipeline.SignalTraces)
+	reloadTraces1ID  = pipeline.NewIDWithName(pipeline.SignalTraces, "1")
+	reloadLogsID     = pipeline.NewID(pipeline.SignalLogs)
+	reloadMetricsID  = pipeline.NewID(pipeline.SignalMetrics)
+	reloadPipelines0 = pipelines.Config{
+		reloadTracesID: {
+			Receivers:  []component.ID{reloadRcvrID},
+			Processors: []component.ID{reloadProcID},
+			Exporters:  []component.ID{reloadExpID},
+		},
+		reloadLogsID: {
+			Receivers: []component.ID{reloadRcvr1ID},
+			Exporters: []component.ID{reloadExp1ID},
+		},
+	}
+)
+
+// newReloadSettings returns the settings for the pipelines, the components are configured with the given values.
+// Every call creates new configuration instances, as it happens when the configuration is reloaded.
+func newReloadSettings(pipelineConfigs pipelines.Config, values map[component.ID]string) Settings {
+	cfg := func(id component.ID) component.Config {
+		return &reloadTestConfig{Value: values[id]}
+	}
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				reloadRcvrID:  cfg(reloadRcvrID),
+				reloadRcvr1ID: cfg(reloadRcvr1ID),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				reloadProcID:   cfg(reloadProcID),
+				reloadMutateID: cfg(reloadMutateID),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				reloadExpID:  cfg(reloadExpID),
+				reloadExp1ID: cfg(reloadExp1ID),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			}),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{
+				reloadConnID: cfg(reloadConnID),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			}),
+		PipelineConfigs: pipelineConfigs,
+	}
+}
+
+func newReloadHost() *Host {
+	return &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+}
+
+type reloadComponents struct {
+	tracesRcvr *testcomponents.ExampleReceiver
+	logsRcvr   *testcomponents.ExampleReceiver
+	proc       *testcomponents.ExampleProcessor
+	tracesExp  *testcomponents.ExampleExporter
+	logsExp    *testcomponents.ExampleExporter
+}
+
+func getReloadComponents(t *testing.T, g *Graph) reloadComponents {
+	rc := reloadComponents{
+		tracesRcvr: g.getReceivers()[pipeline.SignalTraces][reloadRcvrID].(*testcomponents.ExampleReceiver),
+		logsRcvr:   g.getReceivers()[pipeline.SignalLogs][reloadRcvr1ID].(*testcomponents.ExampleReceiver),
+		tracesExp:  g.GetExporters()[pipeline.SignalTraces][reloadExpID].(*testcomponents.ExampleExporter),
+		logsExp:    g.GetExporters()[pipeline.SignalLogs][reloadExp1ID].(*testcomponents.ExampleExporter),
+	}
+	for _, proc := range g.pipelines[reloadTracesID].processors {
+		if proc.(*processorNode).componentID == reloadProcID {
+			rc.proc = proc.(*processorNode).Component.(*testcomponents.ExampleProcessor)
+		}
+	}
+	require.NotNil(t, rc.proc)
+	return rc
+}
+
+func TestGraphReload(t *testing.T) {
+	tests := []struct {
+		name      string
+		pipelines pipelines.Config
+		values    map[component.ID]string
+		// The components expected to be replaced by new instances.
+		replaced []string
+	}{
+		{
+			name:      "unchanged",
+			pipelines: reloadPipelines0,
+		},
+		{
+			name:      "processor_config_changed",
+			pipelines: reloadPipelines0,
+			values:    map[component.ID]string{reloadProcID: "changed"},
+			replaced:  []string{"proc"},
+		},
+		{
+			name:      "exporter_config_changed",
+			pipelines: reloadPipelines0,
+			values:    map[component.ID]string{reloadExpID: "changed"},
+			replaced:  []string{"proc", "tracesExp"},
+		},
+		{
+			name:      "receiver_config_changed",
+			pipelines: reloadPipelines0,
+			values:    map[component.ID]string{reloadRcvrID: "changed"},
+			replaced:  []string{"tracesRcvr"},
+		},
+		{
+			name: "exporter_added",
+			pipelines: pipelines.Config{
+				reloadTracesID: {
+					Receivers:  []component.ID{reloadRcvrID},
+					Processors: []component.ID{reloadProcID},
+					Exporters:  []component.ID{reloadExpID, reloadExp1ID},
+				},
+				reloadLogsID: reloadPipelines0[reloadLogsID],
+			},
+			// The exporter is restarted in the logs pipeline too.
+			replaced: []string{"proc", "logsExp"},
+		},
+		{
+			name: "mutating_processor_added",
+			pipelines: pipelines.Config{
+				reloadTracesID: {
+					Receivers:  []component.ID{reloadRcvrID},
+					Processors: []component.ID{reloadProcID, reloadMutateID},
+					Exporters:  []component.ID{reloadExpID},
+				},
+				reloadLogsID: reloadPipelines0[reloadLogsID],
+			},
+			// The receiver must be recreated since the pipeline now mutates the data.
+			replaced: []string{"tracesRcvr", "proc"},
+		},
+		{
+			name: "pipeline_added",
+			pipelines: pipelines.Config{
+				reloadTracesID: reloadPipelines0[reloadTracesID],
+				reloadLogsID:   reloadPipelines0[reloadLogsID],
+				reloadMetricsID: {
+					Receivers: []component.ID{reloadRcvr1ID},
+					Exporters: []component.ID{reloadExp1ID},
+				},
+			},
+			// The instances of a component are restarted together since they may share their state.
+			replaced: []string{"logsRcvr", "logsExp"},
+		},
+		{
+			name: "receiver_added_to_pipeline",
+			pipelines: pipelines.Config{
+				reloadTracesID: reloadPipelines0[reloadTracesID],
+				reloadTraces1ID: {
+					Receivers: []component.ID{reloadRcvrID},
+					Exporters: []component.ID{reloadExp1ID},
+				},
+				reloadLogsID: reloadPipelines0[reloadLogsID],
+			},
+			replaced: []string{"tracesRcvr", "logsExp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := newReloadHost()
+			g, err := Build(context.Background(), newReloadSettings(reloadPipelines0, nil))
+			require.NoError(t, err)
+			require.NoError(t, g.StartAll(context.Background(), host))
+			before := getReloadComponents(t, g)
+
+			require.NoError(t, g.Reload(context.Background(), newReloadSettings(tt.pipelines, tt.values), host))
+			after := getReloadComponents(t, g)
+
+			replaced := map[string]bool{}
+			for _, name := range tt.replaced {
+				replaced[name] = true
+			}
+			for name, pair := range map[string][2]interface {
+				Started() bool
+				Stopped() bool
+			}{
+				"tracesRcvr": {before.tracesRcvr, after.tracesRcvr},
+				"logsRcvr":   {before.logsRcvr, after.logsRcvr},
+				"proc":       {before.proc, after.proc},
+				"tracesExp":  {before.tracesExp, after.tracesExp},
+				"logsExp":    {before.logsExp, after.logsExp},
+			} {
+				if replaced[name] {
+					assert.NotSame(t, pair[0], pair[1], name)
+					assert.True(t, pair[0].Stopped(), name)
+				} else {
+					assert.Same(t, pair[0], pair[1], name)
+					assert.False(t, pair[0].Stopped(), name)
+				}
+				assert.True(t, pair[1].Started(), name)
+				assert.False(t, pair[1].Stopped(), name)
+			}
+
+			// The data still flows through the reloaded pipelines.
+			require.NoError(t, after.tracesRcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			assert.Len(t, after.tracesExp.Traces, 1)
+			require.NoError(t, after.logsRcvr.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+			assert.Len(t, after.logsExp.Logs, 1)
+
+			require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+			for _, c := range []interface{ Stopped() bool }{after.tracesRcvr, after.logsRcvr, after.proc, after.tracesExp, after.logsExp} {
+				assert.True(t, c.Stopped())
+			}
+		})
+	}
+}
+
+func TestGraphReloadPipelineRemoved(t *testing.T) {
+	host := newReloadHost()
+	g, err := Build(context.Background(), newReloadSettings(reloadPipelines0, nil))
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), host))
+	before := getReloadComponents(t, g)
+
+	require.NoError(t, g.Reload(context.Background(), newReloadSettings(pipelines.Config{
+		reloadTracesID: reloadPipelines0[reloadTracesID],
+	}, nil), host))
+	assert.True(t, before.logsRcvr.Stopped())
+	assert.True(t, before.logsExp.Stopped())
+	assert.False(t, before.tracesRcvr.Stopped())
+	assert.False(t, before.proc.Stopped())
+	assert.False(t, before.tracesExp.Stopped())
+	assert.Len(t, g.pipelines, 1)
+
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+	assert.True(t, before.tracesRcvr.Stopped())
+}
+
+func TestGraphReloadConnector(t *testing.T) {
+	host := newReloadHost()
+	pipelineConfigs := pipelines.Config{
+		reloadTracesID: {
+			Receivers: []component.ID{reloadRcvrID},
+			Exporters: []component.ID{reloadConnID},
+		},
+		reloadTraces1ID: {
+			Receivers:  []component.ID{reloadConnID},
+			Processors: []component.ID{reloadProcID},
+			Exporters:  []component.ID{reloadExpID},
+		},
+	}
+	g, err := Build(context.Background(), newReloadSettings(pipelineConfigs, nil))
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), host))
+	rcvr := g.getReceivers()[pipeline.SignalTraces][reloadRcvrID].(*testcomponents.ExampleReceiver)
+	conn := g.componentGraph.Node(newConnectorNode(pipeline.SignalTraces, pipeline.SignalTraces, reloadConnID).ID()).(*connectorNode).Component
+
+	// Changing the processor downstream of the connector restarts the connector, since the capabilities of the
+	// new processor are only known once built, but keeps the receiver since the capabilities are unchanged.
+	require.NoError(t, g.Reload(context.Background(), newReloadSettings(pipelineConfigs, map[component.ID]string{reloadProcID: "changed"}), host))
+	assert.Same(t, rcvr, g.getReceivers()[pipeline.SignalTraces][reloadRcvrID])
+	assert.NotEqual(t, conn, g.componentGraph.Node(newConnectorNode(pipeline.SignalTraces, pipeline.SignalTraces, reloadConnID).ID()).(*connectorNode).Component)
+	conn = g.componentGraph.Node(newConnectorNode(pipeline.SignalTraces, pipeline.SignalTraces, reloadConnID).ID()).(*connectorNode).Component
+
+	// Changing the exporter of the first pipeline keeps the connector and the downstream pipeline.
+	pipelineConfigs[reloadTracesID] = &pipelines.PipelineConfig{
+		Receivers: []component.ID{reloadRcvrID},
+		Exporters: []component.ID{reloadConnID, reloadExp1ID},
+	}
+	require.NoError(t, g.Reload(context.Background(), newReloadSettings(pipelineConfigs, map[component.ID]string{reloadProcID: "changed"}), host))
+	assert.Equal(t, conn, g.componentGraph.Node(newConnectorNode(pipeline.SignalTraces, pipeline.SignalTraces, reloadConnID).ID()).(*connectorNode).Component)
+
+	exp := g.GetExporters()[pipeline.SignalTraces][reloadExpID].(*testcomponents.ExampleExporter)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestGraphReloadBuildError(t *testing.T) {
+	host := newReloadHost()
+	g, err := Build(context.Background(), newReloadSettings(reloadPipelines0, nil))
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), host))
+	before := getReloadComponents(t, g)
+
+	// The processor is not configured, so the new graph cannot be built.
+	require.Error(t, g.Reload(context.Background(), newReloadSettings(pipelines.Config{
+		reloadTracesID: {
+			Receivers:  []component.ID{reloadRcvrID},
+			Processors: []component.ID{component.MustNewIDWithName("exampleprocessor", "unknown")},
+			Exporters:  []component.ID{reloadExpID},
+		},
+	}, map[component.ID]string{reloadExpID: "changed"}), host))
+
+	// The running graph is untouched.
+	after := getReloadComponents(t, g)
+	assert.Equal(t, before, after)
+	for _, c := range []interface{ Stopped() bool }{before.tracesRcvr, before.logsRcvr, before.proc, before.tracesExp, before.logsExp} {
+		assert.False(t, c.Stopped())
+	}
+	require.NoError(t, before.tracesRcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, before.tracesExp.Traces, 1)
+
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestGraphReloadBuildErrorKeepsCapabilities(t *testing.T) {
+	host := newReloadHost()
+	g, err := Build(context.Background(), newReloadSettings(reloadPipelines0, nil))
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), host))
+	before := getReloadComponents(t, g)
+
+	// The new traces pipeline mutates the data, but the receiver of the logs pipeline cannot be built.
+	require.Error(t, g.Reload(context.Background(), newReloadSettings(pipelines.Config{
+		reloadTracesID: {
+			Receivers:  []component.ID{reloadRcvrID},
+			Processors: []component.ID{reloadProcID, reloadMutateID},
+			Exporters:  []component.ID{reloadExpID},
+		},
+		reloadLogsID: {
+			Receivers: []component.ID{component.MustNewIDWithName("examplereceiver", "unknown")},
+			Exporters: []component.ID{reloadExp1ID},
+		},
+	}, nil), host))
+
+	// The preserved pipeline still has its capabilities and consumer.
+	assert.False(t, g.pipelines[reloadTracesID].capabilitiesNode.Capabilities().MutatesData)
+	require.NoError(t, before.tracesRcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, before.tracesExp.Traces, 1)
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestGraphReloadStartOrder(t *testing.T) {
+	host := newReloadHost()
+	exps := &recordingExporters{}
+	g, err := Build(context.Background(), exps.settings(newReloadSettings(reloadPipelines0, nil)))
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), host))
+
+	require.NoError(t, g.Reload(context.Background(), exps.settings(newReloadSettings(reloadPipelines0,
+		map[component.ID]string{reloadExpID: "changed"})), host))
+	// The new exporter is started before the replaced one is shut down.
+	assert.Equal(t, []string{"start 0", "start 1", "shutdown 0"}, exps.events)
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestGraphReloadStartError(t *testing.T) {
+	host := newReloadHost()
+	exps := &recordingExporters{}
+	g, err := Build(context.Background(), exps.settings(newReloadSettings(reloadPipelines0, nil)))
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), host))
+	rcvr := g.getReceivers()[pipeline.SignalTraces][reloadRcvrID].(*testcomponents.ExampleReceiver)
+	exp := g.GetExporters()[pipeline.SignalTraces][reloadExpID].(*recordingExporter)
+
+	exps.failStart = true
+	require.EqualError(t, g.Reload(context.Background(), exps.settings(newReloadSettings(reloadPipelines0,
+		map[component.ID]string{reloadExpID: "changed", reloadRcvrID: "changed"})), host),
+		`failed to start "exampleexporter" exporter: start error`)
+	// The new exporter is shut down, and the running graph is untouched.
+	assert.Equal(t, []string{"start 0", "shutdown 1"}, exps.events)
+	assert.Same(t, rcvr, g.getReceivers()[pipeline.SignalTraces][reloadRcvrID])
+	assert.Same(t, exp, g.GetExporters()[pipeline.SignalTraces][reloadExpID])
+	assert.False(t, rcvr.Stopped())
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces.(*testcomponents.ExampleExporter).Traces, 1)
+
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+}
+
+// recordingExporters creates the example traces exporters and records when they are started and shut down.
+type recordingExporters struct {
+	events    []string
+	count     int
+	failStart bool
+}
+
+func (r *recordingExporters) settings(set Settings) Settings {
+	f := testcomponents.ExampleExporterFactory
+	factory := exporter.NewFactory(f.Type(), f.CreateDefaultConfig,
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			exp, err := f.CreateTraces(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			r.count++
+			return &recordingExporter{Traces: exp, recorder: r, index: r.count - 1}, nil
+		}, f.TracesStability()),
+		exporter.WithLogs(f.CreateLogs, f.LogsStability()))
+	set.ExporterBuilder = builders.NewExporter(
+		map[component.ID]component.Config{
+			reloadExpID:  set.ExporterBuilder.Config(reloadExpID),
+			reloadExp1ID: set.ExporterBuilder.Config(reloadExp1ID),
+		},
+		map[component.Type]exporter.Factory{f.Type(): factory})
+	return set
+}
+
+type recordingExporter struct {
+	exporter.Traces
+	recorder *recordingExporters
+	index    int
+}
+
+func (e *recordingExporter) Start(ctx context.Context, host component.Host) error {
+	if e.recorder.failStart {
+		return errors.New("start error")
+	}
+	e.recorder.events = append(e.recorder.events, fmt.Sprintf("start %d", e.index))
+	return e.Traces.Start(ctx, host)
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error {
+	e.recorder.events = append(e.recorder.events, fmt.Sprintf("shutdown %d", e.index))
+	return e.Traces.Shutdown(ctx)
+}
+
+func TestGraphReloadNilHost(t *testing.T) {
+	g, err := Build(context.Background(), newReloadSettings(reloadPipelines0, nil))
+	require.NoError(t, err)
+	require.EqualError(t, g.Reload(context.Background(), newReloadSettings(reloadPipelines0, nil), nil), "host cannot be nil")
+}
@@ -349,6 +349,50 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// ReloadPipelines updates the pipelines to match the new configuration. Only the components whose configuration or
+// connectivity changed are restarted, the other components keep running with their state intact.
+// The telemetry and the extensions are not updated, the caller must restart the service if they changed.
+//
+// If the new pipelines cannot be built, the running pipelines are left untouched and the error is returned.
+// If a new component fails to start, the error is returned and the service must be shut down.
+func (srv *Service) ReloadPipelines(ctx context.Context, set Settings, cfg Config) error {
+	receivers := builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories)
+	processors := builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories)
+	exporters := builders.NewExporter(set.ExportersConfigs, set.ExportersFactories)
+	connectors := builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories)
+
+	srv.telemetrySettings.Logger.Info("Reloading pipelines...")
+	err := srv.host.Pipelines.Reload(ctx, graph.Settings{
+		Telemetry:        srv.telemetrySettings,
+		BuildInfo:        srv.buildInfo,
+		ReceiverBuilder:  receivers,
+		ProcessorBuilder: processors,
+		ExporterBuilder:  exporters,
+		ConnectorBuilder: connectors,
+		PipelineConfigs:  cfg.Pipelines,
+		ReportStatus:     srv.host.Reporter.ReportStatus,
+	}, srv.host)
+	if err != nil {
+		return fmt.Errorf("failed to reload pipelines: %w", err)
+	}
+
+	srv.host.Receivers = receivers
+	srv.host.Processors = processors
+	srv.host.Exporters = exporters
+	srv.host.Connectors = connectors
+	srv.host.ModuleInfos = set.ModuleInfos
+	srv.collectorConf = set.CollectorConf
+
+	if srv.collectorConf != nil {
+		if err = srv.host.ServiceExtensions.NotifyConfig(ctx, srv.collectorConf); err != nil {
+			return err
+		}
+	}
+
+	srv.telemetrySettings.Logger.Info("Pipelines reloaded.")
+	return nil
+}
+
 // Creates extensions.
 func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) error {
 	var err error
@@ -252,6 +252,32 @@ func TestServiceGetExporters(t *testing.T) {
 	assert.Contains(t, expMap[xpipeline.SignalProfiles], component.NewID(nopType))
 }
 
+func TestServiceReloadPipelines(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	// Invalid pipelines leave the running pipelines untouched.
+	invalidCfg := newNopConfig()
+	invalidCfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)].Processors[0] = component.MustNewID("invalid")
+	require.Error(t, srv.ReloadPipelines(context.Background(), newNopSettings(), invalidCfg))
+	//nolint:staticcheck
+	assert.Len(t, srv.host.GetExporters()[pipeline.SignalLogs], 1)
+
+	cfg := newNopConfig()
+	delete(cfg.Pipelines, pipeline.NewID(pipeline.SignalLogs))
+	require.NoError(t, srv.ReloadPipelines(context.Background(), newNopSettings(), cfg))
+
+	//nolint:staticcheck
+	expMap := srv.host.GetExporters()
+	assert.Empty(t, expMap[pipeline.SignalLogs])
+	assert.Len(t, expMap[pipeline.SignalTraces], 1)
+}
+
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
 func TestServiceTelemetryCleanupOnError(t *testing.T) {